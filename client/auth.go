@@ -0,0 +1,15 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// WithFirebaseToken authenticates every request as the user the Firebase ID token belongs to. Staff use it for
+// the /admin routes too.
+func WithFirebaseToken(token string) ClientOption {
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("X-firebase-token", token)
+		return nil
+	})
+}
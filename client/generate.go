@@ -0,0 +1,8 @@
+// Package client is a typed Go client for the REST API, generated from server/openapi.json.
+//
+// Regenerate it after changing the document:
+//
+//	go generate ./client
+package client
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen -config oapi-codegen.yaml ../server/openapi.json
@@ -0,0 +1,455 @@
+package main
+
+// A local stand-in for a Stellar anchor, good enough to exercise the server's SEP-1, SEP-10, SEP-6 and SEP-24
+// client without talking to a real partner. Nothing is checked on-chain: transfers are moved along by hand
+// with POST /admin/complete?id=<transaction id>.
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	b "github.com/stellar/go/build"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
+)
+
+const kChallengeLifetime time.Duration = 5 * time.Minute
+const kTokenLifetime time.Duration = time.Hour
+
+var (
+	ErrBadToken     = errors.New("Invalid or expired token.")
+	ErrBadChallenge = errors.New("Challenge transaction is invalid.")
+	ErrNotFound     = errors.New("Transaction not found.")
+)
+
+type Transaction struct {
+	Id                    string `json:"id"`
+	Kind                  string `json:"kind"`
+	Status                string `json:"status"`
+	Account               string `json:"-"`
+	AmountIn              string `json:"amount_in,omitempty"`
+	WithdrawAnchorAccount string `json:"withdraw_anchor_account,omitempty"`
+	WithdrawMemo          string `json:"withdraw_memo,omitempty"`
+	WithdrawMemoType      string `json:"withdraw_memo_type,omitempty"`
+	StartedAt             string `json:"started_at"`
+}
+
+var publicUrl string
+var passphrase string
+var signingKey *keypair.Full
+var jwtSecret []byte
+
+var store = struct {
+	sync.Mutex
+	nextId       int
+	transactions map[string]*Transaction
+}{nextId: 1, transactions: make(map[string]*Transaction)}
+
+func newTransaction(kind string, status string, account string, amount string) *Transaction {
+	store.Lock()
+	defer store.Unlock()
+
+	tx := &Transaction{
+		Id:        strconv.Itoa(store.nextId),
+		Kind:      kind,
+		Status:    status,
+		Account:   account,
+		AmountIn:  amount,
+		StartedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	store.nextId++
+	store.transactions[tx.Id] = tx
+	copied := *tx
+	return &copied
+}
+
+func transactionForId(id string) (*Transaction, error) {
+	store.Lock()
+	defer store.Unlock()
+
+	tx, ok := store.transactions[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *tx
+	return &copied, nil
+}
+
+// Moves a transaction to where the customer is expected to send funds. Withdrawals get an account and memo.
+func readyForFunds(id string) (*Transaction, error) {
+	store.Lock()
+	defer store.Unlock()
+
+	tx, ok := store.transactions[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	tx.Status = "pending_user_transfer_start"
+	if tx.Kind == "withdrawal" {
+		tx.WithdrawAnchorAccount = signingKey.Address()
+		tx.WithdrawMemoType = "text"
+		tx.WithdrawMemo = "withdraw-" + tx.Id
+	}
+	copied := *tx
+	return &copied, nil
+}
+
+func setStatus(id string, status string) error {
+	store.Lock()
+	defer store.Unlock()
+
+	tx, ok := store.transactions[id]
+	if !ok {
+		return ErrNotFound
+	}
+	tx.Status = status
+	return nil
+}
+
+func writeJson(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJson(w, status, map[string]string{"error": err.Error()})
+}
+
+func StellarToml(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	fmt.Fprintf(w, "NETWORK_PASSPHRASE=%q\n", passphrase)
+	fmt.Fprintf(w, "SIGNING_KEY=%q\n", signingKey.Address())
+	fmt.Fprintf(w, "WEB_AUTH_ENDPOINT=%q\n", publicUrl+"/auth")
+	fmt.Fprintf(w, "TRANSFER_SERVER=%q\n", publicUrl+"/sep6")
+	fmt.Fprintf(w, "TRANSFER_SERVER_SEP0024=%q\n", publicUrl+"/sep24")
+}
+
+// SEP-10: hands out a challenge transaction for the account to sign.
+func GetChallenge(w http.ResponseWriter, r *http.Request) {
+	account := r.URL.Query().Get("account")
+	if _, err := keypair.Parse(account); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	nonce := make([]byte, 48)
+	if _, err := rand.Read(nonce); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	now := time.Now()
+	tx, err := b.Transaction(
+		b.SourceAccount{AddressOrSeed: signingKey.Seed()},
+		b.Sequence{Sequence: 0},
+		b.Network{Passphrase: passphrase},
+		b.Timebounds{MinTime: uint64(now.Unix()), MaxTime: uint64(now.Add(kChallengeLifetime).Unix())},
+		b.SetData("fake anchor auth", []byte(base64.StdEncoding.EncodeToString(nonce)), b.SourceAccount{AddressOrSeed: account}),
+	)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	txe, err := tx.Sign(signingKey.Seed())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	txeB64, err := txe.Base64()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJson(w, http.StatusOK, map[string]string{"transaction": txeB64, "network_passphrase": passphrase})
+}
+
+// SEP-10: checks the signed challenge and issues a JWT for the client account.
+func PostChallenge(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Transaction string `json:"transaction"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var env xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(body.Transaction, &env); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	account, err := verifySignedChallenge(&env)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err)
+		return
+	}
+
+	writeJson(w, http.StatusOK, map[string]string{"token": issueToken(account)})
+}
+
+func verifySignedChallenge(env *xdr.TransactionEnvelope) (string, error) {
+	if env.Tx.SeqNum != 0 || env.Tx.SourceAccount.Address() != signingKey.Address() || len(env.Tx.Operations) != 1 {
+		return "", ErrBadChallenge
+	}
+
+	op := env.Tx.Operations[0]
+	if op.SourceAccount == nil || op.Body.Type != xdr.OperationTypeManageData {
+		return "", ErrBadChallenge
+	}
+
+	if env.Tx.TimeBounds == nil || time.Now().Unix() > int64(env.Tx.TimeBounds.MaxTime) {
+		return "", ErrBadChallenge
+	}
+
+	account := op.SourceAccount.Address()
+	client, err := keypair.Parse(account)
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := network.HashTransaction(&env.Tx, passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	anchorSigned, clientSigned := false, false
+	for _, sig := range env.Signatures {
+		if signingKey.Verify(hash[:], sig.Signature) == nil {
+			anchorSigned = true
+		}
+		if client.Verify(hash[:], sig.Signature) == nil {
+			clientSigned = true
+		}
+	}
+
+	if !anchorSigned || !clientSigned {
+		return "", ErrBadChallenge
+	}
+	return account, nil
+}
+
+func sign(input string) string {
+	mac := hmac.New(sha256.New, jwtSecret)
+	mac.Write([]byte(input))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func issueToken(account string) string {
+	now := time.Now()
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	claims, _ := json.Marshal(map[string]interface{}{
+		"iss": publicUrl + "/auth",
+		"sub": account,
+		"iat": now.Unix(),
+		"exp": now.Add(kTokenLifetime).Unix(),
+	})
+	payload := base64.RawURLEncoding.EncodeToString(claims)
+	return header + "." + payload + "." + sign(header+"."+payload)
+}
+
+// Returns the account the bearer token was issued to.
+func authenticate(r *http.Request) (string, error) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || !hmac.Equal([]byte(sign(parts[0]+"."+parts[1])), []byte(parts[2])) {
+		return "", ErrBadToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", ErrBadToken
+	}
+
+	var claims struct {
+		Sub string `json:"sub"`
+		Exp int64  `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || time.Now().Unix() > claims.Exp {
+		return "", ErrBadToken
+	}
+	return claims.Sub, nil
+}
+
+func withAuth(handler func(w http.ResponseWriter, r *http.Request, account string)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		account, err := authenticate(r)
+		if err != nil {
+			writeError(w, http.StatusForbidden, err)
+			return
+		}
+		handler(w, r, account)
+	}
+}
+
+// SEP-6 GET /withdraw: non-interactive, so the funds can be sent straight away.
+func Sep6Withdraw(w http.ResponseWriter, r *http.Request, account string) {
+	tx, err := readyForFunds(newTransaction("withdrawal", "incomplete", account, r.URL.Query().Get("amount")).Id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJson(w, http.StatusOK, map[string]interface{}{
+		"id":         tx.Id,
+		"account_id": tx.WithdrawAnchorAccount,
+		"memo_type":  tx.WithdrawMemoType,
+		"memo":       tx.WithdrawMemo,
+		"eta":        600,
+	})
+}
+
+// SEP-6 GET /deposit: tells the customer how to hand over cash.
+func Sep6Deposit(w http.ResponseWriter, r *http.Request, account string) {
+	tx := newTransaction("deposit", "pending_user_transfer_start", account, r.URL.Query().Get("amount"))
+
+	writeJson(w, http.StatusOK, map[string]interface{}{
+		"id":  tx.Id,
+		"how": "Pay " + tx.AmountIn + " at any partner counter quoting reference " + tx.Id + ".",
+		"eta": 600,
+	})
+}
+
+// SEP-24 POST /transactions/{deposit,withdraw}/interactive
+func Sep24Interactive(kind string) func(w http.ResponseWriter, r *http.Request, account string) {
+	return func(w http.ResponseWriter, r *http.Request, account string) {
+		r.ParseForm()
+		tx := newTransaction(kind, "incomplete", account, r.Form.Get("amount"))
+
+		writeJson(w, http.StatusOK, map[string]string{
+			"type": "interactive_customer_info_needed",
+			"url":  publicUrl + "/sep24/interactive?id=" + tx.Id,
+			"id":   tx.Id,
+		})
+	}
+}
+
+var interactiveTemplate = template.Must(template.New("interactive").Parse(`<!DOCTYPE html>
+<html><body>
+<h1>Fake anchor {{.Kind}} #{{.Id}}</h1>
+<form method="post">
+<label>Full name <input name="name" required></label>
+<button type="submit">Continue</button>
+</form>
+</body></html>`))
+
+// The page shown in the borrower's webview. Submitting it is all the "KYC" we need.
+func Sep24InteractivePage(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	tx, err := transactionForId(id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	if r.Method == "POST" {
+		if _, err := readyForFunds(id); err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		fmt.Fprintln(w, "Thanks, you can close this window.")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	interactiveTemplate.Execute(w, tx)
+}
+
+func GetTransaction(w http.ResponseWriter, r *http.Request, account string) {
+	tx, err := transactionForId(r.URL.Query().Get("id"))
+	if err != nil || tx.Account != account {
+		writeError(w, http.StatusNotFound, ErrNotFound)
+		return
+	}
+	writeJson(w, http.StatusOK, map[string]*Transaction{"transaction": tx})
+}
+
+// Stands in for the cash actually changing hands.
+func Complete(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		status = "completed"
+	}
+
+	if err := setStatus(r.URL.Query().Get("id"), status); err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	writeJson(w, http.StatusOK, map[string]bool{"success": true})
+}
+
+func main() {
+	addr := flag.String("addr", ":8000", "Address to listen on")
+	flag.StringVar(&publicUrl, "url", "http://localhost:8000", "URL the anchor is reachable at, used in stellar.toml")
+	flag.StringVar(&passphrase, "network", network.TestNetworkPassphrase, "Network passphrase")
+	seed := flag.String("seed", "", "Signing key seed; a random key is used if empty")
+	flag.Parse()
+
+	if *seed == "" {
+		full, err := keypair.Random()
+		if err != nil {
+			log.Fatal(err)
+		}
+		signingKey = full
+	} else {
+		kp, err := keypair.Parse(*seed)
+		if err != nil {
+			log.Fatal(err)
+		}
+		full, ok := kp.(*keypair.Full)
+		if !ok {
+			log.Fatal("-seed must be a secret seed")
+		}
+		signingKey = full
+	}
+
+	jwtSecret = make([]byte, 32)
+	if _, err := rand.Read(jwtSecret); err != nil {
+		log.Fatal(err)
+	}
+
+	log.Println("Signing key:", signingKey.Address())
+
+	http.HandleFunc("/.well-known/stellar.toml", StellarToml)
+	http.HandleFunc("/auth", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			PostChallenge(w, r)
+		} else {
+			GetChallenge(w, r)
+		}
+	})
+	http.HandleFunc("/sep6/withdraw", withAuth(Sep6Withdraw))
+	http.HandleFunc("/sep6/deposit", withAuth(Sep6Deposit))
+	http.HandleFunc("/sep6/transaction", withAuth(GetTransaction))
+	http.HandleFunc("/sep24/transactions/withdraw/interactive", withAuth(Sep24Interactive("withdrawal")))
+	http.HandleFunc("/sep24/transactions/deposit/interactive", withAuth(Sep24Interactive("deposit")))
+	http.HandleFunc("/sep24/interactive", Sep24InteractivePage)
+	http.HandleFunc("/sep24/transaction", withAuth(GetTransaction))
+	http.HandleFunc("/admin/complete", Complete)
+
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
@@ -0,0 +1,13 @@
+#!/usr/bin/env bash
+
+# Installing required deps
+go get github.com/stellar/go/build
+go get
+
+# Autolint in place
+go fmt
+
+# Building binary
+go build || exit $
+
+./fake_anchor "$@"
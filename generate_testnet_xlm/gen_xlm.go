@@ -1,113 +1,368 @@
 package main
 
+// Treasury tool for the Stellar accounts behind the server. Run with no arguments for usage.
+
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"io/ioutil"
 	"log"
-	"net/http"
+	"os"
+	"strconv"
+	"strings"
 
 	b "github.com/stellar/go/build"
 	"github.com/stellar/go/clients/horizon"
 	"github.com/stellar/go/keypair"
+
+	"github.com/OneDaijo/sbc-demo-backend/stellarutil"
 )
 
-func sendTransaction(tx *b.TransactionBuilder, from *string) error {
+// Issuer of the PHP asset we pay Bloom in.
+const kPhpIssuer string = "GCBEJ5SNCV4B3E2TEDEUNR7DSC7Y4RLFAGSPNKZGNIOHQFWBHXCMMHZA"
+
+// QIN is issued by the server account.
+const kQinCode string = "QIN"
+
+var network stellarutil.Network
+var seedFile string
+var keystorePath string
+
+const usage = `Usage: gen_xlm [-network testnet|standalone] [-horizon URL] [-seed-file PATH | -keystore PATH] <command> [flags]
+
+Commands:
+  create    Create and fund a new account with friendbot, optionally sweeping XLM to the server account
+  balances  Show balances, signers and thresholds of accounts (default: the server account)
+  trust     Add or remove a trustline for PHP, QIN or CODE:ISSUER
+  merge     Merge an account into another one
+  multisig  Add or remove signers and set thresholds on the server account
+`
+
+func fail(err error) {
+	log.Fatal(err)
+}
+
+// Signs for the server account, from its keystore if given or else its seed file.
+func serverSigner() stellarutil.Signer {
+	if keystorePath != "" {
+		creds, err := stellarutil.CredentialsFromEnv()
+		if err != nil {
+			fail(err)
+		}
+		signer, err := stellarutil.OpenKeystore(keystorePath, creds)
+		if err != nil {
+			fail(err)
+		}
+		return signer
+	}
 
-	txe, err := tx.Sign(*from)
+	seedBytes, err := ioutil.ReadFile(seedFile)
 	if err != nil {
-		return err
+		fail(err)
 	}
+	return seedSigner(strings.TrimSpace(string(seedBytes)))
+}
+
+func seedSigner(seed string) stellarutil.Signer {
+	signer, err := stellarutil.SeedSigner(seed)
+	if err != nil {
+		fail(err)
+	}
+	return signer
+}
 
-	txeB64, err := txe.Base64()
+// Resolves PHP and QIN shortcuts, or CODE:ISSUER.
+func parseAsset(asset string) (string, string, error) {
+	switch asset {
+	case "PHP":
+		return "PHP", kPhpIssuer, nil
+	case kQinCode:
+		return kQinCode, serverSigner().Address(), nil
+	}
 
+	parts := strings.Split(asset, ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("Invalid asset %q, expected PHP, QIN or CODE:ISSUER", asset)
+	}
+	return parts[0], parts[1], nil
+}
+
+func submit(hc *horizon.Client, signers []stellarutil.Signer, muts ...b.TransactionMutator) {
+	tx, err := b.Transaction(muts...)
 	if err != nil {
-		return err
+		fail(err)
 	}
 
-	resp, err := horizon.DefaultTestNetClient.SubmitTransaction(fmt.Sprintf("%s", txeB64))
+	resp, err := stellarutil.SubmitTransaction(hc, tx, signers...)
 	if err != nil {
-		fmt.Println(err)
-		herr, isHorizonError := err.(*horizon.Error)
-		if isHorizonError {
-			resultCodes, err := herr.ResultCodes()
-			if err != nil {
-				fmt.Println("failed to extract result codes from horizon response")
-				return err
-			}
-			fmt.Println(resultCodes)
-		}
-		return err
+		fail(err)
 	}
 
 	fmt.Println("transaction posted in ledger:", resp.Ledger)
+}
 
-	return nil
+func printAccount(hc *horizon.Client, address string) {
+	account, err := hc.LoadAccount(address)
+	if err != nil {
+		fail(stellarutil.DecodeError(err))
+	}
 
+	fmt.Println("Account:", address)
+	fmt.Println("  Balances:")
+	for _, balance := range account.Balances {
+		if balance.Asset.Type == "native" {
+			fmt.Printf("    XLM %s\n", balance.Balance)
+		} else {
+			fmt.Printf("    %s:%s %s (limit %s)\n", balance.Asset.Code, balance.Asset.Issuer, balance.Balance, balance.Limit)
+		}
+	}
+	fmt.Println("  Signers:")
+	for _, signer := range account.Signers {
+		fmt.Printf("    %s weight %d\n", signer.PublicKey, signer.Weight)
+	}
+	fmt.Printf("  Thresholds: low %d, medium %d, high %d\n", account.Thresholds.LowThreshold, account.Thresholds.MedThreshold, account.Thresholds.HighThreshold)
 }
 
-func main() {
+func create(args []string) {
+	fs := flag.NewFlagSet("create", flag.ExitOnError)
+	sweep := fs.String("sweep", "", "Amount of XLM to send on to the server account, e.g. 9990")
+	fs.Parse(args)
+
 	pair, err := keypair.Random()
 	if err != nil {
-		log.Fatal(err)
+		fail(err)
 	}
 
 	log.Println(pair.Seed())
 	log.Println(pair.Address())
 
-	seed_bytes, err := ioutil.ReadFile("../server/stellar_seed.txt")
-	if err != nil {
-		panic(err)
+	if err := network.Friendbot(pair.Address()); err != nil {
+		fail(err)
 	}
-	seed_string := string(seed_bytes)
 
-	to, err := keypair.Parse(seed_string)
-	if err != nil {
-		panic(err)
+	hc := network.Client()
+
+	if *sweep != "" {
+		to := serverSigner().Address()
+		submit(hc, []stellarutil.Signer{seedSigner(pair.Seed())},
+			b.SourceAccount{AddressOrSeed: pair.Address()},
+			network.Mutator(),
+			b.AutoSequence{SequenceProvider: hc},
+			b.Payment(
+				b.Destination{AddressOrSeed: to},
+				b.NativeAmount{Amount: *sweep},
+			),
+		)
+		printAccount(hc, to)
+		return
 	}
-	// pair is the pair that was generated from previous example, or create a pair based on
-	// existing keys.
-	address := pair.Address()
-	resp, err := http.Get("https://horizon-testnet.stellar.org/friendbot?addr=" + address)
-	if err != nil {
-		log.Fatal(err)
+
+	printAccount(hc, pair.Address())
+}
+
+func balances(args []string) {
+	fs := flag.NewFlagSet("balances", flag.ExitOnError)
+	fs.Parse(args)
+
+	hc := network.Client()
+
+	addresses := fs.Args()
+	if len(addresses) == 0 {
+		addresses = []string{serverSigner().Address()}
+	}
+
+	for _, address := range addresses {
+		// Seeds are accepted too so that stellar_seed.txt can be passed directly.
+		kp, err := keypair.Parse(address)
+		if err != nil {
+			fail(err)
+		}
+		printAccount(hc, kp.Address())
+	}
+}
+
+func trust(args []string) {
+	fs := flag.NewFlagSet("trust", flag.ExitOnError)
+	seed := fs.String("seed", "", "Seed of the account adding the trustline")
+	asset := fs.String("asset", "", "PHP, QIN or CODE:ISSUER")
+	limit := fs.String("limit", "", "Trustline limit; the maximum if empty")
+	remove := fs.Bool("remove", false, "Remove the trustline instead")
+	fs.Parse(args)
+
+	if *seed == "" || *asset == "" {
+		fs.Usage()
+		os.Exit(2)
 	}
 
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
+	code, issuer, err := parseAsset(*asset)
 	if err != nil {
-		log.Fatal(err)
-	}
-	fmt.Println(string(body))
-
-	tx, err := b.Transaction(
-		b.SourceAccount{AddressOrSeed: pair.Address()},
-		b.TestNetwork,
-		b.AutoSequence{SequenceProvider: horizon.DefaultTestNetClient},
-		b.Payment(
-			b.Destination{AddressOrSeed: to.Address()},
-			b.NativeAmount{Amount: "9990.0"},
-		),
+		fail(err)
+	}
+
+	account := seedSigner(*seed)
+	hc := network.Client()
+
+	var op b.TransactionMutator
+	if *remove {
+		op = b.RemoveTrust(code, issuer)
+	} else if *limit != "" {
+		op = b.Trust(code, issuer, b.Limit(*limit))
+	} else {
+		op = b.Trust(code, issuer)
+	}
+
+	submit(hc, []stellarutil.Signer{account},
+		b.SourceAccount{AddressOrSeed: account.Address()},
+		network.Mutator(),
+		b.AutoSequence{SequenceProvider: hc},
+		op,
 	)
+	printAccount(hc, account.Address())
+}
 
-	if err != nil {
-		panic(err)
+func merge(args []string) {
+	fs := flag.NewFlagSet("merge", flag.ExitOnError)
+	seed := fs.String("seed", "", "Seed of the account to merge away")
+	into := fs.String("into", "", "Account that receives the remaining XLM; the server account if empty")
+	fs.Parse(args)
+
+	if *seed == "" {
+		fs.Usage()
+		os.Exit(2)
 	}
-	signing_seed := pair.Seed()
 
-	err = sendTransaction(tx, &signing_seed)
+	destination := *into
+	if destination == "" {
+		destination = serverSigner().Address()
+	}
+
+	account := seedSigner(*seed)
+	hc := network.Client()
 
+	submit(hc, []stellarutil.Signer{account},
+		b.SourceAccount{AddressOrSeed: account.Address()},
+		network.Mutator(),
+		b.AutoSequence{SequenceProvider: hc},
+		b.AccountMerge(b.Destination{AddressOrSeed: destination}),
+	)
+	printAccount(hc, destination)
+}
+
+func parseWeight(value string) uint32 {
+	weight, err := strconv.ParseUint(value, 10, 8)
 	if err != nil {
-		panic(err)
+		fail(fmt.Errorf("Invalid weight %q", value))
 	}
+	return uint32(weight)
+}
 
-	account, err := horizon.DefaultTestNetClient.LoadAccount(to.Address())
-	if err != nil {
-		log.Fatal(err)
+func multisig(args []string) {
+	fs := flag.NewFlagSet("multisig", flag.ExitOnError)
+	add := fs.String("add", "", "Signer to add, as ADDRESS:WEIGHT")
+	remove := fs.String("remove", "", "Signer address to remove")
+	master := fs.String("master", "", "New weight of the master key")
+	thresholds := fs.String("thresholds", "", "New thresholds as LOW:MEDIUM:HIGH")
+	cosigners := fs.String("cosign", "", "Comma separated seeds of additional signers needed to reach the threshold")
+	fs.Parse(args)
+
+	// Stellar allows a single signer change per set_options operation.
+	if *add != "" && *remove != "" {
+		fail(errors.New("Add and remove signers in separate runs"))
 	}
 
-	fmt.Println("Balances for account:", to.Address())
+	account := serverSigner()
+	signers := []stellarutil.Signer{account}
+	if *cosigners != "" {
+		for _, seed := range strings.Split(*cosigners, ",") {
+			signers = append(signers, seedSigner(seed))
+		}
+	}
 
-	for _, balance := range account.Balances {
-		log.Println(balance)
+	var options []interface{}
+
+	if *add != "" {
+		parts := strings.Split(*add, ":")
+		if len(parts) != 2 {
+			fail(fmt.Errorf("Invalid signer %q, expected ADDRESS:WEIGHT", *add))
+		}
+		options = append(options, b.AddSigner(parts[0], parseWeight(parts[1])))
+	}
+
+	if *remove != "" {
+		options = append(options, b.RemoveSigner(*remove))
+	}
+
+	if *master != "" {
+		options = append(options, b.MasterWeight(parseWeight(*master)))
+	}
+
+	if *thresholds != "" {
+		parts := strings.Split(*thresholds, ":")
+		if len(parts) != 3 {
+			fail(fmt.Errorf("Invalid thresholds %q, expected LOW:MEDIUM:HIGH", *thresholds))
+		}
+		options = append(options, b.SetThresholds(parseWeight(parts[0]), parseWeight(parts[1]), parseWeight(parts[2])))
+	}
+
+	if len(options) == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	hc := network.Client()
+
+	submit(hc, signers,
+		b.SourceAccount{AddressOrSeed: account.Address()},
+		network.Mutator(),
+		b.AutoSequence{SequenceProvider: hc},
+		b.SetOptions(options...),
+	)
+	printAccount(hc, account.Address())
+}
+
+func main() {
+	networkName := flag.String("network", stellarutil.TestNetwork.Name, "Network to use: testnet or standalone")
+	horizonURL := flag.String("horizon", "", "Horizon URL, overriding the network default")
+	flag.StringVar(&seedFile, "seed-file", "../server/stellar_seed.txt", "File holding the server account seed")
+	flag.StringVar(&keystorePath, "keystore", "", "Keystore holding the server account's signing key, used instead of -seed-file")
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	var err error
+	network, err = stellarutil.NetworkForName(*networkName)
+	if err != nil || network.Name == stellarutil.PublicNetwork.Name {
+		fail(fmt.Errorf("Unsupported network %q", *networkName))
+	}
+	if *horizonURL != "" {
+		network.HorizonURL = *horizonURL
+		if network.FriendbotURL != "" {
+			network.FriendbotURL = *horizonURL + "/friendbot"
+		}
+	}
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	args := flag.Args()[1:]
+	switch flag.Arg(0) {
+	case "create":
+		create(args)
+	case "balances":
+		balances(args)
+	case "trust":
+		trust(args)
+	case "merge":
+		merge(args)
+	case "multisig":
+		multisig(args)
+	default:
+		flag.Usage()
+		os.Exit(2)
 	}
 }
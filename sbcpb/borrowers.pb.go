@@ -0,0 +1,1914 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: borrowers.proto
+
+package sbcpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EmploymentInfo struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	EmploymentStatus     string                 `protobuf:"bytes,1,opt,name=employment_status,json=employmentStatus,proto3" json:"employment_status,omitempty"` // EMPLOYED, UNEMPLOYED or STUDENT
+	EmploymentJobTitle   string                 `protobuf:"bytes,2,opt,name=employment_job_title,json=employmentJobTitle,proto3" json:"employment_job_title,omitempty"`
+	EmploymentStartMonth *int64                 `protobuf:"varint,3,opt,name=employment_start_month,json=employmentStartMonth,proto3,oneof" json:"employment_start_month,omitempty"`
+	EmploymentStartYear  *int64                 `protobuf:"varint,4,opt,name=employment_start_year,json=employmentStartYear,proto3,oneof" json:"employment_start_year,omitempty"`
+	EmploymentIncome     *float64               `protobuf:"fixed64,5,opt,name=employment_income,json=employmentIncome,proto3,oneof" json:"employment_income,omitempty"`
+	EmploymentEducation  string                 `protobuf:"bytes,6,opt,name=employment_education,json=employmentEducation,proto3" json:"employment_education,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *EmploymentInfo) Reset() {
+	*x = EmploymentInfo{}
+	mi := &file_borrowers_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmploymentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmploymentInfo) ProtoMessage() {}
+
+func (x *EmploymentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_borrowers_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmploymentInfo.ProtoReflect.Descriptor instead.
+func (*EmploymentInfo) Descriptor() ([]byte, []int) {
+	return file_borrowers_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EmploymentInfo) GetEmploymentStatus() string {
+	if x != nil {
+		return x.EmploymentStatus
+	}
+	return ""
+}
+
+func (x *EmploymentInfo) GetEmploymentJobTitle() string {
+	if x != nil {
+		return x.EmploymentJobTitle
+	}
+	return ""
+}
+
+func (x *EmploymentInfo) GetEmploymentStartMonth() int64 {
+	if x != nil && x.EmploymentStartMonth != nil {
+		return *x.EmploymentStartMonth
+	}
+	return 0
+}
+
+func (x *EmploymentInfo) GetEmploymentStartYear() int64 {
+	if x != nil && x.EmploymentStartYear != nil {
+		return *x.EmploymentStartYear
+	}
+	return 0
+}
+
+func (x *EmploymentInfo) GetEmploymentIncome() float64 {
+	if x != nil && x.EmploymentIncome != nil {
+		return *x.EmploymentIncome
+	}
+	return 0
+}
+
+func (x *EmploymentInfo) GetEmploymentEducation() string {
+	if x != nil {
+		return x.EmploymentEducation
+	}
+	return ""
+}
+
+type ResidenceInfo struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ResidenceAddr1    string                 `protobuf:"bytes,1,opt,name=residence_addr1,json=residenceAddr1,proto3" json:"residence_addr1,omitempty"`
+	ResidenceAddr2    string                 `protobuf:"bytes,2,opt,name=residence_addr2,json=residenceAddr2,proto3" json:"residence_addr2,omitempty"`
+	ResidenceDistrict string                 `protobuf:"bytes,3,opt,name=residence_district,json=residenceDistrict,proto3" json:"residence_district,omitempty"`
+	ResidenceCity     string                 `protobuf:"bytes,4,opt,name=residence_city,json=residenceCity,proto3" json:"residence_city,omitempty"`
+	ResidencePostal   string                 `protobuf:"bytes,5,opt,name=residence_postal,json=residencePostal,proto3" json:"residence_postal,omitempty"`
+	ResidenceProvince string                 `protobuf:"bytes,6,opt,name=residence_province,json=residenceProvince,proto3" json:"residence_province,omitempty"`
+	ResidenceStatus   string                 `protobuf:"bytes,7,opt,name=residence_status,json=residenceStatus,proto3" json:"residence_status,omitempty"` // own or rent
+	ResidenceRentAmt  *float64               `protobuf:"fixed64,8,opt,name=residence_rent_amt,json=residenceRentAmt,proto3,oneof" json:"residence_rent_amt,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ResidenceInfo) Reset() {
+	*x = ResidenceInfo{}
+	mi := &file_borrowers_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResidenceInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResidenceInfo) ProtoMessage() {}
+
+func (x *ResidenceInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_borrowers_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResidenceInfo.ProtoReflect.Descriptor instead.
+func (*ResidenceInfo) Descriptor() ([]byte, []int) {
+	return file_borrowers_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ResidenceInfo) GetResidenceAddr1() string {
+	if x != nil {
+		return x.ResidenceAddr1
+	}
+	return ""
+}
+
+func (x *ResidenceInfo) GetResidenceAddr2() string {
+	if x != nil {
+		return x.ResidenceAddr2
+	}
+	return ""
+}
+
+func (x *ResidenceInfo) GetResidenceDistrict() string {
+	if x != nil {
+		return x.ResidenceDistrict
+	}
+	return ""
+}
+
+func (x *ResidenceInfo) GetResidenceCity() string {
+	if x != nil {
+		return x.ResidenceCity
+	}
+	return ""
+}
+
+func (x *ResidenceInfo) GetResidencePostal() string {
+	if x != nil {
+		return x.ResidencePostal
+	}
+	return ""
+}
+
+func (x *ResidenceInfo) GetResidenceProvince() string {
+	if x != nil {
+		return x.ResidenceProvince
+	}
+	return ""
+}
+
+func (x *ResidenceInfo) GetResidenceStatus() string {
+	if x != nil {
+		return x.ResidenceStatus
+	}
+	return ""
+}
+
+func (x *ResidenceInfo) GetResidenceRentAmt() float64 {
+	if x != nil && x.ResidenceRentAmt != nil {
+		return *x.ResidenceRentAmt
+	}
+	return 0
+}
+
+type User struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	FirstName      string                 `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName       string                 `protobuf:"bytes,2,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	PhoneNumber    string                 `protobuf:"bytes,3,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	DateOfBirth    string                 `protobuf:"bytes,4,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`        // YYYY-MM-DD
+	QinBalance     float64                `protobuf:"fixed64,5,opt,name=qin_balance,json=qinBalance,proto3" json:"qin_balance,omitempty"`           // Output only
+	StellarAddress string                 `protobuf:"bytes,6,opt,name=stellar_address,json=stellarAddress,proto3" json:"stellar_address,omitempty"` // Only used when QIN is on-chain
+	Created        int64                  `protobuf:"varint,7,opt,name=created,proto3" json:"created,omitempty"`                                    // Unix milliseconds; output only
+	EmploymentInfo *EmploymentInfo        `protobuf:"bytes,8,opt,name=employment_info,json=employmentInfo,proto3" json:"employment_info,omitempty"`
+	ResidenceInfo  *ResidenceInfo         `protobuf:"bytes,9,opt,name=residence_info,json=residenceInfo,proto3" json:"residence_info,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_borrowers_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_borrowers_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_borrowers_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *User) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *User) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *User) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *User) GetDateOfBirth() string {
+	if x != nil {
+		return x.DateOfBirth
+	}
+	return ""
+}
+
+func (x *User) GetQinBalance() float64 {
+	if x != nil {
+		return x.QinBalance
+	}
+	return 0
+}
+
+func (x *User) GetStellarAddress() string {
+	if x != nil {
+		return x.StellarAddress
+	}
+	return ""
+}
+
+func (x *User) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *User) GetEmploymentInfo() *EmploymentInfo {
+	if x != nil {
+		return x.EmploymentInfo
+	}
+	return nil
+}
+
+func (x *User) GetResidenceInfo() *ResidenceInfo {
+	if x != nil {
+		return x.ResidenceInfo
+	}
+	return nil
+}
+
+type TermsValuation struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	CurrencyCode      string                 `protobuf:"bytes,1,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"`
+	QinReward         float64                `protobuf:"fixed64,2,opt,name=qin_reward,json=qinReward,proto3" json:"qin_reward,omitempty"`
+	QinRequired       float64                `protobuf:"fixed64,3,opt,name=qin_required,json=qinRequired,proto3" json:"qin_required,omitempty"`
+	EraInterestReward float64                `protobuf:"fixed64,4,opt,name=era_interest_reward,json=eraInterestReward,proto3" json:"era_interest_reward,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *TermsValuation) Reset() {
+	*x = TermsValuation{}
+	mi := &file_borrowers_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TermsValuation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TermsValuation) ProtoMessage() {}
+
+func (x *TermsValuation) ProtoReflect() protoreflect.Message {
+	mi := &file_borrowers_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TermsValuation.ProtoReflect.Descriptor instead.
+func (*TermsValuation) Descriptor() ([]byte, []int) {
+	return file_borrowers_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TermsValuation) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+func (x *TermsValuation) GetQinReward() float64 {
+	if x != nil {
+		return x.QinReward
+	}
+	return 0
+}
+
+func (x *TermsValuation) GetQinRequired() float64 {
+	if x != nil {
+		return x.QinRequired
+	}
+	return 0
+}
+
+func (x *TermsValuation) GetEraInterestReward() float64 {
+	if x != nil {
+		return x.EraInterestReward
+	}
+	return 0
+}
+
+type LoanTerms struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	InterestRate  float64                `protobuf:"fixed64,2,opt,name=interest_rate,json=interestRate,proto3" json:"interest_rate,omitempty"`
+	QinReward     float64                `protobuf:"fixed64,3,opt,name=qin_reward,json=qinReward,proto3" json:"qin_reward,omitempty"`
+	QinRequired   float64                `protobuf:"fixed64,4,opt,name=qin_required,json=qinRequired,proto3" json:"qin_required,omitempty"`
+	AmountOwed    float64                `protobuf:"fixed64,5,opt,name=amount_owed,json=amountOwed,proto3" json:"amount_owed,omitempty"`
+	OfferedBy     string                 `protobuf:"bytes,6,opt,name=offered_by,json=offeredBy,proto3" json:"offered_by,omitempty"`
+	Valuation     *TermsValuation        `protobuf:"bytes,7,opt,name=valuation,proto3" json:"valuation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoanTerms) Reset() {
+	*x = LoanTerms{}
+	mi := &file_borrowers_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoanTerms) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoanTerms) ProtoMessage() {}
+
+func (x *LoanTerms) ProtoReflect() protoreflect.Message {
+	mi := &file_borrowers_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoanTerms.ProtoReflect.Descriptor instead.
+func (*LoanTerms) Descriptor() ([]byte, []int) {
+	return file_borrowers_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LoanTerms) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LoanTerms) GetInterestRate() float64 {
+	if x != nil {
+		return x.InterestRate
+	}
+	return 0
+}
+
+func (x *LoanTerms) GetQinReward() float64 {
+	if x != nil {
+		return x.QinReward
+	}
+	return 0
+}
+
+func (x *LoanTerms) GetQinRequired() float64 {
+	if x != nil {
+		return x.QinRequired
+	}
+	return 0
+}
+
+func (x *LoanTerms) GetAmountOwed() float64 {
+	if x != nil {
+		return x.AmountOwed
+	}
+	return 0
+}
+
+func (x *LoanTerms) GetOfferedBy() string {
+	if x != nil {
+		return x.OfferedBy
+	}
+	return ""
+}
+
+func (x *LoanTerms) GetValuation() *TermsValuation {
+	if x != nil {
+		return x.Valuation
+	}
+	return nil
+}
+
+type PickupLocation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LocationId    string                 `protobuf:"bytes,1,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	LocationName  string                 `protobuf:"bytes,2,opt,name=location_name,json=locationName,proto3" json:"location_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PickupLocation) Reset() {
+	*x = PickupLocation{}
+	mi := &file_borrowers_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PickupLocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PickupLocation) ProtoMessage() {}
+
+func (x *PickupLocation) ProtoReflect() protoreflect.Message {
+	mi := &file_borrowers_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PickupLocation.ProtoReflect.Descriptor instead.
+func (*PickupLocation) Descriptor() ([]byte, []int) {
+	return file_borrowers_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PickupLocation) GetLocationId() string {
+	if x != nil {
+		return x.LocationId
+	}
+	return ""
+}
+
+func (x *PickupLocation) GetLocationName() string {
+	if x != nil {
+		return x.LocationName
+	}
+	return ""
+}
+
+type Repayment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        float64                `protobuf:"fixed64,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix milliseconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Repayment) Reset() {
+	*x = Repayment{}
+	mi := &file_borrowers_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Repayment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Repayment) ProtoMessage() {}
+
+func (x *Repayment) ProtoReflect() protoreflect.Message {
+	mi := &file_borrowers_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Repayment.ProtoReflect.Descriptor instead.
+func (*Repayment) Descriptor() ([]byte, []int) {
+	return file_borrowers_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Repayment) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Repayment) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type AnchorTransfer struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PartnerId      string                 `protobuf:"bytes,1,opt,name=partner_id,json=partnerId,proto3" json:"partner_id,omitempty"`
+	TransactionId  string                 `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	InteractiveUrl string                 `protobuf:"bytes,3,opt,name=interactive_url,json=interactiveUrl,proto3" json:"interactive_url,omitempty"`
+	Instructions   string                 `protobuf:"bytes,4,opt,name=instructions,proto3" json:"instructions,omitempty"`
+	Status         string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AnchorTransfer) Reset() {
+	*x = AnchorTransfer{}
+	mi := &file_borrowers_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnchorTransfer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnchorTransfer) ProtoMessage() {}
+
+func (x *AnchorTransfer) ProtoReflect() protoreflect.Message {
+	mi := &file_borrowers_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnchorTransfer.ProtoReflect.Descriptor instead.
+func (*AnchorTransfer) Descriptor() ([]byte, []int) {
+	return file_borrowers_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AnchorTransfer) GetPartnerId() string {
+	if x != nil {
+		return x.PartnerId
+	}
+	return ""
+}
+
+func (x *AnchorTransfer) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *AnchorTransfer) GetInteractiveUrl() string {
+	if x != nil {
+		return x.InteractiveUrl
+	}
+	return ""
+}
+
+func (x *AnchorTransfer) GetInstructions() string {
+	if x != nil {
+		return x.Instructions
+	}
+	return ""
+}
+
+func (x *AnchorTransfer) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// Timestamps are Unix milliseconds.
+type Loan struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Amount          float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	CurrencyCode    string                 `protobuf:"bytes,3,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"`
+	DueDate         int64                  `protobuf:"varint,4,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	LoanTerms       []*LoanTerms           `protobuf:"bytes,5,rep,name=loan_terms,json=loanTerms,proto3" json:"loan_terms,omitempty"`
+	AcceptedTerms   *LoanTerms             `protobuf:"bytes,6,opt,name=accepted_terms,json=acceptedTerms,proto3" json:"accepted_terms,omitempty"`
+	State           string                 `protobuf:"bytes,7,opt,name=state,proto3" json:"state,omitempty"` // PENDING, PENDING_REVIEW, APPROVED, REJECTED, ACCEPTED, SENT, REPAID, DEFAULTED or CANCELED
+	PickupLocation  *PickupLocation        `protobuf:"bytes,8,opt,name=pickup_location,json=pickupLocation,proto3" json:"pickup_location,omitempty"`
+	PickupCode      string                 `protobuf:"bytes,9,opt,name=pickup_code,json=pickupCode,proto3" json:"pickup_code,omitempty"` // Issued when the loan is SENT
+	Repayments      []*Repayment           `protobuf:"bytes,10,rep,name=repayments,proto3" json:"repayments,omitempty"`
+	Memo            string                 `protobuf:"bytes,11,opt,name=memo,proto3" json:"memo,omitempty"`
+	RepaidDate      int64                  `protobuf:"varint,12,opt,name=repaid_date,json=repaidDate,proto3" json:"repaid_date,omitempty"`
+	Created         int64                  `protobuf:"varint,13,opt,name=created,proto3" json:"created,omitempty"`
+	CollateralState string                 `protobuf:"bytes,14,opt,name=collateral_state,json=collateralState,proto3" json:"collateral_state,omitempty"` // Only set when QIN is on-chain
+	Disbursement    *AnchorTransfer        `protobuf:"bytes,15,opt,name=disbursement,proto3" json:"disbursement,omitempty"`
+	CashIn          *AnchorTransfer        `protobuf:"bytes,16,opt,name=cash_in,json=cashIn,proto3" json:"cash_in,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Loan) Reset() {
+	*x = Loan{}
+	mi := &file_borrowers_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Loan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Loan) ProtoMessage() {}
+
+func (x *Loan) ProtoReflect() protoreflect.Message {
+	mi := &file_borrowers_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Loan.ProtoReflect.Descriptor instead.
+func (*Loan) Descriptor() ([]byte, []int) {
+	return file_borrowers_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Loan) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Loan) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Loan) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+func (x *Loan) GetDueDate() int64 {
+	if x != nil {
+		return x.DueDate
+	}
+	return 0
+}
+
+func (x *Loan) GetLoanTerms() []*LoanTerms {
+	if x != nil {
+		return x.LoanTerms
+	}
+	return nil
+}
+
+func (x *Loan) GetAcceptedTerms() *LoanTerms {
+	if x != nil {
+		return x.AcceptedTerms
+	}
+	return nil
+}
+
+func (x *Loan) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Loan) GetPickupLocation() *PickupLocation {
+	if x != nil {
+		return x.PickupLocation
+	}
+	return nil
+}
+
+func (x *Loan) GetPickupCode() string {
+	if x != nil {
+		return x.PickupCode
+	}
+	return ""
+}
+
+func (x *Loan) GetRepayments() []*Repayment {
+	if x != nil {
+		return x.Repayments
+	}
+	return nil
+}
+
+func (x *Loan) GetMemo() string {
+	if x != nil {
+		return x.Memo
+	}
+	return ""
+}
+
+func (x *Loan) GetRepaidDate() int64 {
+	if x != nil {
+		return x.RepaidDate
+	}
+	return 0
+}
+
+func (x *Loan) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *Loan) GetCollateralState() string {
+	if x != nil {
+		return x.CollateralState
+	}
+	return ""
+}
+
+func (x *Loan) GetDisbursement() *AnchorTransfer {
+	if x != nil {
+		return x.Disbursement
+	}
+	return nil
+}
+
+func (x *Loan) GetCashIn() *AnchorTransfer {
+	if x != nil {
+		return x.CashIn
+	}
+	return nil
+}
+
+type StateCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StateCount) Reset() {
+	*x = StateCount{}
+	mi := &file_borrowers_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateCount) ProtoMessage() {}
+
+func (x *StateCount) ProtoReflect() protoreflect.Message {
+	mi := &file_borrowers_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateCount.ProtoReflect.Descriptor instead.
+func (*StateCount) Descriptor() ([]byte, []int) {
+	return file_borrowers_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StateCount) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *StateCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type CurrencyTotal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CurrencyCode  string                 `protobuf:"bytes,1,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"`
+	Borrowed      float64                `protobuf:"fixed64,2,opt,name=borrowed,proto3" json:"borrowed,omitempty"`
+	Repaid        float64                `protobuf:"fixed64,3,opt,name=repaid,proto3" json:"repaid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CurrencyTotal) Reset() {
+	*x = CurrencyTotal{}
+	mi := &file_borrowers_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CurrencyTotal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CurrencyTotal) ProtoMessage() {}
+
+func (x *CurrencyTotal) ProtoReflect() protoreflect.Message {
+	mi := &file_borrowers_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CurrencyTotal.ProtoReflect.Descriptor instead.
+func (*CurrencyTotal) Descriptor() ([]byte, []int) {
+	return file_borrowers_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CurrencyTotal) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+func (x *CurrencyTotal) GetBorrowed() float64 {
+	if x != nil {
+		return x.Borrowed
+	}
+	return 0
+}
+
+func (x *CurrencyTotal) GetRepaid() float64 {
+	if x != nil {
+		return x.Repaid
+	}
+	return 0
+}
+
+type LoanSummary struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ActiveLoanId    string                 `protobuf:"bytes,1,opt,name=active_loan_id,json=activeLoanId,proto3" json:"active_loan_id,omitempty"`
+	NumLoans        int64                  `protobuf:"varint,2,opt,name=num_loans,json=numLoans,proto3" json:"num_loans,omitempty"`
+	CountsByState   []*StateCount          `protobuf:"bytes,3,rep,name=counts_by_state,json=countsByState,proto3" json:"counts_by_state,omitempty"`
+	Totals          []*CurrencyTotal       `protobuf:"bytes,4,rep,name=totals,proto3" json:"totals,omitempty"`
+	NumRepaidOnTime int64                  `protobuf:"varint,5,opt,name=num_repaid_on_time,json=numRepaidOnTime,proto3" json:"num_repaid_on_time,omitempty"`
+	OnTimeRate      *float64               `protobuf:"fixed64,6,opt,name=on_time_rate,json=onTimeRate,proto3,oneof" json:"on_time_rate,omitempty"` // Of the loans that were repaid or defaulted
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *LoanSummary) Reset() {
+	*x = LoanSummary{}
+	mi := &file_borrowers_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoanSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoanSummary) ProtoMessage() {}
+
+func (x *LoanSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_borrowers_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoanSummary.ProtoReflect.Descriptor instead.
+func (*LoanSummary) Descriptor() ([]byte, []int) {
+	return file_borrowers_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LoanSummary) GetActiveLoanId() string {
+	if x != nil {
+		return x.ActiveLoanId
+	}
+	return ""
+}
+
+func (x *LoanSummary) GetNumLoans() int64 {
+	if x != nil {
+		return x.NumLoans
+	}
+	return 0
+}
+
+func (x *LoanSummary) GetCountsByState() []*StateCount {
+	if x != nil {
+		return x.CountsByState
+	}
+	return nil
+}
+
+func (x *LoanSummary) GetTotals() []*CurrencyTotal {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+func (x *LoanSummary) GetNumRepaidOnTime() int64 {
+	if x != nil {
+		return x.NumRepaidOnTime
+	}
+	return 0
+}
+
+func (x *LoanSummary) GetOnTimeRate() float64 {
+	if x != nil && x.OnTimeRate != nil {
+		return *x.OnTimeRate
+	}
+	return 0
+}
+
+type GetUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_borrowers_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_borrowers_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_borrowers_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type CreateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	User          *User                  `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserRequest) Reset() {
+	*x = CreateUserRequest{}
+	mi := &file_borrowers_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserRequest) ProtoMessage() {}
+
+func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_borrowers_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
+func (*CreateUserRequest) Descriptor() ([]byte, []int) {
+	return file_borrowers_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type UpdateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	User          *User                  `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserRequest) Reset() {
+	*x = UpdateUserRequest{}
+	mi := &file_borrowers_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserRequest) ProtoMessage() {}
+
+func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_borrowers_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
+	return file_borrowers_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type RequestLoanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	LoanAmount    float64                `protobuf:"fixed64,2,opt,name=loan_amount,json=loanAmount,proto3" json:"loan_amount,omitempty"`
+	CurrencyCode  string                 `protobuf:"bytes,3,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"` // Defaults to PHP
+	LoanMemo      string                 `protobuf:"bytes,4,opt,name=loan_memo,json=loanMemo,proto3" json:"loan_memo,omitempty"`
+	LoanPurpose   string                 `protobuf:"bytes,5,opt,name=loan_purpose,json=loanPurpose,proto3" json:"loan_purpose,omitempty"`
+	TermsAgreed   bool                   `protobuf:"varint,6,opt,name=terms_agreed,json=termsAgreed,proto3" json:"terms_agreed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestLoanRequest) Reset() {
+	*x = RequestLoanRequest{}
+	mi := &file_borrowers_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestLoanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestLoanRequest) ProtoMessage() {}
+
+func (x *RequestLoanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_borrowers_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestLoanRequest.ProtoReflect.Descriptor instead.
+func (*RequestLoanRequest) Descriptor() ([]byte, []int) {
+	return file_borrowers_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RequestLoanRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RequestLoanRequest) GetLoanAmount() float64 {
+	if x != nil {
+		return x.LoanAmount
+	}
+	return 0
+}
+
+func (x *RequestLoanRequest) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+func (x *RequestLoanRequest) GetLoanMemo() string {
+	if x != nil {
+		return x.LoanMemo
+	}
+	return ""
+}
+
+func (x *RequestLoanRequest) GetLoanPurpose() string {
+	if x != nil {
+		return x.LoanPurpose
+	}
+	return ""
+}
+
+func (x *RequestLoanRequest) GetTermsAgreed() bool {
+	if x != nil {
+		return x.TermsAgreed
+	}
+	return false
+}
+
+type GetLoanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	LoanId        string                 `protobuf:"bytes,2,opt,name=loan_id,json=loanId,proto3" json:"loan_id,omitempty"` // Empty for the active loan
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLoanRequest) Reset() {
+	*x = GetLoanRequest{}
+	mi := &file_borrowers_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLoanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLoanRequest) ProtoMessage() {}
+
+func (x *GetLoanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_borrowers_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLoanRequest.ProtoReflect.Descriptor instead.
+func (*GetLoanRequest) Descriptor() ([]byte, []int) {
+	return file_borrowers_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetLoanRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetLoanRequest) GetLoanId() string {
+	if x != nil {
+		return x.LoanId
+	}
+	return ""
+}
+
+type SelectOfferRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	LoanId         string                 `protobuf:"bytes,2,opt,name=loan_id,json=loanId,proto3" json:"loan_id,omitempty"` // Empty for the active loan
+	SelectedTerm   string                 `protobuf:"bytes,3,opt,name=selected_term,json=selectedTerm,proto3" json:"selected_term,omitempty"`
+	PickupLocation *PickupLocation        `protobuf:"bytes,4,opt,name=pickup_location,json=pickupLocation,proto3" json:"pickup_location,omitempty"`
+	PartnerId      string                 `protobuf:"bytes,5,opt,name=partner_id,json=partnerId,proto3" json:"partner_id,omitempty"` // Defaults to the partner of the location
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SelectOfferRequest) Reset() {
+	*x = SelectOfferRequest{}
+	mi := &file_borrowers_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelectOfferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelectOfferRequest) ProtoMessage() {}
+
+func (x *SelectOfferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_borrowers_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelectOfferRequest.ProtoReflect.Descriptor instead.
+func (*SelectOfferRequest) Descriptor() ([]byte, []int) {
+	return file_borrowers_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SelectOfferRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SelectOfferRequest) GetLoanId() string {
+	if x != nil {
+		return x.LoanId
+	}
+	return ""
+}
+
+func (x *SelectOfferRequest) GetSelectedTerm() string {
+	if x != nil {
+		return x.SelectedTerm
+	}
+	return ""
+}
+
+func (x *SelectOfferRequest) GetPickupLocation() *PickupLocation {
+	if x != nil {
+		return x.PickupLocation
+	}
+	return nil
+}
+
+func (x *SelectOfferRequest) GetPartnerId() string {
+	if x != nil {
+		return x.PartnerId
+	}
+	return ""
+}
+
+type RepayRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	LoanId        string                 `protobuf:"bytes,2,opt,name=loan_id,json=loanId,proto3" json:"loan_id,omitempty"`          // Empty for the active loan
+	PartnerId     string                 `protobuf:"bytes,3,opt,name=partner_id,json=partnerId,proto3" json:"partner_id,omitempty"` // Repay by cash-in through this partner instead of instantly
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RepayRequest) Reset() {
+	*x = RepayRequest{}
+	mi := &file_borrowers_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RepayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepayRequest) ProtoMessage() {}
+
+func (x *RepayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_borrowers_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepayRequest.ProtoReflect.Descriptor instead.
+func (*RepayRequest) Descriptor() ([]byte, []int) {
+	return file_borrowers_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RepayRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RepayRequest) GetLoanId() string {
+	if x != nil {
+		return x.LoanId
+	}
+	return ""
+}
+
+func (x *RepayRequest) GetPartnerId() string {
+	if x != nil {
+		return x.PartnerId
+	}
+	return ""
+}
+
+type RepayResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Loan          *Loan                  `protobuf:"bytes,1,opt,name=loan,proto3" json:"loan,omitempty"`
+	Done          bool                   `protobuf:"varint,2,opt,name=done,proto3" json:"done,omitempty"` // False while a cash-in is still waiting for the money
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RepayResponse) Reset() {
+	*x = RepayResponse{}
+	mi := &file_borrowers_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RepayResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepayResponse) ProtoMessage() {}
+
+func (x *RepayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_borrowers_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepayResponse.ProtoReflect.Descriptor instead.
+func (*RepayResponse) Descriptor() ([]byte, []int) {
+	return file_borrowers_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RepayResponse) GetLoan() *Loan {
+	if x != nil {
+		return x.Loan
+	}
+	return nil
+}
+
+func (x *RepayResponse) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+type ListLoansRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`  // 1 to 100, 20 by default
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"` // next_cursor of the previous page, with the same filters and sort
+	State         string                 `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	Sort          string                 `protobuf:"bytes,5,opt,name=sort,proto3" json:"sort,omitempty"`        // created, dueDate, or either with a leading - for newest first; -created by default
+	From          *int64                 `protobuf:"varint,6,opt,name=from,proto3,oneof" json:"from,omitempty"` // Unix milliseconds bounding the sorted date
+	To            *int64                 `protobuf:"varint,7,opt,name=to,proto3,oneof" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLoansRequest) Reset() {
+	*x = ListLoansRequest{}
+	mi := &file_borrowers_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLoansRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLoansRequest) ProtoMessage() {}
+
+func (x *ListLoansRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_borrowers_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLoansRequest.ProtoReflect.Descriptor instead.
+func (*ListLoansRequest) Descriptor() ([]byte, []int) {
+	return file_borrowers_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListLoansRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListLoansRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListLoansRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListLoansRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ListLoansRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListLoansRequest) GetFrom() int64 {
+	if x != nil && x.From != nil {
+		return *x.From
+	}
+	return 0
+}
+
+func (x *ListLoansRequest) GetTo() int64 {
+	if x != nil && x.To != nil {
+		return *x.To
+	}
+	return 0
+}
+
+type ListLoansResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Loans         []*Loan                `protobuf:"bytes,1,rep,name=loans,proto3" json:"loans,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Empty on the last page
+	Summary       *LoanSummary           `protobuf:"bytes,3,opt,name=summary,proto3" json:"summary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLoansResponse) Reset() {
+	*x = ListLoansResponse{}
+	mi := &file_borrowers_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLoansResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLoansResponse) ProtoMessage() {}
+
+func (x *ListLoansResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_borrowers_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLoansResponse.ProtoReflect.Descriptor instead.
+func (*ListLoansResponse) Descriptor() ([]byte, []int) {
+	return file_borrowers_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListLoansResponse) GetLoans() []*Loan {
+	if x != nil {
+		return x.Loans
+	}
+	return nil
+}
+
+func (x *ListLoansResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListLoansResponse) GetSummary() *LoanSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+var File_borrowers_proto protoreflect.FileDescriptor
+
+const file_borrowers_proto_rawDesc = "" +
+	"\n" +
+	"\x0fborrowers.proto\x12\x0fonedaijo.sbc.v1\"\x93\x03\n" +
+	"\x0eEmploymentInfo\x12+\n" +
+	"\x11employment_status\x18\x01 \x01(\tR\x10employmentStatus\x120\n" +
+	"\x14employment_job_title\x18\x02 \x01(\tR\x12employmentJobTitle\x129\n" +
+	"\x16employment_start_month\x18\x03 \x01(\x03H\x00R\x14employmentStartMonth\x88\x01\x01\x127\n" +
+	"\x15employment_start_year\x18\x04 \x01(\x03H\x01R\x13employmentStartYear\x88\x01\x01\x120\n" +
+	"\x11employment_income\x18\x05 \x01(\x01H\x02R\x10employmentIncome\x88\x01\x01\x121\n" +
+	"\x14employment_education\x18\x06 \x01(\tR\x13employmentEducationB\x19\n" +
+	"\x17_employment_start_monthB\x18\n" +
+	"\x16_employment_start_yearB\x14\n" +
+	"\x12_employment_income\"\x86\x03\n" +
+	"\rResidenceInfo\x12'\n" +
+	"\x0fresidence_addr1\x18\x01 \x01(\tR\x0eresidenceAddr1\x12'\n" +
+	"\x0fresidence_addr2\x18\x02 \x01(\tR\x0eresidenceAddr2\x12-\n" +
+	"\x12residence_district\x18\x03 \x01(\tR\x11residenceDistrict\x12%\n" +
+	"\x0eresidence_city\x18\x04 \x01(\tR\rresidenceCity\x12)\n" +
+	"\x10residence_postal\x18\x05 \x01(\tR\x0fresidencePostal\x12-\n" +
+	"\x12residence_province\x18\x06 \x01(\tR\x11residenceProvince\x12)\n" +
+	"\x10residence_status\x18\a \x01(\tR\x0fresidenceStatus\x121\n" +
+	"\x12residence_rent_amt\x18\b \x01(\x01H\x00R\x10residenceRentAmt\x88\x01\x01B\x15\n" +
+	"\x13_residence_rent_amt\"\xfe\x02\n" +
+	"\x04User\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x01 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x02 \x01(\tR\blastName\x12!\n" +
+	"\fphone_number\x18\x03 \x01(\tR\vphoneNumber\x12\"\n" +
+	"\rdate_of_birth\x18\x04 \x01(\tR\vdateOfBirth\x12\x1f\n" +
+	"\vqin_balance\x18\x05 \x01(\x01R\n" +
+	"qinBalance\x12'\n" +
+	"\x0fstellar_address\x18\x06 \x01(\tR\x0estellarAddress\x12\x18\n" +
+	"\acreated\x18\a \x01(\x03R\acreated\x12H\n" +
+	"\x0femployment_info\x18\b \x01(\v2\x1f.onedaijo.sbc.v1.EmploymentInfoR\x0eemploymentInfo\x12E\n" +
+	"\x0eresidence_info\x18\t \x01(\v2\x1e.onedaijo.sbc.v1.ResidenceInfoR\rresidenceInfo\"\xa7\x01\n" +
+	"\x0eTermsValuation\x12#\n" +
+	"\rcurrency_code\x18\x01 \x01(\tR\fcurrencyCode\x12\x1d\n" +
+	"\n" +
+	"qin_reward\x18\x02 \x01(\x01R\tqinReward\x12!\n" +
+	"\fqin_required\x18\x03 \x01(\x01R\vqinRequired\x12.\n" +
+	"\x13era_interest_reward\x18\x04 \x01(\x01R\x11eraInterestReward\"\x81\x02\n" +
+	"\tLoanTerms\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\rinterest_rate\x18\x02 \x01(\x01R\finterestRate\x12\x1d\n" +
+	"\n" +
+	"qin_reward\x18\x03 \x01(\x01R\tqinReward\x12!\n" +
+	"\fqin_required\x18\x04 \x01(\x01R\vqinRequired\x12\x1f\n" +
+	"\vamount_owed\x18\x05 \x01(\x01R\n" +
+	"amountOwed\x12\x1d\n" +
+	"\n" +
+	"offered_by\x18\x06 \x01(\tR\tofferedBy\x12=\n" +
+	"\tvaluation\x18\a \x01(\v2\x1f.onedaijo.sbc.v1.TermsValuationR\tvaluation\"V\n" +
+	"\x0ePickupLocation\x12\x1f\n" +
+	"\vlocation_id\x18\x01 \x01(\tR\n" +
+	"locationId\x12#\n" +
+	"\rlocation_name\x18\x02 \x01(\tR\flocationName\"A\n" +
+	"\tRepayment\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x01R\x06amount\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\"\xbb\x01\n" +
+	"\x0eAnchorTransfer\x12\x1d\n" +
+	"\n" +
+	"partner_id\x18\x01 \x01(\tR\tpartnerId\x12%\n" +
+	"\x0etransaction_id\x18\x02 \x01(\tR\rtransactionId\x12'\n" +
+	"\x0finteractive_url\x18\x03 \x01(\tR\x0einteractiveUrl\x12\"\n" +
+	"\finstructions\x18\x04 \x01(\tR\finstructions\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\"\xa2\x05\n" +
+	"\x04Loan\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12#\n" +
+	"\rcurrency_code\x18\x03 \x01(\tR\fcurrencyCode\x12\x19\n" +
+	"\bdue_date\x18\x04 \x01(\x03R\adueDate\x129\n" +
+	"\n" +
+	"loan_terms\x18\x05 \x03(\v2\x1a.onedaijo.sbc.v1.LoanTermsR\tloanTerms\x12A\n" +
+	"\x0eaccepted_terms\x18\x06 \x01(\v2\x1a.onedaijo.sbc.v1.LoanTermsR\racceptedTerms\x12\x14\n" +
+	"\x05state\x18\a \x01(\tR\x05state\x12H\n" +
+	"\x0fpickup_location\x18\b \x01(\v2\x1f.onedaijo.sbc.v1.PickupLocationR\x0epickupLocation\x12\x1f\n" +
+	"\vpickup_code\x18\t \x01(\tR\n" +
+	"pickupCode\x12:\n" +
+	"\n" +
+	"repayments\x18\n" +
+	" \x03(\v2\x1a.onedaijo.sbc.v1.RepaymentR\n" +
+	"repayments\x12\x12\n" +
+	"\x04memo\x18\v \x01(\tR\x04memo\x12\x1f\n" +
+	"\vrepaid_date\x18\f \x01(\x03R\n" +
+	"repaidDate\x12\x18\n" +
+	"\acreated\x18\r \x01(\x03R\acreated\x12)\n" +
+	"\x10collateral_state\x18\x0e \x01(\tR\x0fcollateralState\x12C\n" +
+	"\fdisbursement\x18\x0f \x01(\v2\x1f.onedaijo.sbc.v1.AnchorTransferR\fdisbursement\x128\n" +
+	"\acash_in\x18\x10 \x01(\v2\x1f.onedaijo.sbc.v1.AnchorTransferR\x06cashIn\"8\n" +
+	"\n" +
+	"StateCount\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\tR\x05state\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"h\n" +
+	"\rCurrencyTotal\x12#\n" +
+	"\rcurrency_code\x18\x01 \x01(\tR\fcurrencyCode\x12\x1a\n" +
+	"\bborrowed\x18\x02 \x01(\x01R\bborrowed\x12\x16\n" +
+	"\x06repaid\x18\x03 \x01(\x01R\x06repaid\"\xb2\x02\n" +
+	"\vLoanSummary\x12$\n" +
+	"\x0eactive_loan_id\x18\x01 \x01(\tR\factiveLoanId\x12\x1b\n" +
+	"\tnum_loans\x18\x02 \x01(\x03R\bnumLoans\x12C\n" +
+	"\x0fcounts_by_state\x18\x03 \x03(\v2\x1b.onedaijo.sbc.v1.StateCountR\rcountsByState\x126\n" +
+	"\x06totals\x18\x04 \x03(\v2\x1e.onedaijo.sbc.v1.CurrencyTotalR\x06totals\x12+\n" +
+	"\x12num_repaid_on_time\x18\x05 \x01(\x03R\x0fnumRepaidOnTime\x12%\n" +
+	"\fon_time_rate\x18\x06 \x01(\x01H\x00R\n" +
+	"onTimeRate\x88\x01\x01B\x0f\n" +
+	"\r_on_time_rate\")\n" +
+	"\x0eGetUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"W\n" +
+	"\x11CreateUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12)\n" +
+	"\x04user\x18\x02 \x01(\v2\x15.onedaijo.sbc.v1.UserR\x04user\"W\n" +
+	"\x11UpdateUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12)\n" +
+	"\x04user\x18\x02 \x01(\v2\x15.onedaijo.sbc.v1.UserR\x04user\"\xd6\x01\n" +
+	"\x12RequestLoanRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vloan_amount\x18\x02 \x01(\x01R\n" +
+	"loanAmount\x12#\n" +
+	"\rcurrency_code\x18\x03 \x01(\tR\fcurrencyCode\x12\x1b\n" +
+	"\tloan_memo\x18\x04 \x01(\tR\bloanMemo\x12!\n" +
+	"\floan_purpose\x18\x05 \x01(\tR\vloanPurpose\x12!\n" +
+	"\fterms_agreed\x18\x06 \x01(\bR\vtermsAgreed\"B\n" +
+	"\x0eGetLoanRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\aloan_id\x18\x02 \x01(\tR\x06loanId\"\xd4\x01\n" +
+	"\x12SelectOfferRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\aloan_id\x18\x02 \x01(\tR\x06loanId\x12#\n" +
+	"\rselected_term\x18\x03 \x01(\tR\fselectedTerm\x12H\n" +
+	"\x0fpickup_location\x18\x04 \x01(\v2\x1f.onedaijo.sbc.v1.PickupLocationR\x0epickupLocation\x12\x1d\n" +
+	"\n" +
+	"partner_id\x18\x05 \x01(\tR\tpartnerId\"_\n" +
+	"\fRepayRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\aloan_id\x18\x02 \x01(\tR\x06loanId\x12\x1d\n" +
+	"\n" +
+	"partner_id\x18\x03 \x01(\tR\tpartnerId\"N\n" +
+	"\rRepayResponse\x12)\n" +
+	"\x04loan\x18\x01 \x01(\v2\x15.onedaijo.sbc.v1.LoanR\x04loan\x12\x12\n" +
+	"\x04done\x18\x02 \x01(\bR\x04done\"\xc1\x01\n" +
+	"\x10ListLoansRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05state\x18\x04 \x01(\tR\x05state\x12\x12\n" +
+	"\x04sort\x18\x05 \x01(\tR\x04sort\x12\x17\n" +
+	"\x04from\x18\x06 \x01(\x03H\x00R\x04from\x88\x01\x01\x12\x13\n" +
+	"\x02to\x18\a \x01(\x03H\x01R\x02to\x88\x01\x01B\a\n" +
+	"\x05_fromB\x05\n" +
+	"\x03_to\"\x99\x01\n" +
+	"\x11ListLoansResponse\x12+\n" +
+	"\x05loans\x18\x01 \x03(\v2\x15.onedaijo.sbc.v1.LoanR\x05loans\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x126\n" +
+	"\asummary\x18\x03 \x01(\v2\x1c.onedaijo.sbc.v1.LoanSummaryR\asummary2\xd5\x04\n" +
+	"\tBorrowers\x12A\n" +
+	"\aGetUser\x12\x1f.onedaijo.sbc.v1.GetUserRequest\x1a\x15.onedaijo.sbc.v1.User\x12G\n" +
+	"\n" +
+	"CreateUser\x12\".onedaijo.sbc.v1.CreateUserRequest\x1a\x15.onedaijo.sbc.v1.User\x12G\n" +
+	"\n" +
+	"UpdateUser\x12\".onedaijo.sbc.v1.UpdateUserRequest\x1a\x15.onedaijo.sbc.v1.User\x12I\n" +
+	"\vRequestLoan\x12#.onedaijo.sbc.v1.RequestLoanRequest\x1a\x15.onedaijo.sbc.v1.Loan\x12A\n" +
+	"\aGetLoan\x12\x1f.onedaijo.sbc.v1.GetLoanRequest\x1a\x15.onedaijo.sbc.v1.Loan\x12I\n" +
+	"\vSelectOffer\x12#.onedaijo.sbc.v1.SelectOfferRequest\x1a\x15.onedaijo.sbc.v1.Loan\x12F\n" +
+	"\x05Repay\x12\x1d.onedaijo.sbc.v1.RepayRequest\x1a\x1e.onedaijo.sbc.v1.RepayResponse\x12R\n" +
+	"\tListLoans\x12!.onedaijo.sbc.v1.ListLoansRequest\x1a\".onedaijo.sbc.v1.ListLoansResponseB,Z*github.com/OneDaijo/sbc-demo-backend/sbcpbb\x06proto3"
+
+var (
+	file_borrowers_proto_rawDescOnce sync.Once
+	file_borrowers_proto_rawDescData []byte
+)
+
+func file_borrowers_proto_rawDescGZIP() []byte {
+	file_borrowers_proto_rawDescOnce.Do(func() {
+		file_borrowers_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_borrowers_proto_rawDesc), len(file_borrowers_proto_rawDesc)))
+	})
+	return file_borrowers_proto_rawDescData
+}
+
+var file_borrowers_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_borrowers_proto_goTypes = []any{
+	(*EmploymentInfo)(nil),     // 0: onedaijo.sbc.v1.EmploymentInfo
+	(*ResidenceInfo)(nil),      // 1: onedaijo.sbc.v1.ResidenceInfo
+	(*User)(nil),               // 2: onedaijo.sbc.v1.User
+	(*TermsValuation)(nil),     // 3: onedaijo.sbc.v1.TermsValuation
+	(*LoanTerms)(nil),          // 4: onedaijo.sbc.v1.LoanTerms
+	(*PickupLocation)(nil),     // 5: onedaijo.sbc.v1.PickupLocation
+	(*Repayment)(nil),          // 6: onedaijo.sbc.v1.Repayment
+	(*AnchorTransfer)(nil),     // 7: onedaijo.sbc.v1.AnchorTransfer
+	(*Loan)(nil),               // 8: onedaijo.sbc.v1.Loan
+	(*StateCount)(nil),         // 9: onedaijo.sbc.v1.StateCount
+	(*CurrencyTotal)(nil),      // 10: onedaijo.sbc.v1.CurrencyTotal
+	(*LoanSummary)(nil),        // 11: onedaijo.sbc.v1.LoanSummary
+	(*GetUserRequest)(nil),     // 12: onedaijo.sbc.v1.GetUserRequest
+	(*CreateUserRequest)(nil),  // 13: onedaijo.sbc.v1.CreateUserRequest
+	(*UpdateUserRequest)(nil),  // 14: onedaijo.sbc.v1.UpdateUserRequest
+	(*RequestLoanRequest)(nil), // 15: onedaijo.sbc.v1.RequestLoanRequest
+	(*GetLoanRequest)(nil),     // 16: onedaijo.sbc.v1.GetLoanRequest
+	(*SelectOfferRequest)(nil), // 17: onedaijo.sbc.v1.SelectOfferRequest
+	(*RepayRequest)(nil),       // 18: onedaijo.sbc.v1.RepayRequest
+	(*RepayResponse)(nil),      // 19: onedaijo.sbc.v1.RepayResponse
+	(*ListLoansRequest)(nil),   // 20: onedaijo.sbc.v1.ListLoansRequest
+	(*ListLoansResponse)(nil),  // 21: onedaijo.sbc.v1.ListLoansResponse
+}
+var file_borrowers_proto_depIdxs = []int32{
+	0,  // 0: onedaijo.sbc.v1.User.employment_info:type_name -> onedaijo.sbc.v1.EmploymentInfo
+	1,  // 1: onedaijo.sbc.v1.User.residence_info:type_name -> onedaijo.sbc.v1.ResidenceInfo
+	3,  // 2: onedaijo.sbc.v1.LoanTerms.valuation:type_name -> onedaijo.sbc.v1.TermsValuation
+	4,  // 3: onedaijo.sbc.v1.Loan.loan_terms:type_name -> onedaijo.sbc.v1.LoanTerms
+	4,  // 4: onedaijo.sbc.v1.Loan.accepted_terms:type_name -> onedaijo.sbc.v1.LoanTerms
+	5,  // 5: onedaijo.sbc.v1.Loan.pickup_location:type_name -> onedaijo.sbc.v1.PickupLocation
+	6,  // 6: onedaijo.sbc.v1.Loan.repayments:type_name -> onedaijo.sbc.v1.Repayment
+	7,  // 7: onedaijo.sbc.v1.Loan.disbursement:type_name -> onedaijo.sbc.v1.AnchorTransfer
+	7,  // 8: onedaijo.sbc.v1.Loan.cash_in:type_name -> onedaijo.sbc.v1.AnchorTransfer
+	9,  // 9: onedaijo.sbc.v1.LoanSummary.counts_by_state:type_name -> onedaijo.sbc.v1.StateCount
+	10, // 10: onedaijo.sbc.v1.LoanSummary.totals:type_name -> onedaijo.sbc.v1.CurrencyTotal
+	2,  // 11: onedaijo.sbc.v1.CreateUserRequest.user:type_name -> onedaijo.sbc.v1.User
+	2,  // 12: onedaijo.sbc.v1.UpdateUserRequest.user:type_name -> onedaijo.sbc.v1.User
+	5,  // 13: onedaijo.sbc.v1.SelectOfferRequest.pickup_location:type_name -> onedaijo.sbc.v1.PickupLocation
+	8,  // 14: onedaijo.sbc.v1.RepayResponse.loan:type_name -> onedaijo.sbc.v1.Loan
+	8,  // 15: onedaijo.sbc.v1.ListLoansResponse.loans:type_name -> onedaijo.sbc.v1.Loan
+	11, // 16: onedaijo.sbc.v1.ListLoansResponse.summary:type_name -> onedaijo.sbc.v1.LoanSummary
+	12, // 17: onedaijo.sbc.v1.Borrowers.GetUser:input_type -> onedaijo.sbc.v1.GetUserRequest
+	13, // 18: onedaijo.sbc.v1.Borrowers.CreateUser:input_type -> onedaijo.sbc.v1.CreateUserRequest
+	14, // 19: onedaijo.sbc.v1.Borrowers.UpdateUser:input_type -> onedaijo.sbc.v1.UpdateUserRequest
+	15, // 20: onedaijo.sbc.v1.Borrowers.RequestLoan:input_type -> onedaijo.sbc.v1.RequestLoanRequest
+	16, // 21: onedaijo.sbc.v1.Borrowers.GetLoan:input_type -> onedaijo.sbc.v1.GetLoanRequest
+	17, // 22: onedaijo.sbc.v1.Borrowers.SelectOffer:input_type -> onedaijo.sbc.v1.SelectOfferRequest
+	18, // 23: onedaijo.sbc.v1.Borrowers.Repay:input_type -> onedaijo.sbc.v1.RepayRequest
+	20, // 24: onedaijo.sbc.v1.Borrowers.ListLoans:input_type -> onedaijo.sbc.v1.ListLoansRequest
+	2,  // 25: onedaijo.sbc.v1.Borrowers.GetUser:output_type -> onedaijo.sbc.v1.User
+	2,  // 26: onedaijo.sbc.v1.Borrowers.CreateUser:output_type -> onedaijo.sbc.v1.User
+	2,  // 27: onedaijo.sbc.v1.Borrowers.UpdateUser:output_type -> onedaijo.sbc.v1.User
+	8,  // 28: onedaijo.sbc.v1.Borrowers.RequestLoan:output_type -> onedaijo.sbc.v1.Loan
+	8,  // 29: onedaijo.sbc.v1.Borrowers.GetLoan:output_type -> onedaijo.sbc.v1.Loan
+	8,  // 30: onedaijo.sbc.v1.Borrowers.SelectOffer:output_type -> onedaijo.sbc.v1.Loan
+	19, // 31: onedaijo.sbc.v1.Borrowers.Repay:output_type -> onedaijo.sbc.v1.RepayResponse
+	21, // 32: onedaijo.sbc.v1.Borrowers.ListLoans:output_type -> onedaijo.sbc.v1.ListLoansResponse
+	25, // [25:33] is the sub-list for method output_type
+	17, // [17:25] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_borrowers_proto_init() }
+func file_borrowers_proto_init() {
+	if File_borrowers_proto != nil {
+		return
+	}
+	file_borrowers_proto_msgTypes[0].OneofWrappers = []any{}
+	file_borrowers_proto_msgTypes[1].OneofWrappers = []any{}
+	file_borrowers_proto_msgTypes[11].OneofWrappers = []any{}
+	file_borrowers_proto_msgTypes[20].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_borrowers_proto_rawDesc), len(file_borrowers_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   22,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_borrowers_proto_goTypes,
+		DependencyIndexes: file_borrowers_proto_depIdxs,
+		MessageInfos:      file_borrowers_proto_msgTypes,
+	}.Build()
+	File_borrowers_proto = out.File
+	file_borrowers_proto_goTypes = nil
+	file_borrowers_proto_depIdxs = nil
+}
@@ -0,0 +1,409 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: borrowers.proto
+
+package sbcpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Borrowers_GetUser_FullMethodName     = "/onedaijo.sbc.v1.Borrowers/GetUser"
+	Borrowers_CreateUser_FullMethodName  = "/onedaijo.sbc.v1.Borrowers/CreateUser"
+	Borrowers_UpdateUser_FullMethodName  = "/onedaijo.sbc.v1.Borrowers/UpdateUser"
+	Borrowers_RequestLoan_FullMethodName = "/onedaijo.sbc.v1.Borrowers/RequestLoan"
+	Borrowers_GetLoan_FullMethodName     = "/onedaijo.sbc.v1.Borrowers/GetLoan"
+	Borrowers_SelectOffer_FullMethodName = "/onedaijo.sbc.v1.Borrowers/SelectOffer"
+	Borrowers_Repay_FullMethodName       = "/onedaijo.sbc.v1.Borrowers/Repay"
+	Borrowers_ListLoans_FullMethodName   = "/onedaijo.sbc.v1.Borrowers/ListLoans"
+)
+
+// BorrowersClient is the client API for Borrowers service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Borrowers and their loans for internal services. It mirrors the REST API in server/openapi.json, but callers
+// are services authenticated by their client certificate, and they name the user they act for in user_id. That
+// user must exist in Firebase, not be disabled and, except for GetUser and CreateUser, have a verified email.
+//
+// Errors use the standard gRPC codes. The REST error code is attached as a google.rpc.ErrorInfo reason, and
+// problems with request fields as a google.rpc.BadRequest whose field names match the REST API.
+type BorrowersClient interface {
+	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error)
+	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*User, error)
+	// Replaces the employment info, residence info and Stellar address with those that are set.
+	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*User, error)
+	// Applies for a loan. The loan comes back APPROVED with the ERAs' offers.
+	RequestLoan(ctx context.Context, in *RequestLoanRequest, opts ...grpc.CallOption) (*Loan, error)
+	// Reading the active loan defaults it if it is overdue.
+	GetLoan(ctx context.Context, in *GetLoanRequest, opts ...grpc.CallOption) (*Loan, error)
+	// Accepts an offer and/or chooses a pickup location. Choosing a location sends the loan.
+	SelectOffer(ctx context.Context, in *SelectOfferRequest, opts ...grpc.CallOption) (*Loan, error)
+	Repay(ctx context.Context, in *RepayRequest, opts ...grpc.CallOption) (*RepayResponse, error)
+	ListLoans(ctx context.Context, in *ListLoansRequest, opts ...grpc.CallOption) (*ListLoansResponse, error)
+}
+
+type borrowersClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBorrowersClient(cc grpc.ClientConnInterface) BorrowersClient {
+	return &borrowersClient{cc}
+}
+
+func (c *borrowersClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, Borrowers_GetUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *borrowersClient) CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, Borrowers_CreateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *borrowersClient) UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, Borrowers_UpdateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *borrowersClient) RequestLoan(ctx context.Context, in *RequestLoanRequest, opts ...grpc.CallOption) (*Loan, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Loan)
+	err := c.cc.Invoke(ctx, Borrowers_RequestLoan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *borrowersClient) GetLoan(ctx context.Context, in *GetLoanRequest, opts ...grpc.CallOption) (*Loan, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Loan)
+	err := c.cc.Invoke(ctx, Borrowers_GetLoan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *borrowersClient) SelectOffer(ctx context.Context, in *SelectOfferRequest, opts ...grpc.CallOption) (*Loan, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Loan)
+	err := c.cc.Invoke(ctx, Borrowers_SelectOffer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *borrowersClient) Repay(ctx context.Context, in *RepayRequest, opts ...grpc.CallOption) (*RepayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RepayResponse)
+	err := c.cc.Invoke(ctx, Borrowers_Repay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *borrowersClient) ListLoans(ctx context.Context, in *ListLoansRequest, opts ...grpc.CallOption) (*ListLoansResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLoansResponse)
+	err := c.cc.Invoke(ctx, Borrowers_ListLoans_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BorrowersServer is the server API for Borrowers service.
+// All implementations must embed UnimplementedBorrowersServer
+// for forward compatibility.
+//
+// Borrowers and their loans for internal services. It mirrors the REST API in server/openapi.json, but callers
+// are services authenticated by their client certificate, and they name the user they act for in user_id. That
+// user must exist in Firebase, not be disabled and, except for GetUser and CreateUser, have a verified email.
+//
+// Errors use the standard gRPC codes. The REST error code is attached as a google.rpc.ErrorInfo reason, and
+// problems with request fields as a google.rpc.BadRequest whose field names match the REST API.
+type BorrowersServer interface {
+	GetUser(context.Context, *GetUserRequest) (*User, error)
+	CreateUser(context.Context, *CreateUserRequest) (*User, error)
+	// Replaces the employment info, residence info and Stellar address with those that are set.
+	UpdateUser(context.Context, *UpdateUserRequest) (*User, error)
+	// Applies for a loan. The loan comes back APPROVED with the ERAs' offers.
+	RequestLoan(context.Context, *RequestLoanRequest) (*Loan, error)
+	// Reading the active loan defaults it if it is overdue.
+	GetLoan(context.Context, *GetLoanRequest) (*Loan, error)
+	// Accepts an offer and/or chooses a pickup location. Choosing a location sends the loan.
+	SelectOffer(context.Context, *SelectOfferRequest) (*Loan, error)
+	Repay(context.Context, *RepayRequest) (*RepayResponse, error)
+	ListLoans(context.Context, *ListLoansRequest) (*ListLoansResponse, error)
+	mustEmbedUnimplementedBorrowersServer()
+}
+
+// UnimplementedBorrowersServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBorrowersServer struct{}
+
+func (UnimplementedBorrowersServer) GetUser(context.Context, *GetUserRequest) (*User, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedBorrowersServer) CreateUser(context.Context, *CreateUserRequest) (*User, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (UnimplementedBorrowersServer) UpdateUser(context.Context, *UpdateUserRequest) (*User, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateUser not implemented")
+}
+func (UnimplementedBorrowersServer) RequestLoan(context.Context, *RequestLoanRequest) (*Loan, error) {
+	return nil, status.Error(codes.Unimplemented, "method RequestLoan not implemented")
+}
+func (UnimplementedBorrowersServer) GetLoan(context.Context, *GetLoanRequest) (*Loan, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLoan not implemented")
+}
+func (UnimplementedBorrowersServer) SelectOffer(context.Context, *SelectOfferRequest) (*Loan, error) {
+	return nil, status.Error(codes.Unimplemented, "method SelectOffer not implemented")
+}
+func (UnimplementedBorrowersServer) Repay(context.Context, *RepayRequest) (*RepayResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Repay not implemented")
+}
+func (UnimplementedBorrowersServer) ListLoans(context.Context, *ListLoansRequest) (*ListLoansResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListLoans not implemented")
+}
+func (UnimplementedBorrowersServer) mustEmbedUnimplementedBorrowersServer() {}
+func (UnimplementedBorrowersServer) testEmbeddedByValue()                   {}
+
+// UnsafeBorrowersServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BorrowersServer will
+// result in compilation errors.
+type UnsafeBorrowersServer interface {
+	mustEmbedUnimplementedBorrowersServer()
+}
+
+func RegisterBorrowersServer(s grpc.ServiceRegistrar, srv BorrowersServer) {
+	// If the following call panics, it indicates UnimplementedBorrowersServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Borrowers_ServiceDesc, srv)
+}
+
+func _Borrowers_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BorrowersServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Borrowers_GetUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BorrowersServer).GetUser(ctx, req.(*GetUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Borrowers_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BorrowersServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Borrowers_CreateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BorrowersServer).CreateUser(ctx, req.(*CreateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Borrowers_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BorrowersServer).UpdateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Borrowers_UpdateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BorrowersServer).UpdateUser(ctx, req.(*UpdateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Borrowers_RequestLoan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestLoanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BorrowersServer).RequestLoan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Borrowers_RequestLoan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BorrowersServer).RequestLoan(ctx, req.(*RequestLoanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Borrowers_GetLoan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLoanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BorrowersServer).GetLoan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Borrowers_GetLoan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BorrowersServer).GetLoan(ctx, req.(*GetLoanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Borrowers_SelectOffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SelectOfferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BorrowersServer).SelectOffer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Borrowers_SelectOffer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BorrowersServer).SelectOffer(ctx, req.(*SelectOfferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Borrowers_Repay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BorrowersServer).Repay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Borrowers_Repay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BorrowersServer).Repay(ctx, req.(*RepayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Borrowers_ListLoans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLoansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BorrowersServer).ListLoans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Borrowers_ListLoans_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BorrowersServer).ListLoans(ctx, req.(*ListLoansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Borrowers_ServiceDesc is the grpc.ServiceDesc for Borrowers service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Borrowers_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "onedaijo.sbc.v1.Borrowers",
+	HandlerType: (*BorrowersServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetUser",
+			Handler:    _Borrowers_GetUser_Handler,
+		},
+		{
+			MethodName: "CreateUser",
+			Handler:    _Borrowers_CreateUser_Handler,
+		},
+		{
+			MethodName: "UpdateUser",
+			Handler:    _Borrowers_UpdateUser_Handler,
+		},
+		{
+			MethodName: "RequestLoan",
+			Handler:    _Borrowers_RequestLoan_Handler,
+		},
+		{
+			MethodName: "GetLoan",
+			Handler:    _Borrowers_GetLoan_Handler,
+		},
+		{
+			MethodName: "SelectOffer",
+			Handler:    _Borrowers_SelectOffer_Handler,
+		},
+		{
+			MethodName: "Repay",
+			Handler:    _Borrowers_Repay_Handler,
+		},
+		{
+			MethodName: "ListLoans",
+			Handler:    _Borrowers_ListLoans_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "borrowers.proto",
+}
@@ -0,0 +1,8 @@
+// Package sbcpb is the gRPC API for internal services, generated from borrowers.proto.
+//
+// Regenerate it after changing the definition, with buf, protoc-gen-go and protoc-gen-go-grpc on the PATH:
+//
+//	go generate ./sbcpb
+package sbcpb
+
+//go:generate buf generate
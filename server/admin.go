@@ -0,0 +1,547 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"golang.org/x/net/context"
+
+	"cloud.google.com/go/datastore"
+	"firebase.google.com/go/auth"
+	"github.com/gorilla/mux"
+	"google.golang.org/api/iterator"
+)
+
+// Admin endpoints are for operations staff signed in with Firebase like borrowers. Their roles are a "roles"
+// list in their Firebase custom claims, set with set_staff_roles/; each route names the roles that may call it,
+// and admin may call every route. Every call is recorded in the audit log.
+
+const (
+	kRoleSupport     string = "support"      // Looks up borrowers and their loans
+	kRoleLoanOfficer string = "loan_officer" // Looks up borrowers and their loans, and decides held applications
+	kRoleFinance     string = "finance"      // Sees loans and ERA balances
+	kRoleAdmin       string = "admin"        // Everything, including the audit log, webhooks and settings
+)
+
+const kRolesClaim string = "roles"
+
+var ErrAdminForbidden = NewAPIError(http.StatusForbidden, "FORBIDDEN", "Not allowed.")
+
+type AdminUser struct {
+	Uid     string       `json:"uid"`
+	Email   string       `json:"email,omitempty"`
+	User    *User        `json:"user"`
+	Summary *LoanSummary `json:"summary,omitempty"` // Only when reading a single user
+}
+
+type AdminUserPage struct {
+	Users      []AdminUser `json:"users"`
+	NextCursor string      `json:"nextCursor,omitempty"`
+}
+
+type EraBalance struct {
+	Name           string          `json:"name"`
+	QinBalance     float64         `json:"qinBalance"`     // Starting QIN, plus collateral forfeited by defaulters, less rewards paid on repayment
+	InterestEarned float64         `json:"interestEarned"` // In the base currency, on repaid loans
+	NumSent        int64           `json:"numSent"`        // Loans with its terms still out
+	NumRepaid      int64           `json:"numRepaid"`
+	NumDefaulted   int64           `json:"numDefaulted"`
+	Totals         []CurrencyTotal `json:"totals"`
+}
+
+type EraBalancesResponse struct {
+	BaseCurrency string        `json:"baseCurrency"`
+	Eras         []*EraBalance `json:"eras"`
+}
+
+// Reads the staff roles out of a user's custom claims.
+func rolesFromClaims(claims map[string]interface{}) []string {
+	var roles []string
+	values, _ := claims[kRolesClaim].([]interface{})
+	for _, value := range values {
+		if role, ok := value.(string); ok {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+func hasRole(roles []string, allowed []string) bool {
+	for _, role := range roles {
+		if role == kRoleAdmin {
+			return true
+		}
+		for _, allowedRole := range allowed {
+			if role == allowedRole {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Turns away requests of users without one of the roles, and records every request in the audit log before it
+// is served.
+func RequireRole(handler http.HandlerFunc, roles ...string) http.HandlerFunc {
+	return RequireVerifiedUser(func(w http.ResponseWriter, r *http.Request) {
+		authResponse, _ := r.Context().Value(authContextKey{}).(FirebaseAuthResponse)
+
+		key, action, err := StartAdminAction(r, authResponse)
+		if err != nil {
+			WriteError(w, r, err)
+			return
+		}
+
+		writer := &statusWriter{ResponseWriter: w}
+		if hasRole(authResponse.Roles, roles) {
+			handler(writer, r)
+		} else {
+			WriteError(writer, r, ErrAdminForbidden)
+		}
+
+		if writer.status == 0 {
+			writer.status = http.StatusOK
+		}
+		FinishAdminAction(r, key, action, writer.status)
+	})
+}
+
+// Reads limit and cursor query parameters.
+func parsePage(v *Validator, values url.Values) (int, *datastore.Cursor) {
+	limit := kDefaultLoanPageSize
+	if value := values.Get("limit"); value != "" {
+		parsed, limit_err := strconv.Atoi(value)
+		if limit_err != nil {
+			v.Fail("limit", kFieldInvalidValue, "Must be a whole number.")
+		} else {
+			v.Range("limit", float64(parsed), 1, float64(kMaxLoanPageSize))
+			limit = parsed
+		}
+	}
+
+	var cursor *datastore.Cursor
+	if value := values.Get("cursor"); value != "" {
+		decoded, cursor_err := datastore.DecodeCursor(value)
+		if cursor_err != nil {
+			v.Fail("cursor", kFieldInvalidValue, "Must be a nextCursor from a previous page.")
+		} else {
+			cursor = &decoded
+		}
+	}
+	return limit, cursor
+}
+
+// GET /admin/users finds users by exactly one of uid, email, phoneNumber or a lastName prefix.
+func SearchUsers(w http.ResponseWriter, r *http.Request) {
+	values := r.URL.Query()
+	var v Validator
+
+	given := 0
+	for _, param := range []string{"uid", "email", "phoneNumber", "lastName"} {
+		if values.Get(param) != "" {
+			given++
+		}
+	}
+	if given != 1 {
+		v.Fail("query", kFieldRequired, "Provide exactly one of uid, email, phoneNumber or lastName.")
+	}
+	limit, cursor := parsePage(&v, values)
+
+	err := v.Err()
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	ctx := RequestContext(r)
+	page := AdminUserPage{Users: make([]AdminUser, 0)}
+
+	switch {
+	case values.Get("uid") != "" || values.Get("email") != "":
+		// Both name at most one user.
+		uid := values.Get("uid")
+		var email string
+		if uid == "" {
+			uid, email, err = lookupUidByEmail(ctx, values.Get("email"))
+		}
+		if err == nil && uid != "" {
+			var user *User
+			user, err = ReadUser(ctx, uid)
+			if err == nil {
+				page.Users = append(page.Users, AdminUser{Uid: uid, Email: email, User: user})
+			} else if err == datastore.ErrNoSuchEntity {
+				err = nil
+			}
+		}
+	default:
+		query := datastore.NewQuery(kUserKind)
+		if phoneNumber := values.Get("phoneNumber"); phoneNumber != "" {
+			query = query.Filter("PhoneNum =", phoneNumber)
+		} else {
+			// Datastore has no text search, but a range on the name finds those starting with the prefix.
+			lastName := values.Get("lastName")
+			query = query.Filter("Lastname >=", lastName).Filter("Lastname <", lastName+"\ufffd")
+		}
+		if cursor != nil {
+			query = query.Start(*cursor)
+		}
+		page.Users, page.NextCursor, err = queryUsers(ctx, query, limit)
+	}
+
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(page)
+}
+
+// The uid of the Firebase user with an email, or "" if there is none.
+func lookupUidByEmail(ctx context.Context, email string) (string, string, error) {
+	client, err := NewFirebaseAuth(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	userRecord, err := client.GetUserByEmail(ctx, email)
+	if auth.IsUserNotFound(err) {
+		return "", "", nil
+	} else if err != nil {
+		return "", "", err
+	}
+	return userRecord.UID, userRecord.Email, nil
+}
+
+func queryUsers(ctx context.Context, query *datastore.Query, limit int) ([]AdminUser, string, error) {
+	dbClient := GetDbClient()
+	defer func() { returnDbClient <- dbClient }()
+
+	users := make([]AdminUser, 0, limit)
+	it := dbClient.Run(ctx, query.Limit(limit))
+	for true {
+		var user User
+		key, err := it.Next(&user)
+		if err == iterator.Done {
+			break
+		} else if err != nil {
+			return nil, "", err
+		}
+		users = append(users, AdminUser{Uid: key.Name, User: completeUser(&user)})
+	}
+
+	if len(users) < limit {
+		return users, "", nil
+	}
+	cursor, err := it.Cursor()
+	if err != nil {
+		return nil, "", err
+	}
+	return users, cursor.String(), nil
+}
+
+// GET /admin/users/{uid} reads a user's profile and loan summary.
+func GetAdminUser(w http.ResponseWriter, r *http.Request) {
+	ctx := RequestContext(r)
+	uid := mux.Vars(r)["uid"]
+
+	user, err := ReadUser(ctx, uid)
+	if err == datastore.ErrNoSuchEntity {
+		err = ErrUserNotFound
+	}
+
+	var summary LoanSummary
+	if err == nil {
+		dbClient := GetDbClient()
+		err = dbClient.Get(ctx, LoanSummaryKey(uid), &summary)
+		returnDbClient <- dbClient
+
+		// Users who never asked for a loan have no summary yet.
+		if err == datastore.ErrNoSuchEntity {
+			err = nil
+		}
+	}
+
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	summary.Complete()
+	json.NewEncoder(w).Encode(AdminUser{Uid: uid, User: user, Summary: &summary})
+}
+
+// GET /admin/users/{uid}/loans pages through a user's loans like GET /v2/loans, but with the loan requests
+// they were made with and without defaulting overdue loans.
+func GetAdminUserLoans(w http.ResponseWriter, r *http.Request) {
+	uid := mux.Vars(r)["uid"]
+	loanQuery, err := ParseLoanQuery(r.URL.Query(), "-created", kDefaultLoanPageSize)
+
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	ctx := RequestContext(r)
+	dbClient := GetDbClient()
+
+	var loanPage LoanPage
+	err = dbClient.Get(ctx, LoanSummaryKey(uid), &loanPage.Summary)
+	if err == datastore.ErrNoSuchEntity {
+		err = nil
+	}
+	if err == nil {
+		loanPage.LoanRecords, loanPage.NextCursor, err = QueryLoans(ctx, dbClient, uid, loanQuery)
+	}
+
+	returnDbClient <- dbClient
+
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	loanPage.Summary.Complete()
+	json.NewEncoder(w).Encode(loanPage)
+}
+
+// GET /admin/users/{uid}/loans/{id} reads one loan with its loan request.
+func GetAdminUserLoan(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	dbClient := GetDbClient()
+	var loanRecord LoanRecord
+	err := dbClient.Get(RequestContext(r), LoanKey(vars["uid"], vars["id"]), &loanRecord)
+	returnDbClient <- dbClient
+
+	if err == datastore.ErrNoSuchEntity {
+		err = ErrLoanNotFound
+	}
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(loanRecord)
+}
+
+// Distinct values of a loan property, read from its index.
+func distinctLoanValues(ctx context.Context, dbClient *datastore.Client, property string, value func(loanRecord *LoanRecord) string) ([]string, error) {
+	var loanRecords []LoanRecord
+	query := datastore.NewQuery(kLoanKind).Project(property).DistinctOn(property)
+	if _, err := dbClient.GetAll(ctx, query, &loanRecords); err != nil {
+		return nil, err
+	}
+
+	values := make([]string, 0, len(loanRecords))
+	for i := range loanRecords {
+		if v := value(&loanRecords[i]); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
+
+// A Datastore sum, which is an int64 while every summed value is a whole number.
+func aggregateFloat(result datastore.AggregationResult, alias string) float64 {
+	switch value := result[alias].(type) {
+	case int64:
+		return float64(value)
+	case float64:
+		return value
+	}
+	return 0.0
+}
+
+// Works out each ERA's balances from the loans that went out on its terms, with an aggregation query per ERA,
+// state and currency rather than reading the loans.
+func ReadEraBalances(ctx context.Context, dbClient *datastore.Client) ([]*EraBalance, error) {
+	byName := make(map[string]*EraBalance)
+	var balances []*EraBalance
+	balanceOf := func(name string) *EraBalance {
+		balance, ok := byName[name]
+		if !ok {
+			balance = &EraBalance{Name: name, QinBalance: config.Era.InitialQinBalance, Totals: make([]CurrencyTotal, 0)}
+			byName[name] = balance
+			balances = append(balances, balance)
+		}
+		return balance
+	}
+	for _, name := range eraDriver._era_external_names {
+		balanceOf(name)
+	}
+
+	eras, err := distinctLoanValues(ctx, dbClient, "AcceptedTerms.OfferedBy", func(loanRecord *LoanRecord) string {
+		if loanRecord.AcceptedTerms == nil {
+			return ""
+		}
+		return loanRecord.AcceptedTerms.OfferedBy
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, era := range eras {
+		balanceOf(era)
+	}
+
+	currencies, err := distinctLoanValues(ctx, dbClient, "CurrencyCode", func(loanRecord *LoanRecord) string {
+		return loanRecord.CurrencyCode
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, balance := range balances {
+		for _, currencyCode := range currencies {
+			total := CurrencyTotal{CurrencyCode: currencyCode}
+
+			for _, state := range []string{"SENT", "REPAID", "DEFAULTED"} {
+				aggregation := datastore.NewQuery(kLoanKind).
+					Filter("State =", state).
+					Filter("AcceptedTerms.OfferedBy =", balance.Name).
+					Filter("CurrencyCode =", currencyCode).
+					NewAggregationQuery().
+					WithCount("count").
+					WithSum("Amount", "borrowed")
+				switch state {
+				case "REPAID":
+					// Loans are repaid in one payment of the amount owed.
+					aggregation = aggregation.
+						WithSum("AcceptedTerms.AmountOwed", "repaid").
+						WithSum("AcceptedTerms.QinReward", "qinRewarded").
+						WithSum("AcceptedTerms.Valuation.EraInterestReward", "interestEarned")
+				case "DEFAULTED":
+					aggregation = aggregation.WithSum("AcceptedTerms.QinRequired", "qinForfeited")
+				}
+
+				result, err := dbClient.RunAggregationQuery(ctx, aggregation)
+				if err != nil {
+					return nil, err
+				}
+
+				count, _ := result["count"].(int64)
+				switch state {
+				case "SENT":
+					balance.NumSent += count
+				case "REPAID":
+					balance.NumRepaid += count
+					balance.QinBalance -= aggregateFloat(result, "qinRewarded")
+					balance.InterestEarned += aggregateFloat(result, "interestEarned")
+					total.Repaid += aggregateFloat(result, "repaid")
+				case "DEFAULTED":
+					balance.NumDefaulted += count
+					balance.QinBalance += aggregateFloat(result, "qinForfeited")
+				}
+				total.Borrowed += aggregateFloat(result, "borrowed")
+			}
+
+			if total.Borrowed != 0.0 || total.Repaid != 0.0 {
+				balance.Totals = append(balance.Totals, total)
+			}
+		}
+	}
+
+	// ERAs in the order they are configured, then any others, such as the house terms, by name.
+	configured := len(eraDriver._era_external_names)
+	sort.SliceStable(balances[configured:], func(i, j int) bool {
+		return balances[configured+i].Name < balances[configured+j].Name
+	})
+
+	for _, balance := range balances {
+		balance.QinBalance = Round(balance.QinBalance*100.0) / 100.0
+		balance.InterestEarned = Round(balance.InterestEarned*10000.0) / 10000.0
+		for i := range balance.Totals {
+			balance.Totals[i].Borrowed = Round(balance.Totals[i].Borrowed*10000.0) / 10000.0
+			balance.Totals[i].Repaid = Round(balance.Totals[i].Repaid*10000.0) / 10000.0
+		}
+	}
+	return balances, nil
+}
+
+// GET /admin/eras shows each ERA's QIN balance, interest earned and loans.
+func GetEraBalances(w http.ResponseWriter, r *http.Request) {
+	dbClient := GetDbClient()
+	balances, err := ReadEraBalances(RequestContext(r), dbClient)
+	returnDbClient <- dbClient
+
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(EraBalancesResponse{BaseCurrency: currencyRegistry.BaseCurrency, Eras: balances})
+}
+
+type QinDiscrepancyPage struct {
+	Discrepancies []QinDiscrepancy `json:"discrepancies"`
+	NextCursor    string           `json:"nextCursor,omitempty"`
+}
+
+// GET /admin/qin-discrepancies lists users whose off-chain and on-chain QIN balances disagree, oldest first.
+func GetQinDiscrepancies(w http.ResponseWriter, r *http.Request) {
+	var v Validator
+	limit, cursor := parsePage(&v, r.URL.Query())
+
+	err := v.Err()
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	query := datastore.NewQuery(kQinDiscrepancyKind).Order("FirstFound")
+	if cursor != nil {
+		query = query.Start(*cursor)
+	}
+
+	dbClient := GetDbClient()
+
+	var page QinDiscrepancyPage
+	page.Discrepancies = make([]QinDiscrepancy, 0, limit)
+
+	it := dbClient.Run(RequestContext(r), query.Limit(limit))
+	for true {
+		var discrepancy QinDiscrepancy
+		_, next_err := it.Next(&discrepancy)
+		if next_err != nil {
+			if next_err != iterator.Done {
+				err = next_err
+			}
+			break
+		}
+		page.Discrepancies = append(page.Discrepancies, discrepancy)
+	}
+
+	if err == nil && len(page.Discrepancies) == limit {
+		next, cursor_err := it.Cursor()
+		if cursor_err == nil {
+			page.NextCursor = next.String()
+		}
+	}
+
+	returnDbClient <- dbClient
+
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(page)
+}
+
+func AddAdminRoutes(router *mux.Router) {
+	router.HandleFunc("/users", RequireRole(SearchUsers, kRoleSupport, kRoleLoanOfficer)).Methods("Get")
+	router.HandleFunc("/users/{uid}", RequireRole(GetAdminUser, kRoleSupport, kRoleLoanOfficer)).Methods("Get")
+	router.HandleFunc("/users/{uid}/loans", RequireRole(GetAdminUserLoans, kRoleSupport, kRoleLoanOfficer, kRoleFinance)).Methods("Get")
+	router.HandleFunc("/users/{uid}/loans/{id}", RequireRole(GetAdminUserLoan, kRoleSupport, kRoleLoanOfficer, kRoleFinance)).Methods("Get")
+	router.HandleFunc("/users/{uid}/loans/{id}/review", RequireRole(GetLoanReview, kRoleLoanOfficer)).Methods("Get")
+	router.HandleFunc("/users/{uid}/loans/{id}/review/claim", RequireRole(ClaimLoanReview, kRoleLoanOfficer)).Methods("Post")
+	router.HandleFunc("/users/{uid}/loans/{id}/review/approve", RequireRole(ApproveLoanReview, kRoleLoanOfficer)).Methods("Post")
+	router.HandleFunc("/users/{uid}/loans/{id}/review/reject", RequireRole(RejectLoanReview, kRoleLoanOfficer)).Methods("Post")
+	router.HandleFunc("/reviews", RequireRole(GetLoanReviews, kRoleLoanOfficer)).Methods("Get")
+	router.HandleFunc("/eras", RequireRole(GetEraBalances, kRoleFinance)).Methods("Get")
+	router.HandleFunc("/qin-discrepancies", RequireRole(GetQinDiscrepancies, kRoleFinance)).Methods("Get")
+	router.HandleFunc("/audit-log", RequireRole(GetAuditLog)).Methods("Get")
+	router.HandleFunc("/webhooks/deliveries", RequireRole(GetWebhookDeliveries)).Methods("Get")
+	router.HandleFunc("/webhooks/deliveries/{id}/replay", RequireRole(ReplayWebhookDelivery)).Methods("Post")
+}
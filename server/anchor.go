@@ -0,0 +1,776 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/BurntSushi/toml"
+	b "github.com/stellar/go/build"
+	"github.com/stellar/go/clients/horizon"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
+)
+
+// Cash-out and cash-in partners. Bloom is reached through its federation server; everyone else is a
+// Stellar anchor speaking SEP-6 (non-interactive) or SEP-24 (interactive) behind SEP-10 authentication.
+
+const (
+	kPartnerFederation string = "federation"
+	kPartnerSep6       string = "sep6"
+	kPartnerSep24      string = "sep24"
+)
+
+const kDefaultPartnerId string = "bloom"
+
+// Anchor transaction statuses that matter to us, as defined by SEP-6 and SEP-24.
+const (
+	kAnchorStatusIncomplete               string = "incomplete"
+	kAnchorStatusPendingUserTransferStart string = "pending_user_transfer_start"
+	kAnchorStatusCompleted                string = "completed"
+	kAnchorStatusError                    string = "error"
+)
+
+// Our own statuses for transfers that the anchor doesn't track.
+const (
+	kTransferNew        string = "new"        // The partner hasn't been asked where to send the funds yet
+	kTransferReady      string = "ready"      // Where to send the funds is known; nothing sent yet
+	kTransferSubmitting string = "submitting" // Payment signed and saved, about to be submitted
+	kTransferUnknown    string = "unknown"    // Submitted, but whether it was applied isn't known yet
+	kTransferSubmitted  string = "submitted"
+	kTransferFailed     string = "failed"
+)
+
+var (
+	ErrUnknownPartner      = NewAPIError(http.StatusBadRequest, "UNKNOWN_PARTNER", "Cash-out partner was not found.")
+	ErrPartnerNoCashIn     = NewAPIError(http.StatusBadRequest, "PARTNER_NO_CASH_IN", "Partner does not support cash-in.")
+	ErrAnchorTomlInvalid   = NewAPIError(http.StatusBadGateway, "ANCHOR_TOML_INVALID", "Anchor stellar.toml is missing required fields.")
+	ErrAnchorChallenge     = NewAPIError(http.StatusBadGateway, "ANCHOR_CHALLENGE_FAILED", "Anchor authentication challenge was invalid.")
+	ErrAnchorRequestFailed = NewAPIError(http.StatusBadGateway, "ANCHOR_REQUEST_FAILED", "Anchor request failed.")
+	ErrPartnerCurrency     = NewAPIError(http.StatusBadRequest, "PARTNER_CURRENCY_UNSUPPORTED", "Partner does not handle the loan currency.")
+)
+
+// Partner is a remittance center or anchor that borrowers can cash out at or pay in through.
+type Partner struct {
+	PartnerId     string          `json:"id"`
+	Name          string          `json:"name"`
+	Type          string          `json:"type"`
+	HomeDomain    string          `json:"homeDomain,omitempty"`    // Anchors; may carry a scheme for local testing
+	FederationUrl string          `json:"federationUrl,omitempty"` // Federation partners
+	Code          string          `json:"code,omitempty"`          // Federation partners
+	Assets        []*PartnerAsset `json:"assets"`
+	CashIn        bool            `json:"cashIn"`
+}
+
+// PartnerAsset is the Stellar asset a partner pays out or takes in for one currency.
+type PartnerAsset struct {
+	CurrencyCode string `json:"currencyCode"`
+	AssetCode    string `json:"assetCode"`
+	AssetIssuer  string `json:"assetIssuer"`
+}
+
+// AnchorTransfer tracks a deposit or withdrawal with a partner.
+type AnchorTransfer struct {
+	PartnerId      string `json:"partnerId"`
+	TransactionId  string `json:"transactionId,omitempty"`
+	InteractiveUrl string `json:"interactiveUrl,omitempty"`
+	Instructions   string `json:"instructions,omitempty"`
+	Status         string `json:"status,omitempty"`
+	// Only set on payouts
+	Payment *PartnerPayment `json:"-" datastore:",noindex"`
+	Pending bool            `json:"-"` // Until the payout is finished, for the worker that advances it
+}
+
+// Where a payout's funds go, and once claimed for sending, the signed payment. The payment is saved before it
+// is submitted and then only ever submitted again, never rebuilt, so a payout can't be sent twice.
+type PartnerPayment struct {
+	Address  string
+	MemoType string
+	Memo     string
+	TxHash   string
+	Envelope string
+}
+
+type StellarToml struct {
+	NetworkPassphrase   string `toml:"NETWORK_PASSPHRASE"`
+	SigningKey          string `toml:"SIGNING_KEY"`
+	WebAuthEndpoint     string `toml:"WEB_AUTH_ENDPOINT"`
+	TransferServer      string `toml:"TRANSFER_SERVER"`
+	TransferServerSep24 string `toml:"TRANSFER_SERVER_SEP0024"`
+	FederationServer    string `toml:"FEDERATION_SERVER"`
+}
+
+type challengeResponse struct {
+	Transaction       string `json:"transaction"`
+	NetworkPassphrase string `json:"network_passphrase"`
+}
+
+type tokenResponse struct {
+	Token string `json:"token"`
+}
+
+// Response to SEP-6 GET /withdraw and SEP-6 GET /deposit. Only the fields we use are listed.
+type sep6Response struct {
+	Id        string `json:"id"`
+	AccountId string `json:"account_id"`
+	MemoType  string `json:"memo_type"`
+	Memo      string `json:"memo"`
+	How       string `json:"how"`
+	Eta       int64  `json:"eta"`
+}
+
+type sep24InteractiveResponse struct {
+	Type string `json:"type"`
+	Url  string `json:"url"`
+	Id   string `json:"id"`
+}
+
+type anchorTransaction struct {
+	Id                    string `json:"id"`
+	Kind                  string `json:"kind"`
+	Status                string `json:"status"`
+	MoreInfoUrl           string `json:"more_info_url"`
+	WithdrawAnchorAccount string `json:"withdraw_anchor_account"`
+	WithdrawMemo          string `json:"withdraw_memo"`
+	WithdrawMemoType      string `json:"withdraw_memo_type"`
+}
+
+type anchorTransactionResponse struct {
+	Transaction anchorTransaction `json:"transaction"`
+}
+
+type anchorErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// Registry of configured partners by ID.
+var partners map[string]*Partner
+
+var defaultPartners = []*Partner{
+	{
+		PartnerId:     kDefaultPartnerId,
+		Name:          "Bloom Remit",
+		Type:          kPartnerFederation,
+		FederationUrl: "https://staging.bloomremit.net/stellar/federation",
+		Code:          "BOPIPHMM",
+		Assets: []*PartnerAsset{
+			{CurrencyCode: "PHP", AssetCode: "PHP", AssetIssuer: "GCBEJ5SNCV4B3E2TEDEUNR7DSC7Y4RLFAGSPNKZGNIOHQFWBHXCMMHZA"},
+		},
+	},
+}
+
+// Loads partners from a JSON file, falling back to the built-in Bloom partner when the file is missing.
+func LoadPartners(path string) error {
+	list := defaultPartners
+
+	bytes, err := ioutil.ReadFile(path)
+	if err == nil {
+		list = nil
+		if err := json.Unmarshal(bytes, &list); err != nil {
+			return err
+		}
+	}
+
+	partners = make(map[string]*Partner)
+	for _, partner := range list {
+		switch partner.Type {
+		case kPartnerFederation, kPartnerSep6, kPartnerSep24:
+		default:
+			return errors.New("Unknown partner type " + partner.Type + " for " + partner.PartnerId)
+		}
+		partners[partner.PartnerId] = partner
+	}
+	return nil
+}
+
+func (partner *Partner) AssetForCurrency(currencyCode string) (*PartnerAsset, error) {
+	for _, asset := range partner.Assets {
+		if asset.CurrencyCode == currencyCode {
+			return asset, nil
+		}
+	}
+	return nil, ErrPartnerCurrency
+}
+
+func PartnerForId(partnerId string) (*Partner, error) {
+	if partnerId == "" {
+		partnerId = kDefaultPartnerId
+	}
+	partner, ok := partners[partnerId]
+	if !ok {
+		return nil, ErrUnknownPartner
+	}
+	return partner, nil
+}
+
+// AnchorClient talks to a single anchor and caches its stellar.toml and SEP-10 token.
+type AnchorClient struct {
+	partner *Partner
+	http    *http.Client
+
+	mu          sync.Mutex
+	toml        *StellarToml
+	token       string
+	tokenExpiry time.Time
+}
+
+var anchorClients = struct {
+	sync.Mutex
+	m map[string]*AnchorClient
+}{m: make(map[string]*AnchorClient)}
+
+func AnchorClientForPartner(partner *Partner) *AnchorClient {
+	anchorClients.Lock()
+	defer anchorClients.Unlock()
+	client, ok := anchorClients.m[partner.PartnerId]
+	if !ok {
+		client = &AnchorClient{
+			partner: partner,
+			http: &http.Client{
+				Timeout: 10 * time.Second,
+			},
+		}
+		anchorClients.m[partner.PartnerId] = client
+	}
+	return client
+}
+
+// Home domains without a scheme are served over HTTPS as SEP-1 requires.
+func (c *AnchorClient) baseUrl() string {
+	if strings.HasPrefix(c.partner.HomeDomain, "http://") || strings.HasPrefix(c.partner.HomeDomain, "https://") {
+		return strings.TrimRight(c.partner.HomeDomain, "/")
+	}
+	return "https://" + c.partner.HomeDomain
+}
+
+// Fetches and caches the anchor's stellar.toml (SEP-1).
+func (c *AnchorClient) StellarToml(ctx context.Context) (*StellarToml, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.toml != nil {
+		return c.toml, nil
+	}
+
+	resp, err := c.send(ctx, "GET", c.baseUrl()+"/.well-known/stellar.toml", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, ErrAnchorRequestFailed
+	}
+
+	var stellarToml StellarToml
+	if _, err := toml.DecodeReader(resp.Body, &stellarToml); err != nil {
+		return nil, err
+	}
+
+	if stellarToml.WebAuthEndpoint == "" || stellarToml.SigningKey == "" {
+		return nil, ErrAnchorTomlInvalid
+	}
+	if c.partner.Type == kPartnerSep6 && stellarToml.TransferServer == "" {
+		return nil, ErrAnchorTomlInvalid
+	}
+	if c.partner.Type == kPartnerSep24 && stellarToml.TransferServerSep24 == "" {
+		return nil, ErrAnchorTomlInvalid
+	}
+
+	c.toml = &stellarToml
+	return c.toml, nil
+}
+
+func (c *AnchorClient) transferServer(stellarToml *StellarToml) string {
+	if c.partner.Type == kPartnerSep24 {
+		return strings.TrimRight(stellarToml.TransferServerSep24, "/")
+	}
+	return strings.TrimRight(stellarToml.TransferServer, "/")
+}
+
+// Checks that a SEP-10 challenge is a sequence 0 transaction from the anchor's signing key, signed by it,
+// whose only operation is a manage_data on our account and whose time bounds include now.
+func verifyChallenge(env *xdr.TransactionEnvelope, signingKey string, clientAddress string, passphrase string) error {
+	if env.Tx.SeqNum != 0 || env.Tx.SourceAccount.Address() != signingKey {
+		return ErrAnchorChallenge
+	}
+
+	if len(env.Tx.Operations) != 1 {
+		return ErrAnchorChallenge
+	}
+
+	op := env.Tx.Operations[0]
+	if op.Body.Type != xdr.OperationTypeManageData || op.SourceAccount == nil || op.SourceAccount.Address() != clientAddress {
+		return ErrAnchorChallenge
+	}
+
+	// A challenge without an expiry could be replayed forever.
+	timeBounds := env.Tx.TimeBounds
+	if timeBounds == nil || timeBounds.MaxTime == 0 {
+		return ErrAnchorChallenge
+	}
+	now := uint64(time.Now().Unix())
+	if now < uint64(timeBounds.MinTime) || now > uint64(timeBounds.MaxTime) {
+		return ErrAnchorChallenge
+	}
+
+	hash, err := network.HashTransaction(&env.Tx, passphrase)
+	if err != nil {
+		return err
+	}
+
+	anchorKey, err := keypair.Parse(signingKey)
+	if err != nil {
+		return err
+	}
+
+	for _, sig := range env.Signatures {
+		if anchorKey.Verify(hash[:], sig.Signature) == nil {
+			return nil
+		}
+	}
+
+	return ErrAnchorChallenge
+}
+
+// Reads the expiry out of a JWT without verifying it; the anchor is the one that checks the signature.
+func jwtExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}
+	}
+	return time.Unix(claims.Exp, 0)
+}
+
+// Authenticates with the anchor using SEP-10 and returns a cached JWT while it is still valid.
+func (c *AnchorClient) Authenticate(ctx context.Context) (string, error) {
+	stellarToml, err := c.StellarToml(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && time.Now().Add(time.Minute).Before(c.tokenExpiry) {
+		return c.token, nil
+	}
+
+	account, err := ServerAddress()
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.send(ctx, "GET", stellarToml.WebAuthEndpoint+"?account="+url.QueryEscape(account), "", nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var challenge challengeResponse
+	if err := decodeAnchorResponse(resp, &challenge); err != nil {
+		return "", err
+	}
+
+	passphrase := challenge.NetworkPassphrase
+	if passphrase == "" {
+		passphrase = stellarToml.NetworkPassphrase
+	}
+	if passphrase == "" {
+		passphrase = config.network.Passphrase
+	}
+
+	var env xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(challenge.Transaction, &env); err != nil {
+		return "", err
+	}
+
+	if err := verifyChallenge(&env, stellarToml.SigningKey, account, passphrase); err != nil {
+		return "", err
+	}
+
+	hash, err := network.HashTransaction(&env.Tx, passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	sig, err := serverSigner.SignHash(hash)
+	if err != nil {
+		return "", err
+	}
+	env.Signatures = append(env.Signatures, sig)
+
+	signed, err := xdr.MarshalBase64(env)
+	if err != nil {
+		return "", err
+	}
+
+	body, _ := json.Marshal(challengeResponse{Transaction: signed})
+	resp, err = c.send(ctx, "POST", stellarToml.WebAuthEndpoint, "application/json", strings.NewReader(string(body)))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var token tokenResponse
+	if err := decodeAnchorResponse(resp, &token); err != nil {
+		return "", err
+	}
+
+	c.token = token.Token
+	c.tokenExpiry = jwtExpiry(token.Token)
+	return c.token, nil
+}
+
+// Makes a request to the anchor that needs no SEP-10 token, tagged with the request ID in ctx.
+func (c *AnchorClient) send(ctx context.Context, method string, target string, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	SetOutgoingRequestId(ctx, req)
+	return c.http.Do(req)
+}
+
+func decodeAnchorResponse(resp *http.Response, v interface{}) error {
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var anchorErr anchorErrorResponse
+		json.NewDecoder(resp.Body).Decode(&anchorErr)
+		if anchorErr.Error != "" {
+			return errors.New("Anchor request failed: " + anchorErr.Error)
+		}
+		return ErrAnchorRequestFailed
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func (c *AnchorClient) do(ctx context.Context, method string, path string, params url.Values, v interface{}) error {
+	stellarToml, err := c.StellarToml(ctx)
+	if err != nil {
+		return err
+	}
+
+	token, err := c.Authenticate(ctx)
+	if err != nil {
+		return err
+	}
+
+	var req *http.Request
+	if method == "GET" {
+		req, err = http.NewRequest(method, c.transferServer(stellarToml)+path+"?"+params.Encode(), nil)
+	} else {
+		req, err = http.NewRequest(method, c.transferServer(stellarToml)+path, strings.NewReader(params.Encode()))
+		if req != nil {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	}
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	SetOutgoingRequestId(ctx, req)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return decodeAnchorResponse(resp, v)
+}
+
+// Starts a withdrawal (cash-out) of amount from our account. For SEP-6 the response already contains where to
+// send the funds; for SEP-24 the borrower has to finish the interactive flow first.
+func (c *AnchorClient) StartWithdraw(ctx context.Context, amount float64, currencyCode string, loanId string) (*AnchorTransfer, *sep6Response, error) {
+	asset, err := c.partner.AssetForCurrency(currencyCode)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	account, err := ServerAddress()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := url.Values{}
+	params.Set("asset_code", asset.AssetCode)
+	params.Set("account", account)
+	params.Set("amount", strconv.FormatFloat(amount, 'f', -1, 64))
+
+	transfer := &AnchorTransfer{PartnerId: c.partner.PartnerId}
+
+	if c.partner.Type == kPartnerSep24 {
+		var interactive sep24InteractiveResponse
+		if err := c.do(ctx, "POST", "/transactions/withdraw/interactive", params, &interactive); err != nil {
+			return nil, nil, err
+		}
+		transfer.TransactionId = interactive.Id
+		transfer.InteractiveUrl = interactive.Url
+		transfer.Status = kAnchorStatusIncomplete
+		return transfer, nil, nil
+	}
+
+	params.Set("type", "cash")
+	params.Set("dest", loanId)
+
+	var withdraw sep6Response
+	if err := c.do(ctx, "GET", "/withdraw", params, &withdraw); err != nil {
+		return nil, nil, err
+	}
+	transfer.TransactionId = withdraw.Id
+	transfer.Status = kAnchorStatusPendingUserTransferStart
+	return transfer, &withdraw, nil
+}
+
+// Starts a deposit (cash-in) into our account, tagged with the loan ID so the repayment can be matched.
+func (c *AnchorClient) StartDeposit(ctx context.Context, amount float64, currencyCode string, loanId string) (*AnchorTransfer, error) {
+	if !c.partner.CashIn {
+		return nil, ErrPartnerNoCashIn
+	}
+
+	asset, err := c.partner.AssetForCurrency(currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := ServerAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("asset_code", asset.AssetCode)
+	params.Set("account", account)
+	params.Set("amount", strconv.FormatFloat(amount, 'f', -1, 64))
+	params.Set("memo_type", "text")
+	params.Set("memo", loanId)
+
+	transfer := &AnchorTransfer{PartnerId: c.partner.PartnerId}
+
+	if c.partner.Type == kPartnerSep24 {
+		var interactive sep24InteractiveResponse
+		if err := c.do(ctx, "POST", "/transactions/deposit/interactive", params, &interactive); err != nil {
+			return nil, err
+		}
+		transfer.TransactionId = interactive.Id
+		transfer.InteractiveUrl = interactive.Url
+		transfer.Status = kAnchorStatusIncomplete
+		return transfer, nil
+	}
+
+	var deposit sep6Response
+	if err := c.do(ctx, "GET", "/deposit", params, &deposit); err != nil {
+		return nil, err
+	}
+	transfer.TransactionId = deposit.Id
+	transfer.Instructions = deposit.How
+	transfer.Status = kAnchorStatusPendingUserTransferStart
+	return transfer, nil
+}
+
+func (c *AnchorClient) Transaction(ctx context.Context, id string) (*anchorTransaction, error) {
+	params := url.Values{}
+	params.Set("id", id)
+
+	var resp anchorTransactionResponse
+	if err := c.do(ctx, "GET", "/transaction", params, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Transaction, nil
+}
+
+func memoMutator(memoType string, memo string) (b.TransactionMutator, error) {
+	switch memoType {
+	case "", "text":
+		return b.MemoText{Value: memo}, nil
+	case "id":
+		id, err := strconv.ParseUint(memo, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return b.MemoID{Value: id}, nil
+	case "hash":
+		// SEP-6 sends hash memos base64 encoded.
+		raw, err := base64.StdEncoding.DecodeString(memo)
+		if err != nil || len(raw) != 32 {
+			return nil, errors.New("Invalid hash memo")
+		}
+		var hash xdr.Hash
+		copy(hash[:], raw)
+		return b.MemoHash{Value: hash}, nil
+	default:
+		return nil, errors.New("Unsupported memo type " + memoType)
+	}
+}
+
+// The payment of a payout to a partner in its asset for the loan currency, converting from XLM along the way.
+func PartnerPaymentTransaction(hc *horizon.Client, partner *Partner, currencyCode string, payment *PartnerPayment, amount float64) (*b.TransactionBuilder, error) {
+	asset, err := partner.AssetForCurrency(currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	memoMut, err := memoMutator(payment.MemoType, payment.Memo)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := ServerAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Transaction(
+		b.SourceAccount{AddressOrSeed: source},
+		config.network.Mutator(),
+		b.AutoSequence{SequenceProvider: hc},
+		b.Payment(
+			b.Destination{AddressOrSeed: payment.Address},
+			b.CreditAmount{Code: asset.AssetCode, Issuer: asset.AssetIssuer, Amount: strconv.FormatFloat(amount, 'f', -1, 64)},
+			b.PayWith(b.Asset{Native: true}, "1000000"),
+		),
+		memoMut,
+	)
+}
+
+// Starts paying out a loan through the chosen partner. The returned transfer is stored on the loan; nothing is
+// sent until it has been, by PayOutLoan.
+func Disburse(ctx context.Context, partner *Partner, amount float64, currencyCode string, loanId string) (*AnchorTransfer, error) {
+	if partner.Type == kPartnerFederation {
+		address, memo, err := GetFederationAddressAndMemo(ctx, partner)
+		if err != nil {
+			return nil, err
+		}
+		return &AnchorTransfer{
+			PartnerId: partner.PartnerId,
+			Status:    kTransferReady,
+			Payment:   &PartnerPayment{Address: address, MemoType: "text", Memo: memo},
+			Pending:   true,
+		}, nil
+	}
+
+	client := AnchorClientForPartner(partner)
+	transfer, withdraw, err := client.StartWithdraw(ctx, amount, currencyCode, loanId)
+	if err != nil {
+		return nil, err
+	}
+
+	// SEP-24 partners need the borrower to finish the interactive flow before funds can be sent.
+	if withdraw != nil {
+		transfer.Status = kTransferReady
+		transfer.Payment = &PartnerPayment{Address: withdraw.AccountId, MemoType: withdraw.MemoType, Memo: withdraw.Memo}
+	}
+	transfer.Pending = true
+	return transfer, nil
+}
+
+// The payout of a loan being sent, written with it. The worker asks the partner where the funds go.
+func NewDisbursement(partnerId string) *AnchorTransfer {
+	return &AnchorTransfer{PartnerId: partnerId, Status: kTransferNew, Pending: true}
+}
+
+// Whether a payout has reached a status it won't leave. Payouts through an anchor are finished once the anchor
+// says so; those to a federation address once the payment is applied.
+func (transfer *AnchorTransfer) Finished() bool {
+	switch transfer.Status {
+	case kAnchorStatusCompleted, kAnchorStatusError, kTransferFailed:
+		return true
+	case kTransferSubmitted:
+		return transfer.TransactionId == ""
+	}
+	return false
+}
+
+// Tracks an anchor's side of a payout: where to send the funds once an interactive withdrawal is ready for
+// them, and whether the anchor finished after they were sent. Returns whether the transfer changed.
+func AdvanceDisbursement(ctx context.Context, transfer *AnchorTransfer) (bool, error) {
+	if transfer.TransactionId == "" || transfer.Finished() {
+		return false, nil
+	}
+
+	// Sending is left to PayOutLoan.
+	switch transfer.Status {
+	case kTransferReady, kTransferSubmitting, kTransferUnknown:
+		return false, nil
+	}
+
+	partner, err := PartnerForId(transfer.PartnerId)
+	if err != nil {
+		return false, err
+	}
+
+	anchorTx, err := AnchorClientForPartner(partner).Transaction(ctx, transfer.TransactionId)
+	if err != nil {
+		return false, err
+	}
+
+	if anchorTx.Status == kAnchorStatusPendingUserTransferStart && transfer.Status != kTransferSubmitted {
+		transfer.Status = kTransferReady
+		transfer.Payment = &PartnerPayment{Address: anchorTx.WithdrawAnchorAccount, MemoType: anchorTx.WithdrawMemoType, Memo: anchorTx.WithdrawMemo}
+		return true, nil
+	}
+
+	// Once submitted we only care about the anchor finishing or failing.
+	if transfer.Status == kTransferSubmitted && anchorTx.Status != kAnchorStatusCompleted && anchorTx.Status != kAnchorStatusError {
+		return false, nil
+	}
+
+	if anchorTx.Status == transfer.Status {
+		return false, nil
+	}
+	transfer.Status = anchorTx.Status
+	return true, nil
+}
+
+// Refreshes the status of a cash-in and reports whether the anchor has completed it.
+func CheckCashIn(ctx context.Context, transfer *AnchorTransfer) (bool, error) {
+	partner, err := PartnerForId(transfer.PartnerId)
+	if err != nil {
+		return false, err
+	}
+
+	anchorTx, err := AnchorClientForPartner(partner).Transaction(ctx, transfer.TransactionId)
+	if err != nil {
+		return false, err
+	}
+
+	transfer.Status = anchorTx.Status
+	return anchorTx.Status == kAnchorStatusCompleted, nil
+}
+
+func GetPartners(w http.ResponseWriter, r *http.Request) {
+	list := make([]*Partner, 0, len(partners))
+	for _, partner := range partners {
+		list = append(list, partner)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].PartnerId < list[j].PartnerId })
+
+	json.NewEncoder(w).Encode(list)
+}
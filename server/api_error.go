@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"cloud.google.com/go/datastore"
+)
+
+// Errors clients can act on are APIErrors, which carry their own status and a stable code. Anything
+// else is reported as INTERNAL without its message and logged with the request ID.
+
+const kRequestIdHeader string = "X-Request-Id"
+
+// Request IDs passed in by a proxy are kept if they look sane.
+var requestIdPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+type FieldError struct {
+	Field   string `json:"field"` // JSON path of the field, e.g. "employmentInfo.employmentStatus"
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type APIError struct {
+	Status  int
+	Code    string
+	Message string // English; localized messages are looked up by Code
+	Fields  []FieldError
+}
+
+func NewAPIError(status int, code string, message string) *APIError {
+	return &APIError{Status: status, Code: code, Message: message}
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// Returns a copy of the error with field level details. The copy is not == to e, so compare codes instead.
+func (e *APIError) WithFields(fields ...FieldError) *APIError {
+	withFields := *e
+	withFields.Fields = append(append([]FieldError(nil), e.Fields...), fields...)
+	return &withFields
+}
+
+type ErrorBody struct {
+	Code      string       `json:"code"`
+	Message   string       `json:"message"`
+	RequestId string       `json:"requestId"`
+	Fields    []FieldError `json:"fields,omitempty"`
+}
+
+type ErrorEnvelope struct {
+	Error ErrorBody `json:"error"`
+}
+
+var (
+	ErrNotFound = NewAPIError(http.StatusNotFound, "NOT_FOUND", "The requested resource was not found.")
+	ErrInternal = NewAPIError(http.StatusInternalServerError, "INTERNAL", "Something went wrong on our side.")
+)
+
+// Maps any error onto the APIError clients will see.
+func AsAPIError(err error) *APIError {
+	if apiErr, ok := err.(*APIError); ok {
+		return apiErr
+	}
+	if err == datastore.ErrNoSuchEntity {
+		return ErrNotFound
+	}
+	return ErrInternal
+}
+
+// Returns the ID of the request, taking it from the proxy or making one up, and echoes it in the response.
+func RequestId(w http.ResponseWriter, r *http.Request) string {
+	if id := w.Header().Get(kRequestIdHeader); id != "" {
+		return id
+	}
+
+	id := r.Header.Get(kRequestIdHeader)
+	if !requestIdPattern.MatchString(id) {
+		id = newRequestId()
+	}
+
+	w.Header().Set(kRequestIdHeader, id)
+	return id
+}
+
+// Writes err as a JSON error envelope in the language the client asked for.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	requestId := RequestId(w, r)
+	apiErr := AsAPIError(err)
+
+	// Log internal server errors.
+	if apiErr.Status >= http.StatusInternalServerError {
+		Logger(r.Context()).Error("Internal error", "err", err)
+	}
+
+	lang := PreferredLanguage(r)
+	body := ErrorBody{
+		Code:      apiErr.Code,
+		Message:   LocalizedMessage(lang, apiErr.Code, apiErr.Message),
+		RequestId: requestId,
+	}
+	for _, field := range apiErr.Fields {
+		field.Message = LocalizedMessage(lang, field.Code, field.Message)
+		body.Fields = append(body.Fields, field)
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Language", lang)
+	w.WriteHeader(apiErr.Status)
+	json.NewEncoder(w).Encode(ErrorEnvelope{Error: body})
+}
+
+// Picks the first language in Accept-Language that we have messages for, in the order the client listed them.
+func PreferredLanguage(r *http.Request) string {
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag := strings.ToLower(strings.TrimSpace(strings.SplitN(part, ";", 2)[0]))
+		base := strings.SplitN(tag, "-", 2)[0]
+		if alias, ok := languageAliases[base]; ok {
+			base = alias
+		}
+		if base == kDefaultLanguage {
+			return base
+		}
+		if _, ok := errorMessages[base]; ok {
+			return base
+		}
+	}
+	return kDefaultLanguage
+}
+
+func LocalizedMessage(lang string, code string, fallback string) string {
+	if message, ok := errorMessages[lang][code]; ok {
+		return message
+	}
+	return fallback
+}
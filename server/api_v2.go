@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// v2 addresses loans by ID under /v2/loans/{id} instead of through the single active loan.
+
+type AcceptOfferRequest struct {
+	Location  PickupLocation `json:"pickupLocation,omitempty"` // Optionally choose the location in the same call
+	PartnerId string         `json:"partnerId,omitempty"`
+}
+
+type PickupLocationRequest struct {
+	Location  PickupLocation `json:"pickupLocation"`
+	PartnerId string         `json:"partnerId,omitempty"`
+}
+
+// Decodes an optional JSON body; an empty body leaves v untouched.
+func decodeOptionalRequest(r *http.Request, v interface{}) error {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return ErrBadJsonPopulation
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return DecodeRequest(r, v)
+}
+
+// GET /v2/loans/{id}
+func GetLoanV2(w http.ResponseWriter, r *http.Request) {
+	loanRecord, err := ReadLoan(RequestContext(r), RequestUid(r), mux.Vars(r)["id"])
+
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	loanRecord.Request = nil
+	json.NewEncoder(w).Encode(loanRecord)
+}
+
+// POST /v2/loans/{id}/offers/{termId}/accept
+func AcceptOfferV2(w http.ResponseWriter, r *http.Request) {
+	var acceptRequest AcceptOfferRequest
+	err := decodeOptionalRequest(r, &acceptRequest)
+
+	selection := LoanSelectRequest{
+		SelectedTerm: mux.Vars(r)["termId"],
+		Location:     acceptRequest.Location,
+		PartnerId:    acceptRequest.PartnerId,
+	}
+
+	if err == nil {
+		var v Validator
+		selection.Validate(&v)
+		err = v.Err()
+	}
+
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	loanRecord, err := SelectLoanTerms(RequestContext(r), RequestUid(r), mux.Vars(r)["id"], selection)
+
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	loanRecord.Request = nil
+	json.NewEncoder(w).Encode(loanRecord)
+}
+
+// PUT /v2/loans/{id}/pickup-location, once terms have been accepted.
+func SetPickupLocationV2(w http.ResponseWriter, r *http.Request) {
+	var locationRequest PickupLocationRequest
+	err := DecodeRequest(r, &locationRequest)
+
+	selection := LoanSelectRequest{
+		Location:  locationRequest.Location,
+		PartnerId: locationRequest.PartnerId,
+	}
+
+	if err == nil {
+		var v Validator
+		if selection.Location.LocationId == "" && selection.Location.LocationName == "" {
+			v.Fail("pickupLocation", kFieldRequired, "This field is required.")
+		} else {
+			selection.Validate(&v)
+		}
+		err = v.Err()
+	}
+
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	loanRecord, err := SelectLoanTerms(RequestContext(r), RequestUid(r), mux.Vars(r)["id"], selection)
+
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	loanRecord.Request = nil
+	json.NewEncoder(w).Encode(loanRecord)
+}
+
+// POST /v2/loans/{id}/repayments
+func RepayLoanV2(w http.ResponseWriter, r *http.Request) {
+	var repayRequest RepayRequest
+	err := decodeOptionalRequest(r, &repayRequest)
+
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	loanRecord, done, err := RepayLoan(RequestContext(r), RequestUid(r), mux.Vars(r)["id"], repayRequest)
+
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	loanRecord.Request = nil
+	if !done {
+		w.WriteHeader(http.StatusAccepted)
+	}
+	json.NewEncoder(w).Encode(loanRecord)
+}
+
+// DELETE /v2/loans/{id}
+func CancelLoanV2(w http.ResponseWriter, r *http.Request) {
+	loanRecord, err := CancelLoan(RequestContext(r), RequestUid(r), mux.Vars(r)["id"])
+
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	loanRecord.Request = nil
+	json.NewEncoder(w).Encode(loanRecord)
+}
+
+// GET /v2/loans, newest first.
+func ListLoansV2(w http.ResponseWriter, r *http.Request) {
+	loanQuery, err := ParseLoanQuery(r.URL.Query(), "-created", kDefaultLoanPageSize)
+
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	loanPage, err := ListLoans(RequestContext(r), RequestUid(r), loanQuery)
+
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(loanPage)
+}
+
+func AddV2Routes(router *mux.Router) {
+	router.HandleFunc("/user", RequireUser(GetUser)).Methods("Get")
+	router.HandleFunc("/user", RequireUser(Idempotent(CreateUser))).Methods("Post")
+	router.HandleFunc("/user", RequireVerifiedUser(Idempotent(PatchUser))).Methods("Patch")
+	router.HandleFunc("/loans", RequireVerifiedUser(ListLoansV2)).Methods("Get")
+	router.HandleFunc("/loans", RequireVerifiedUser(Idempotent(LoanRequestFun))).Methods("Post")
+	router.HandleFunc("/loans/{id}", RequireVerifiedUser(GetLoanV2)).Methods("Get")
+	router.HandleFunc("/loans/{id}", RequireVerifiedUser(Idempotent(CancelLoanV2))).Methods("Delete")
+	router.HandleFunc("/loans/{id}/offers/{termId}/accept", RequireVerifiedUser(Idempotent(AcceptOfferV2))).Methods("Post")
+	router.HandleFunc("/loans/{id}/pickup-location", RequireVerifiedUser(Idempotent(SetPickupLocationV2))).Methods("Put")
+	router.HandleFunc("/loans/{id}/repayments", RequireVerifiedUser(Idempotent(RepayLoanV2))).Methods("Post")
+	router.HandleFunc("/partners", RequireUser(GetPartners)).Methods("Get")
+	router.HandleFunc("/locations", RequireUser(GetLocations)).Methods("Get")
+	router.HandleFunc("/events", TokenFromQuery(RequireVerifiedUser(GetEvents))).Methods("Get")
+}
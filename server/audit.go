@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"github.com/gorilla/mux"
+	"google.golang.org/api/iterator"
+)
+
+// Every request to an admin route is recorded before it is carried out, including those turned away for lacking
+// a role, with who made it, what they asked for and the borrower it was about; the status is filled in once it
+// has been served. A request that can't be recorded is not carried out. Records are kept in Datastore and listed
+// at GET /admin/audit-log, newest first.
+
+const kAdminActionKind string = "adminAction"
+
+var ErrAuditLogUnavailable = NewAPIError(http.StatusServiceUnavailable, "AUDIT_LOG_UNAVAILABLE", "The request could not be recorded in the audit log, so it was not carried out.")
+
+type AdminAction struct {
+	ActionId   string   `json:"id" datastore:"-"` // Encoded datastore key
+	Actor      string   `json:"actor"`            // Staff member's uid
+	ActorEmail string   `json:"actorEmail,omitempty" datastore:",noindex"`
+	Roles      []string `json:"roles" datastore:",noindex"`
+	Action     string   `json:"action"`            // Method and route template, e.g. "GET /admin/users/{uid}"
+	Subject    string   `json:"subject,omitempty"` // Borrower acted on, if any
+	Path       string   `json:"path" datastore:",noindex"`
+	Query      string   `json:"query,omitempty" datastore:",noindex"`
+	Status     int      `json:"status" datastore:",noindex"` // 0 if the request never finished
+	RequestId  string   `json:"requestId" datastore:",noindex"`
+	Created    int64    `json:"created"`
+}
+
+type AdminActionPage struct {
+	Actions    []AdminAction `json:"actions"`
+	NextCursor string        `json:"nextCursor,omitempty"`
+}
+
+// Records a request to an admin route before it is served. Returns the key to finish the record with.
+func StartAdminAction(r *http.Request, authResponse FirebaseAuthResponse) (*datastore.Key, *AdminAction, error) {
+	action := &AdminAction{
+		Actor:      authResponse.UserInfo.UID,
+		ActorEmail: authResponse.UserInfo.Email,
+		Roles:      authResponse.Roles,
+		Action:     r.Method + " " + routeTemplate(r),
+		Subject:    mux.Vars(r)["uid"],
+		Path:       r.URL.Path,
+		Query:      r.URL.RawQuery,
+		RequestId:  RequestIdFrom(r.Context()),
+		Created:    time.Now().UnixNano() / int64(time.Millisecond),
+	}
+
+	ctx := RequestContext(r)
+
+	dbClient := GetDbClient()
+	key, err := dbClient.Put(ctx, datastore.IncompleteKey(kAdminActionKind, nil), action)
+	returnDbClient <- dbClient
+
+	if err != nil {
+		Logger(ctx).Error("Failed to record admin action", "action", action.Action, "err", err)
+		return nil, nil, ErrAuditLogUnavailable
+	}
+	return key, action, nil
+}
+
+// Fills in the status a recorded request was served with.
+func FinishAdminAction(r *http.Request, key *datastore.Key, action *AdminAction, status int) {
+	ctx := RequestContext(r)
+	Logger(ctx).Info("Admin action", "action", action.Action, "subject", action.Subject, "status", status)
+
+	action.Status = status
+
+	dbClient := GetDbClient()
+	_, err := dbClient.Put(ctx, key, action)
+	returnDbClient <- dbClient
+
+	// The request itself is already on record.
+	if err != nil {
+		Logger(ctx).Error("Failed to record admin action status", "action", action.Action, "err", err)
+	}
+}
+
+// GET /admin/audit-log lists admin actions, optionally only those of an actor or about a subject.
+func GetAuditLog(w http.ResponseWriter, r *http.Request) {
+	values := r.URL.Query()
+	var v Validator
+
+	query := datastore.NewQuery(kAdminActionKind)
+	if actor := values.Get("actor"); actor != "" {
+		query = query.Filter("Actor =", actor)
+	}
+	if subject := values.Get("subject"); subject != "" {
+		query = query.Filter("Subject =", subject)
+	}
+	query = query.Order("-Created")
+
+	limit, cursor := parsePage(&v, values)
+	if cursor != nil {
+		query = query.Start(*cursor)
+	}
+
+	err := v.Err()
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	dbClient := GetDbClient()
+
+	var page AdminActionPage
+	page.Actions = make([]AdminAction, 0, limit)
+
+	it := dbClient.Run(RequestContext(r), query.Limit(limit))
+	for true {
+		var action AdminAction
+		key, next_err := it.Next(&action)
+		if next_err != nil {
+			if next_err != iterator.Done {
+				err = next_err
+			}
+			break
+		}
+		action.ActionId = key.Encode()
+		page.Actions = append(page.Actions, action)
+	}
+
+	if err == nil && len(page.Actions) == limit {
+		next, cursor_err := it.Cursor()
+		if cursor_err == nil {
+			page.NextCursor = next.String()
+		}
+	}
+
+	returnDbClient <- dbClient
+
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(page)
+}
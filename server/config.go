@@ -0,0 +1,507 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/OneDaijo/sbc-demo-backend/stellarutil"
+)
+
+// Settings come from, in increasing order of precedence: the defaults below, the profile's defaults, the
+// config file, SBC_* environment variables and flags. Every setting is a flag, so the flag set doubles as the
+// schema: -addr is "addr" in the config file and SBC_ADDR in the environment. Secrets are never flags and
+// only come from the config file or the environment.
+
+const kEnvPrefix string = "SBC_"
+
+const (
+	kProfileLocal      string = "local"
+	kProfileStaging    string = "staging"
+	kProfileProduction string = "production"
+)
+
+// Where a setting got its value.
+const (
+	kSourceDefault string = "default"
+	kSourceProfile string = "profile"
+	kSourceFile    string = "file"
+	kSourceEnv     string = "env"
+	kSourceFlag    string = "flag"
+)
+
+// Defaults per profile, on top of those the flags are registered with.
+var profileDefaults = map[string]map[string]string{
+	kProfileLocal: {
+		"project-id":      "testfaketest-a6c57",
+		"allowed-origins": `(\.onedaijo\.com|^https?://localhost)(?::\d+)?$`,
+		"log-format":      kLogFormatText,
+	},
+	kProfileStaging:    {},
+	kProfileProduction: {},
+}
+
+type EraModelConfig struct {
+	Name        string  // Shown to borrowers as OfferedBy
+	RejectAbove float64 // Probability of default above which the ERA rejects the borrower
+}
+
+type EraConfig struct {
+	MaxInterestRate   float64 // Maximum interest rate that we are willing to set
+	MaxQinCollateral  float64 // Maximum collateral in terms of qin that a borrower is expected to have
+	GraceNumLoans     uint64  // Number of loans where the borrower need not have any QIN
+	InitialQinBalance float64 // Initial QIN starting balance of the ERAs
+	InterestFraction  float64 // Fraction of the principal rewarded to the ERA
+	Kiva              EraModelConfig
+	Prosper           EraModelConfig
+	Naive             EraModelConfig
+	Random            EraModelConfig
+}
+
+// Rules that hold a loan application for a loan officer to review. Each is off at its zero value.
+type ReviewConfig struct {
+	AmountAbove       float64       // In the base currency
+	FirstLoan         bool          // Borrowers who have never repaid or defaulted on a loan
+	EraSpread         float64       // Between the highest and lowest interest rate offered
+	MissingEmployment bool          // No employment status, income or start year
+	Sla               time.Duration // How long a review may take
+}
+
+type Config struct {
+	Profile    string
+	ConfigFile string
+
+	Addr           string
+	MetricsAddr    string
+	CertFile       string
+	KeyFile        string
+	ProjectId      string // Google Cloud project of the Datastore
+	NumDbClients   int
+	AllowedOrigins string // Pattern of the origins allowed to make credentialed requests
+	LogLevel       string
+	LogFormat      string
+
+	TraceExporter    string
+	TraceEndpoint    string // OTLP/gRPC collector
+	TraceInsecure    bool
+	TraceSampleRatio float64
+
+	PartnersFile   string
+	CurrenciesFile string
+	FxRatesFile    string
+	LocationsFile  string
+	OpenApiFile    string
+	WebhooksFile   string
+	RateLimitsFile string
+
+	Keystore           string
+	KeystorePassphrase string // Secret
+	KeystoreKey        string // Secret; 32 bytes, base64
+	SignerSocket       string
+	SeedFile           string
+
+	StellarNetwork string
+	HorizonUrl     string // Overrides the network's Horizon
+
+	QinOnChain bool
+	QinEscrow  string
+	Degraded   bool // Turns payments away whatever the health checks say
+
+	IdempotencyWindow time.Duration
+	ShutdownTimeout   time.Duration
+
+	RateLimitStore string
+	ClientIpHeader string // Set by the proxy in front of us, if any
+
+	Grpc   GrpcConfig
+	Era    EraConfig
+	Review ReviewConfig
+
+	allowedOrigins *regexp.Regexp
+	logLevel       slog.Level
+	network        stellarutil.Network
+	flags          *flag.FlagSet
+	secrets        map[string]*string
+	sources        map[string]string
+}
+
+var config *Config
+
+// A comma separated list flag.
+type listValue []string
+
+func (list *listValue) String() string {
+	return strings.Join(*list, ",")
+}
+
+func (list *listValue) Set(value string) error {
+	*list = nil
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*list = append(*list, item)
+		}
+	}
+	return nil
+}
+
+func (cfg *Config) register(fs *flag.FlagSet) {
+	fs.StringVar(&cfg.Profile, "profile", kProfileLocal, "Environment to run as: local, staging or production")
+	fs.StringVar(&cfg.ConfigFile, "config", "", "JSON file of settings, keyed by flag name")
+
+	fs.StringVar(&cfg.Addr, "addr", ":443", "Address to serve the REST API on")
+	fs.StringVar(&cfg.MetricsAddr, "metrics-addr", ":9090", "Plain HTTP address to serve Prometheus metrics on; keep it private, off if empty")
+	fs.StringVar(&cfg.CertFile, "cert", "server.crt", "TLS certificate of the REST API")
+	fs.StringVar(&cfg.KeyFile, "key", "server.key", "Key of the TLS certificate")
+	fs.StringVar(&cfg.ProjectId, "project-id", "", "Google Cloud project of the Datastore")
+	fs.IntVar(&cfg.NumDbClients, "db-clients", 5, "Number of Datastore clients to share between requests")
+	fs.StringVar(&cfg.AllowedOrigins, "allowed-origins", `\.onedaijo.com(?::\d+)?$`, "Pattern of the origins that may make credentialed cross-origin requests")
+	fs.StringVar(&cfg.LogLevel, "log-level", "info", "Least severe log lines to write: debug, info, warn or error")
+	fs.StringVar(&cfg.LogFormat, "log-format", kLogFormatJson, "Log line format: json or text")
+	fs.StringVar(&cfg.TraceExporter, "trace-exporter", kTraceExporterNone, "Where to send trace spans: otlp, stdout or none")
+	fs.StringVar(&cfg.TraceEndpoint, "trace-endpoint", "localhost:4317", "OTLP/gRPC collector to export spans to")
+	fs.BoolVar(&cfg.TraceInsecure, "trace-insecure", false, "Export spans without TLS, e.g. to a collector on the same host")
+	fs.Float64Var(&cfg.TraceSampleRatio, "trace-sample-ratio", 1.0, "Fraction of new traces to record; traces started by a caller follow its decision")
+
+	fs.StringVar(&cfg.PartnersFile, "partners-file", "partners.json", "Cash-out and cash-in partners")
+	fs.StringVar(&cfg.CurrenciesFile, "currencies-file", "currencies.json", "Loan currencies and their limits")
+	fs.StringVar(&cfg.FxRatesFile, "fx-rates-file", "fx_rates.json", "Static exchange rates")
+	fs.StringVar(&cfg.LocationsFile, "locations-file", "locations.json", "Pickup locations to upsert at startup")
+	fs.StringVar(&cfg.OpenApiFile, "openapi-file", "openapi.json", "API description served at /openapi.json")
+	fs.StringVar(&cfg.WebhooksFile, "webhooks-file", "webhooks.json", "Webhook subscriptions")
+	fs.StringVar(&cfg.RateLimitsFile, "rate-limits-file", "rate_limits.json", "Rate limits per route; built-in limits are used if it is missing")
+
+	fs.StringVar(&cfg.Keystore, "keystore", "", "Encrypted keystore holding the server account's signing key")
+	fs.StringVar(&cfg.SignerSocket, "signer-socket", "", "Unix socket of a signing sidecar holding the server account's signing key")
+	fs.StringVar(&cfg.SeedFile, "seed-file", "", "Plain text seed of the server account; for local development only")
+
+	fs.StringVar(&cfg.StellarNetwork, "stellar-network", stellarutil.TestNetwork.Name, "Stellar network transactions are built for: testnet, standalone or public")
+	fs.StringVar(&cfg.HorizonUrl, "horizon-url", "", "Horizon server to use, overriding the network's")
+
+	fs.BoolVar(&cfg.QinOnChain, "qin-on-chain", false, "Issue QIN as a Stellar asset and escrow collateral on-chain")
+	fs.StringVar(&cfg.QinEscrow, "qin-escrow", "", "Address of the account that holds locked QIN collateral")
+	fs.BoolVar(&cfg.Degraded, "degraded", false, "Serve reads but turn away requests that move money, e.g. during a Stellar outage")
+
+	fs.DurationVar(&cfg.IdempotencyWindow, "idempotency-window", 24*time.Hour, "How long responses are replayed for a repeated Idempotency-Key")
+	fs.DurationVar(&cfg.ShutdownTimeout, "shutdown-timeout", 30*time.Second, "How long to wait for requests and background work to finish on SIGTERM")
+
+	fs.StringVar(&cfg.RateLimitStore, "rate-limit-store", kRateLimitStoreMemory, "Where rate limit buckets are kept: memory, or datastore to share them between replicas")
+	fs.StringVar(&cfg.ClientIpHeader, "client-ip-header", "", "Header a proxy in front of the server puts the client IP in, e.g. X-Forwarded-For; the connection's address if empty")
+
+	fs.StringVar(&cfg.Grpc.Addr, "grpc-addr", "", "Address to serve the gRPC API for internal services on, e.g. :8443; off if empty")
+	fs.StringVar(&cfg.Grpc.CertFile, "grpc-cert", "server.crt", "Certificate of the gRPC server")
+	fs.StringVar(&cfg.Grpc.KeyFile, "grpc-key", "server.key", "Key of the gRPC server certificate")
+	fs.StringVar(&cfg.Grpc.ClientCA, "grpc-client-ca", "", "CA certificates that sign the client certificates of gRPC callers")
+	fs.Var((*listValue)(&cfg.Grpc.Clients), "grpc-clients", "Comma separated names a gRPC client certificate must have one of; any if empty")
+
+	fs.Float64Var(&cfg.Era.MaxInterestRate, "era-max-interest-rate", 0.10, "Interest rate charged to a borrower certain to default")
+	fs.Float64Var(&cfg.Era.MaxQinCollateral, "era-max-qin-collateral", 0.5, "QIN collateral asked of a borrower certain to default")
+	fs.Uint64Var(&cfg.Era.GraceNumLoans, "era-grace-loans", 1, "Number of loans a borrower may take without QIN collateral")
+	fs.Float64Var(&cfg.Era.InitialQinBalance, "era-initial-qin", 100.0, "QIN each ERA starts with")
+	fs.Float64Var(&cfg.Era.InterestFraction, "era-interest-fraction", 0.02, "Fraction of the principal rewarded to the ERA whose terms are accepted")
+	cfg.Era.Kiva.register(fs, "kiva", "LendingData", 0.9)
+	cfg.Era.Prosper.register(fs, "prosper", "IntelligentAnalytica", 0.6)
+	cfg.Era.Naive.register(fs, "naive", "ABC Analytica", 1.0)
+	cfg.Era.Random.register(fs, "random", "Star Labs", 1.0)
+
+	fs.Float64Var(&cfg.Review.AmountAbove, "review-amount-above", 0.0, "Hold applications for more than this, in the base currency, for review; off if 0")
+	fs.BoolVar(&cfg.Review.FirstLoan, "review-first-loan", false, "Hold the applications of first-time borrowers for review")
+	fs.Float64Var(&cfg.Review.EraSpread, "review-era-spread", 0.0, "Hold applications for review when the offered interest rates differ by more than this; off if 0")
+	fs.BoolVar(&cfg.Review.MissingEmployment, "review-missing-employment", false, "Hold applications without employment status, income or start year for review")
+	fs.DurationVar(&cfg.Review.Sla, "review-sla", 24*time.Hour, "How long a loan officer has to decide a held application before it is overdue")
+
+	// Secrets are registered here rather than as flags.
+	cfg.secrets = map[string]*string{
+		"keystore-passphrase": &cfg.KeystorePassphrase,
+		"keystore-key":        &cfg.KeystoreKey,
+	}
+}
+
+func (model *EraModelConfig) register(fs *flag.FlagSet, era string, name string, rejectAbove float64) {
+	fs.StringVar(&model.Name, "era-"+era+"-name", name, "Name the "+era+" ERA offers terms under")
+	fs.Float64Var(&model.RejectAbove, "era-"+era+"-reject-above", rejectAbove, "Probability of default above which the "+era+" ERA rejects borrowers")
+}
+
+// Environment variable of a setting, e.g. SBC_GRPC_ADDR for grpc-addr.
+func envName(name string) string {
+	return kEnvPrefix + strings.ToUpper(strings.Replace(name, "-", "_", -1))
+}
+
+func (cfg *Config) set(name string, value string, source string) error {
+	if secret, ok := cfg.secrets[name]; ok {
+		if source == kSourceProfile || source == kSourceFlag {
+			return fmt.Errorf("%s is a secret; set it in the config file or %s", name, envName(name))
+		}
+		*secret = value
+	} else if err := cfg.flags.Set(name, value); err != nil {
+		return fmt.Errorf("%s (from %s): %v", name, source, err)
+	}
+	cfg.sources[name] = source
+	return nil
+}
+
+// Reads a config file: a JSON object keyed by setting names, whose values are strings, numbers, booleans or
+// lists of strings.
+func (cfg *Config) loadFile(path string) error {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var settings map[string]interface{}
+	if err := json.Unmarshal(bytes, &settings); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if name == "profile" || name == "config" {
+			return fmt.Errorf("%s: %s can only be set by flag or %s", path, name, envName(name))
+		}
+
+		var value string
+		switch typed := settings[name].(type) {
+		case []interface{}:
+			items := make([]string, len(typed))
+			for i, item := range typed {
+				items[i] = fmt.Sprint(item)
+			}
+			value = strings.Join(items, ",")
+		case nil:
+			continue
+		default:
+			value = fmt.Sprint(typed)
+		}
+
+		if err := cfg.set(name, value, kSourceFile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Builds the configuration from every source and validates it.
+func LoadConfig(args []string) (*Config, error) {
+	cfg := new(Config)
+	cfg.flags = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	cfg.sources = make(map[string]string)
+	cfg.register(cfg.flags)
+
+	// The profile and config file are needed first; flags are parsed again at the end so they win.
+	if err := cfg.flags.Parse(args); err != nil {
+		return nil, err
+	}
+	// Visit also reports flags changed with Set, so note the ones on the command line now.
+	explicit := make(map[string]bool)
+	cfg.flags.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+	for _, name := range []string{"profile", "config"} {
+		if explicit[name] {
+			cfg.sources[name] = kSourceFlag
+		} else if value, ok := os.LookupEnv(envName(name)); ok {
+			cfg.flags.Set(name, value)
+			cfg.sources[name] = kSourceEnv
+		}
+	}
+	profile, configFile := cfg.Profile, cfg.ConfigFile
+
+	defaults, ok := profileDefaults[profile]
+	if !ok {
+		return nil, fmt.Errorf("Unknown profile %s; use local, staging or production", profile)
+	}
+	for name, value := range defaults {
+		if err := cfg.set(name, value, kSourceProfile); err != nil {
+			return nil, err
+		}
+	}
+
+	if configFile != "" {
+		if err := cfg.loadFile(configFile); err != nil {
+			return nil, err
+		}
+	}
+
+	var env_err error
+	visitEnv := func(name string) {
+		if value, ok := os.LookupEnv(envName(name)); ok && env_err == nil {
+			env_err = cfg.set(name, value, kSourceEnv)
+			if _, secret := cfg.secrets[name]; secret {
+				os.Unsetenv(envName(name))
+			}
+		}
+	}
+	cfg.flags.VisitAll(func(f *flag.Flag) {
+		if f.Name != "profile" && f.Name != "config" {
+			visitEnv(f.Name)
+		}
+	})
+	for name := range cfg.secrets {
+		visitEnv(name)
+	}
+	if env_err != nil {
+		return nil, env_err
+	}
+
+	if err := cfg.flags.Parse(args); err != nil {
+		return nil, err
+	}
+	for name := range explicit {
+		cfg.sources[name] = kSourceFlag
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// Checks settings against each other and the profile.
+func (cfg *Config) Validate() error {
+	var problems []string
+	fail := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if cfg.ProjectId == "" {
+		fail("project-id is required")
+	}
+	if cfg.NumDbClients < 1 {
+		fail("db-clients must be at least 1")
+	}
+
+	allowedOrigins, err := regexp.Compile(cfg.AllowedOrigins)
+	if err != nil {
+		fail("allowed-origins is not a valid pattern: %v", err)
+	}
+	cfg.allowedOrigins = allowedOrigins
+
+	if err := cfg.logLevel.UnmarshalText([]byte(cfg.LogLevel)); err != nil {
+		fail("log-level must be debug, info, warn or error")
+	}
+	if cfg.LogFormat != kLogFormatJson && cfg.LogFormat != kLogFormatText {
+		fail("log-format must be json or text")
+	}
+	switch cfg.TraceExporter {
+	case kTraceExporterNone, kTraceExporterOtlp, kTraceExporterStdout:
+	default:
+		fail("trace-exporter must be otlp, stdout or none")
+	}
+	if cfg.TraceSampleRatio < 0 || cfg.TraceSampleRatio > 1 {
+		fail("trace-sample-ratio must be between 0 and 1")
+	}
+
+	if cfg.IdempotencyWindow < time.Minute {
+		fail("idempotency-window must be at least 1m")
+	}
+	if cfg.ShutdownTimeout <= 0 {
+		fail("shutdown-timeout must be positive")
+	}
+	if cfg.RateLimitStore != kRateLimitStoreMemory && cfg.RateLimitStore != kRateLimitStoreDatastore {
+		fail("rate-limit-store must be memory or datastore")
+	}
+	network, err := stellarutil.NetworkForName(cfg.StellarNetwork)
+	if err != nil {
+		fail("stellar-network must be testnet, standalone or public")
+	}
+	if cfg.HorizonUrl != "" {
+		if target, err := url.Parse(cfg.HorizonUrl); err != nil || (target.Scheme != "https" && target.Scheme != "http") || target.Host == "" {
+			fail("horizon-url must be an http or https URL")
+		}
+		network.HorizonURL = cfg.HorizonUrl
+	}
+	cfg.network = network
+	if cfg.Keystore != "" && cfg.KeystorePassphrase == "" && cfg.KeystoreKey == "" {
+		fail("keystore needs keystore-passphrase or keystore-key")
+	}
+	if cfg.QinOnChain && cfg.QinEscrow == "" {
+		fail("qin-on-chain needs qin-escrow")
+	}
+	if cfg.Grpc.Addr != "" && cfg.Grpc.ClientCA == "" {
+		fail("grpc-addr needs grpc-client-ca to authenticate clients")
+	}
+
+	era := cfg.Era
+	if era.MaxInterestRate < 0 || era.MaxQinCollateral < 0 || era.InitialQinBalance < 0 || era.InterestFraction < 0 {
+		fail("era settings cannot be negative")
+	}
+	for _, model := range []EraModelConfig{era.Kiva, era.Prosper, era.Naive, era.Random} {
+		if model.Name == "" {
+			fail("every ERA needs a name")
+		}
+	}
+	if cfg.Review.AmountAbove < 0 || cfg.Review.EraSpread < 0 {
+		fail("review settings cannot be negative")
+	}
+	if cfg.Review.Sla <= 0 {
+		fail("review-sla must be positive")
+	}
+
+	if cfg.Profile != kProfileLocal {
+		if cfg.SeedFile != "" {
+			fail("seed-file is for local development only; use keystore or signer-socket")
+		}
+		if cfg.allowedOrigins != nil && cfg.allowedOrigins.MatchString("http://localhost") {
+			fail("allowed-origins lets in localhost outside the local profile")
+		}
+		if cfg.StellarNetwork == stellarutil.StandaloneNetwork.Name {
+			fail("stellar-network standalone is for local development only")
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New("Invalid configuration: " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+type ConfigSetting struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+type ConfigResponse struct {
+	Profile  string          `json:"profile"`
+	Settings []ConfigSetting `json:"settings"`
+}
+
+// Every setting with its effective value and where it came from. Secrets only show whether they are set.
+func (cfg *Config) Settings() []ConfigSetting {
+	var settings []ConfigSetting
+	source := func(name string) string {
+		if source, ok := cfg.sources[name]; ok {
+			return source
+		}
+		return kSourceDefault
+	}
+
+	cfg.flags.VisitAll(func(f *flag.Flag) {
+		settings = append(settings, ConfigSetting{Name: f.Name, Value: f.Value.String(), Source: source(f.Name)})
+	})
+	for name, secret := range cfg.secrets {
+		value := "(not set)"
+		if *secret != "" {
+			value = "(set)"
+		}
+		settings = append(settings, ConfigSetting{Name: name, Value: value, Source: source(name)})
+	}
+
+	sort.Slice(settings, func(i, j int) bool { return settings[i].Name < settings[j].Name })
+	return settings
+}
+
+// GET /config shows operators the effective settings.
+func GetConfig(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(ConfigResponse{Profile: config.Profile, Settings: config.Settings()})
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math"
+	"net/http"
+)
+
+// Loans can be requested in any currency listed in currencies.json. Amounts are valued in a single base
+// currency through an FxRateProvider so QIN and ERA rewards can be compared across loans.
+
+const kDefaultCurrency string = "PHP"
+
+var (
+	ErrUnsupportedCurrency  = NewAPIError(http.StatusBadRequest, "UNSUPPORTED_CURRENCY", "Currency is not supported.")
+	ErrLoanAmountOutOfRange = NewAPIError(http.StatusBadRequest, "LOAN_AMOUNT_OUT_OF_RANGE", "Loan amount is outside the allowed range for this currency.")
+	ErrLoanAmountStep       = NewAPIError(http.StatusBadRequest, "LOAN_AMOUNT_STEP", "Loan amount is not a multiple of the allowed step for this currency.")
+	ErrFxRateUnavailable    = errors.New("No exchange rate is available for this currency.")
+)
+
+// CurrencyConfig holds the limits and rounding rules for loans in one currency.
+type CurrencyConfig struct {
+	Code     string  `json:"code"`
+	MinLoan  float64 `json:"minLoan"`
+	MaxLoan  float64 `json:"maxLoan"`
+	Step     float64 `json:"step,omitempty"` // Loan amounts must be a multiple of this; 0 allows any amount
+	Decimals int     `json:"decimals"`       // Amounts owed are rounded to this many decimal places
+}
+
+// TermsValuation expresses the rewards attached to a set of loan terms in the base currency.
+type TermsValuation struct {
+	CurrencyCode      string  `json:"currencyCode"`
+	QinReward         float64 `json:"qinReward"`
+	QinRequired       float64 `json:"qinRequired"`
+	EraInterestReward float64 `json:"eraInterestReward"`
+}
+
+type CurrencyRegistry struct {
+	BaseCurrency string            `json:"baseCurrency"`
+	Currencies   []*CurrencyConfig `json:"currencies"`
+}
+
+// FxRateProvider converts between currencies, including QIN.
+type FxRateProvider interface {
+	// Rate returns how many units of to one unit of from is worth.
+	Rate(from string, to string) (float64, error)
+}
+
+// StaticFxRates values every currency against a common reference currency, as read from a file.
+type StaticFxRates struct {
+	Reference string             `json:"reference"`
+	Rates     map[string]float64 `json:"rates"` // Value of one unit in the reference currency
+}
+
+func (rates *StaticFxRates) Rate(from string, to string) (float64, error) {
+	if from == to {
+		return 1.0, nil
+	}
+
+	fromValue, ok := rates.Rates[from]
+	if !ok || fromValue <= 0.0 {
+		return 0, ErrFxRateUnavailable
+	}
+
+	toValue, ok := rates.Rates[to]
+	if !ok || toValue <= 0.0 {
+		return 0, ErrFxRateUnavailable
+	}
+
+	return fromValue / toValue, nil
+}
+
+func LoadStaticFxRates(path string) (*StaticFxRates, error) {
+	rates := &StaticFxRates{Reference: kDefaultCurrency, Rates: map[string]float64{kDefaultCurrency: 1.0}}
+
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return rates, nil
+	}
+
+	if err := json.Unmarshal(bytes, rates); err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+var currencyRegistry *CurrencyRegistry
+var fxRates FxRateProvider
+
+var defaultCurrencyRegistry = &CurrencyRegistry{
+	BaseCurrency: kDefaultCurrency,
+	Currencies: []*CurrencyConfig{
+		{Code: kDefaultCurrency, MinLoan: 100.0, MaxLoan: 100000.0, Decimals: 2},
+	},
+}
+
+func LoadCurrencies(path string) error {
+	currencyRegistry = defaultCurrencyRegistry
+
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var registry CurrencyRegistry
+	if err := json.Unmarshal(bytes, &registry); err != nil {
+		return err
+	}
+
+	if registry.BaseCurrency == "" || len(registry.Currencies) == 0 {
+		return errors.New("Currencies file needs a base currency and at least one currency")
+	}
+
+	for _, currency := range registry.Currencies {
+		if currency.MinLoan <= 0.0 || currency.MaxLoan < currency.MinLoan || currency.Decimals < 0 {
+			return errors.New("Invalid limits for currency " + currency.Code)
+		}
+	}
+
+	currencyRegistry = &registry
+	return nil
+}
+
+func CurrencyForCode(code string) (*CurrencyConfig, error) {
+	for _, currency := range currencyRegistry.Currencies {
+		if currency.Code == code {
+			return currency, nil
+		}
+	}
+	return nil, ErrUnsupportedCurrency
+}
+
+// Rounds an amount to the number of decimal places used by the currency.
+func (currency *CurrencyConfig) Round(amount float64) float64 {
+	scale := math.Pow10(currency.Decimals)
+	return Round(amount*scale) / scale
+}
+
+func (currency *CurrencyConfig) ValidateLoanAmount(amount float64) error {
+	if amount < currency.MinLoan || amount > currency.MaxLoan {
+		return ErrLoanAmountOutOfRange
+	}
+
+	if currency.Step > 0.0 {
+		steps := amount / currency.Step
+		if math.Abs(steps-math.Floor(steps+0.5)) > 1e-9 {
+			return ErrLoanAmountStep
+		}
+	}
+	return nil
+}
+
+// Converts an amount into the base currency.
+func ToBaseCurrency(amount float64, currencyCode string) (float64, error) {
+	rate, err := fxRates.Rate(currencyCode, currencyRegistry.BaseCurrency)
+	if err != nil {
+		return 0, err
+	}
+	return amount * rate, nil
+}
+
+// Values QIN for a set of terms in the base currency, alongside the ERA's interest reward which ERAs already
+// compute in the base currency. Returns nil without a QIN rate, since the valuation is informational only.
+func ValueTerms(terms *LoanTerms, eraInterestReward float64) *TermsValuation {
+	qinValue, err := ToBaseCurrency(1.0, kQinAssetCode)
+	if err != nil {
+		return nil
+	}
+
+	// Valuations use cents of the base currency regardless of its configured rounding.
+	return &TermsValuation{
+		CurrencyCode:      currencyRegistry.BaseCurrency,
+		QinReward:         Round(terms.QinReward*qinValue*100.0) / 100.0,
+		QinRequired:       Round(terms.QinRequired*qinValue*100.0) / 100.0,
+		EraInterestReward: Round(eraInterestReward*100.0) / 100.0,
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"errors"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"cloud.google.com/go/datastore"
+	"github.com/stellar/go/clients/horizon"
+
+	"github.com/OneDaijo/sbc-demo-backend/stellarutil"
+)
+
+// Payouts are advanced by a worker, not by requests reading the loan. A loan is sent with a new payout in the
+// same transaction, so none is lost if the server stops before the partner is asked where the funds go. Each
+// step is recorded on the loan guarded by the status it started from, and a payment is signed and saved before
+// it is submitted, so however many replicas advance a payout at once its funds are only sent once.
+
+const kDisbursementInterval time.Duration = 30 * time.Second
+
+var ErrDisbursementAdvanced = errors.New("Payout was advanced by another request.")
+
+// Records a payout step, as long as no one else has taken the payout past the status it started from.
+func saveDisbursement(ctx context.Context, dbClient *datastore.Client, uid string, loanId string, from string, transfer *AnchorTransfer) (*LoanRecord, error) {
+	transfer.Pending = !transfer.Finished()
+
+	updated, err := UpdateLoanRecordWith(ctx, dbClient, uid, loanId, func(loanRecord *LoanRecord) error {
+		if loanRecord.Disbursement == nil || loanRecord.Disbursement.Status != from {
+			return ErrDisbursementAdvanced
+		}
+		loanRecord.Disbursement = transfer
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	ObserveDisbursement(transfer.PartnerId, transfer, nil)
+	return updated, nil
+}
+
+// Takes a loan's payout one step further. Returns the updated loan, or nil if it is waiting on the anchor or
+// the borrower.
+func advancePayout(ctx context.Context, dbClient *datastore.Client, hc *horizon.Client, uid string, loanRecord *LoanRecord) (*LoanRecord, error) {
+	transfer := *loanRecord.Disbursement
+	from := transfer.Status
+
+	switch transfer.Status {
+	case kTransferNew:
+		// Replicas racing here may each start a withdrawal with the anchor, but only the one saved is paid.
+		partner, err := PartnerForId(transfer.PartnerId)
+		if err != nil {
+			return nil, err
+		}
+		started, err := Disburse(ctx, partner, loanRecord.Amount, loanRecord.CurrencyCode, loanRecord.LoanId)
+		if err != nil {
+			ObserveDisbursement(transfer.PartnerId, nil, err)
+			return nil, err
+		}
+		transfer = *started
+
+	case kTransferReady:
+		// Claim the payout by saving the signed payment; only the claim that commits gets submitted.
+		partner, err := PartnerForId(transfer.PartnerId)
+		if err != nil {
+			return nil, err
+		}
+		tx, err := PartnerPaymentTransaction(hc, partner, loanRecord.CurrencyCode, transfer.Payment, loanRecord.Amount)
+		if err != nil {
+			return nil, err
+		}
+		payment := *transfer.Payment
+		payment.TxHash, payment.Envelope, err = signTransaction(tx)
+		if err != nil {
+			return nil, err
+		}
+		transfer.Payment = &payment
+		transfer.Status = kTransferSubmitting
+
+	case kTransferSubmitting, kTransferUnknown:
+		apply_err := applyTransaction(ctx, hc, transfer.Payment.TxHash, transfer.Payment.Envelope)
+		switch {
+		case apply_err == nil:
+			transfer.Status = kTransferSubmitted
+		case stellarutil.IsOperationRejected(apply_err):
+			Logger(ctx).Error("Payout was rejected", "loanId", loanRecord.LoanId, "partnerId", transfer.PartnerId, "err", apply_err)
+			transfer.Status = kTransferFailed
+		case stellarutil.IsRejected(apply_err):
+			// Turned away as signed, e.g. over a sequence number another server transaction used first. It was
+			// never applied, so the payment is built again.
+			Logger(ctx).Warn("Payout was turned away, building it again", "loanId", loanRecord.LoanId, "partnerId", transfer.PartnerId, "err", apply_err)
+			payment := *transfer.Payment
+			payment.TxHash, payment.Envelope = "", ""
+			transfer.Payment = &payment
+			transfer.Status = kTransferReady
+		case transfer.Status == kTransferUnknown:
+			// Still unknown; the same payment is submitted again next time.
+			return nil, apply_err
+		default:
+			Logger(ctx).Warn("Payout outcome unknown", "loanId", loanRecord.LoanId, "partnerId", transfer.PartnerId, "err", apply_err)
+			transfer.Status = kTransferUnknown
+		}
+
+	default:
+		changed, err := AdvanceDisbursement(ctx, &transfer)
+		if err != nil || !changed {
+			return nil, err
+		}
+	}
+
+	return saveDisbursement(ctx, dbClient, uid, loanRecord.LoanId, from, &transfer)
+}
+
+// Advances a loan's payout as far as it can go for now.
+func PayOutLoan(ctx context.Context, dbClient *datastore.Client, hc *horizon.Client, uid string, loanRecord *LoanRecord) error {
+	for loanRecord.Disbursement != nil && !loanRecord.Disbursement.Finished() {
+		updated, err := advancePayout(ctx, dbClient, hc, uid, loanRecord)
+		if err == ErrDisbursementAdvanced {
+			// Another replica got there first and will carry on.
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if updated == nil {
+			return nil
+		}
+		loanRecord = updated
+	}
+	return nil
+}
+
+// Takes the first step of a loan that was just sent, asking the partner where the funds go, so that the
+// response can show an interactive URL. The rest happens in the background, and whatever fails is left to the
+// worker.
+func StartPayout(ctx context.Context, uid string, loanRecord *LoanRecord) *LoanRecord {
+	dbClient := GetDbClient()
+	updated, err := advancePayout(ctx, dbClient, NewHorizonClient(ctx), uid, loanRecord)
+	returnDbClient <- dbClient
+
+	if err != nil && err != ErrDisbursementAdvanced {
+		Logger(ctx).Warn("Payout could not be started, leaving it to the worker", "loanId", loanRecord.LoanId, "err", err)
+	}
+	if updated != nil {
+		loanRecord = updated
+	}
+
+	PayOutLoanAsync(ctx, uid, *loanRecord)
+	return loanRecord
+}
+
+// Carries on paying out a loan that was just sent, on a pooled client after the handler's transaction.
+func PayOutLoanAsync(ctx context.Context, uid string, loanRecord LoanRecord) {
+	ctx = DetachContext(ctx)
+	RunInBackground(func() {
+		dbClient := GetDbClient()
+		err := PayOutLoan(ctx, dbClient, NewHorizonClient(ctx), uid, &loanRecord)
+		returnDbClient <- dbClient
+		if err != nil {
+			Logger(ctx).Error("Payout failed", "loanId", loanRecord.LoanId, "err", err)
+		}
+	})
+}
+
+// Periodically advances every unfinished payout: not yet started, waiting on the anchor, ready to send, or sent
+// with an unknown outcome.
+func AdvanceDisbursements(ctx context.Context) {
+	for true {
+		if !SleepUntilDone(ctx, kDisbursementInterval) {
+			return
+		}
+
+		if CheckPaymentsAvailable() != nil {
+			continue
+		}
+
+		dbClient := GetDbClient()
+
+		var loanRecords []LoanRecord
+		keys, err := dbClient.GetAll(ctx, datastore.NewQuery(kLoanKind).Filter("Disbursement.Pending =", true), &loanRecords)
+		if err != nil {
+			logger.Error("Failed to list unfinished payouts", "err", err)
+			returnDbClient <- dbClient
+			continue
+		}
+
+		hc := NewHorizonClient(ctx)
+		for i := range loanRecords {
+			// Leave the rest for the next run after a restart.
+			if ctx.Err() != nil {
+				break
+			}
+			// Not canceled by shutdown, so that a payment is always recorded once it has been submitted.
+			if err := PayOutLoan(context.Background(), dbClient, hc, keys[i].Parent.Name, &loanRecords[i]); err != nil {
+				logger.Error("Failed to advance payout", "loanId", loanRecords[i].LoanId, "err", err)
+			}
+		}
+
+		returnDbClient <- dbClient
+	}
+}
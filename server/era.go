@@ -10,10 +10,6 @@ type BorrowerApp struct {
 	employment_status      string
 }
 
-const MAX_INTEREST_RATE float64 = 0.10 // Maximum interest rate that we are willing to set
-const MAX_QIN_COLLATERAL float64 = 0.5 // Maximum collateral in terms of qin that a borrower is expected to have
-const GRACE_NUM_LOANS uint64 = 1       // Number of loans where the borrower need not have any QIN
-
 // BorrowerInformation represents the set of information used to determine the QIN collateral
 type BorrowerInformation struct {
 	no_loans         uint64
@@ -66,7 +62,7 @@ func processBorrowerApp(era ERA, borrower_app BorrowerApp, borrower_information
 	// Check if borrower should be rejected on the basis on not having enough earned qin, short circuit otherwise
 	// Qin collateral that the borrower must post given the borrower information
 	qin_collateral := 0.0
-	if borrower_information.no_loans >= GRACE_NUM_LOANS { // must have at least grace num loans for qin collateral to apply
+	if borrower_information.no_loans >= config.Era.GraceNumLoans { // must have at least grace num loans for qin collateral to apply
 		qin_collateral = era.computeQinCollateral(prob_default, borrower_information.successful_loans)
 		if borrower_information.earned_qin < qin_collateral {
 			return nil
@@ -79,9 +75,9 @@ func processBorrowerApp(era ERA, borrower_app BorrowerApp, borrower_information
 	// Shamelessly fixing rather than throwing or enforcing back to ERA
 	if interest_rate < 0.0 {
 		interest_rate = 0.0
-	} else if interest_rate > MAX_INTEREST_RATE {
-		interest_rate = MAX_INTEREST_RATE
-	} else { // if between 0 and MAX_INTEREST_RATE, then take no action
+	} else if interest_rate > config.Era.MaxInterestRate {
+		interest_rate = config.Era.MaxInterestRate
+	} else { // if between 0 and the max interest rate, then take no action
 
 	}
 
@@ -1,5 +1,12 @@
 package main
 
+import (
+	"golang.org/x/net/context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
 // Internal mapping of the ERAs for indices
 type ERAIdx uint
 
@@ -31,10 +38,6 @@ type LoanStatusState struct {
 	loan_status LoanStatus
 }
 
-// Initial QIN starting balance of the ERAs
-const INITIAL_QIN_BALANCE float64 = 100.0
-const ERA_INTEREST_FRACTION float64 = 0.02
-
 // ERA driver represents the pseudo-object responsible for disseminating information to the ERAs and aggregating responses
 type ERADriver struct {
 	_eras                     []ERA                      // array of era structs
@@ -53,27 +56,32 @@ func constructERADriver() *ERADriver {
 	// Constructing individual ERAs
 	// TODO Create registry service so we know what ERAs exist
 	era_driver._eras = []ERA{KivaERA{}, ProsperERA{}, NaiveERA{}, RandomERA{}}
-	era_driver._era_external_names = []string{"LendingData", "IntelligentAnalytica", "ABC Analytica", "Star Labs"}
+	era_driver._era_external_names = []string{config.Era.Kiva.Name, config.Era.Prosper.Name, config.Era.Naive.Name, config.Era.Random.Name}
 	era_driver._num_eras = len(era_driver._eras)
 
 	// Setting the initial qin balances of all the ERAs
-	era_driver._eras_qin_balances = []float64{INITIAL_QIN_BALANCE, INITIAL_QIN_BALANCE, INITIAL_QIN_BALANCE, INITIAL_QIN_BALANCE}
+	era_driver._eras_qin_balances = []float64{config.Era.InitialQinBalance, config.Era.InitialQinBalance, config.Era.InitialQinBalance, config.Era.InitialQinBalance}
 
 	return era_driver // safe from pointer scope analysis
 }
 
 // Processes borrower request by mapping across each era and reducing over each of the responses
-func processBorrowerRequest(era_driver *ERADriver, borrower_app BorrowerApp, borrower_information BorrowerInformation) ([]*ERATerms, uint) {
+func processBorrowerRequest(ctx context.Context, era_driver *ERADriver, borrower_app BorrowerApp, borrower_information BorrowerInformation) ([]*ERATerms, uint) {
 	// Initializing array for the output era terms
 	era_responses := make([]*ERATerms, era_driver._num_eras, era_driver._num_eras)
 
 	// Computing loan fraction that ERA gets as reward based on successful repayment of borrower
-	var loan_fraction float64 = ERA_INTEREST_FRACTION * float64(borrower_app.principal_amount)
+	var loan_fraction float64 = config.Era.InterestFraction * float64(borrower_app.principal_amount)
 
 	// Generating responses for each individual borrower sequentially
 	var num_not_nil uint = 0
 	for i := 0; i < len(era_responses); i++ {
+		_, span := tracer.Start(ctx, "era.processBorrowerApp", trace.WithAttributes(attribute.String("sbc.era", era_driver._era_external_names[i])))
 		era_responses[i] = processBorrowerApp(era_driver._eras[i], borrower_app, borrower_information, loan_fraction, era_driver._era_external_names[i])
+		span.SetAttributes(attribute.Bool("sbc.offered", era_responses[i] != nil))
+		span.End()
+
+		ObserveEraDecision(era_driver._era_external_names[i], era_responses[i])
 		if era_responses[i] != nil {
 			num_not_nil++
 		}
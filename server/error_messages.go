@@ -0,0 +1,72 @@
+package main
+
+// Translations of APIError messages, keyed by language and then error code. English comes from the
+// errors themselves; codes missing here fall back to it.
+
+const kDefaultLanguage string = "en"
+
+// Tagalog is served the Filipino messages.
+var languageAliases = map[string]string{
+	"tl": "fil",
+}
+
+var errorMessages = map[string]map[string]string{
+	"fil": {
+		"AUTH_FAILED":                   "Hindi ka ma-authenticate.",
+		"EMAIL_NOT_VERIFIED":            "Hindi pa na-verify ang iyong email.",
+		"USER_DISABLED":                 "Naka-disable ang account na ito.",
+		"AUTH_TOKEN_MISSING":            "Walang auth token na ipinadala.",
+		"USER_NOT_FOUND":                "Hindi nahanap ang user.",
+		"LOAN_WRONG_STATE":              "Hindi maaaring gawin ito sa kasalukuyang estado ng iyong loan.",
+		"NO_ACTIVE_LOAN":                "Wala kang aktibong loan.",
+		"LOAN_NOT_FOUND":                "Hindi nahanap ang loan.",
+		"INVALID_ID":                    "Hindi nahanap ang ibinigay na ID.",
+		"USER_ALREADY_EXISTS":           "Mayroon nang user na ganito.",
+		"USER_NOT_REGISTERED":           "Hindi pa nakarehistro ang user.",
+		"LOAN_IN_DEFAULT":               "Hindi na mababayaran ang loan dahil ito ay naka-default.",
+		"NOT_ENOUGH_QIN":                "Kulang ang iyong QIN.",
+		"INVALID_REQUEST_BODY":          "May mga field na kulang o mali ang laman.",
+		"LOAN_ALREADY_EXISTS":           "Mayroon ka nang aktibong loan.",
+		"USER_DATA_NOT_FOUND":           "Hindi nahanap ang impormasyon tungkol sa iyong trabaho at tirahan.",
+		"STELLAR_ADDRESS_INVALID":       "Hindi wastong Stellar account ID ang ibinigay.",
+		"STELLAR_ACCOUNT_NOT_LINKED":    "Kailangan munang mag-link ng Stellar account bago ma-lock ang QIN collateral.",
+		"STELLAR_ACCOUNT_NOT_READY":     "Kailangang i-trust ng Stellar account ang QIN at isama ang server account bilang signer.",
+		"STELLAR_ACCOUNT_NOT_FOUND":     "Hindi nahanap ang Stellar account sa network.",
+		"UNKNOWN_PARTNER":               "Hindi nahanap ang cash-out partner.",
+		"PARTNER_NO_CASH_IN":            "Hindi tumatanggap ng cash-in ang partner na ito.",
+		"ANCHOR_TOML_INVALID":           "May kulang sa stellar.toml ng partner.",
+		"ANCHOR_CHALLENGE_FAILED":       "Hindi wasto ang authentication challenge ng partner.",
+		"ANCHOR_REQUEST_FAILED":         "Hindi natuloy ang request sa partner.",
+		"PARTNER_CURRENCY_UNSUPPORTED":  "Hindi hawak ng partner ang currency ng loan.",
+		"INVALID_LOCATION":              "Hindi nahanap ang pickup location.",
+		"LOCATION_PARTNER_MISMATCH":     "Hindi kabilang ang pickup location sa napiling partner.",
+		"LOCATION_CURRENCY_UNSUPPORTED": "Hindi tumatanggap ang pickup location ng currency ng loan.",
+		"LOCATION_LIMIT_REACHED":        "Naabot na ng pickup location ang limit nito para sa araw na ito.",
+		"INVALID_LOCATION_SEARCH":       "Hindi wasto ang mga parameter sa paghahanap ng location.",
+		"UNSUPPORTED_CURRENCY":          "Hindi suportado ang currency na ito.",
+		"LOAN_AMOUNT_OUT_OF_RANGE":      "Lampas sa pinapayagang halaga ang loan para sa currency na ito.",
+		"LOAN_AMOUNT_STEP":              "Ang halaga ng loan ay dapat multiple ng pinapayagang step para sa currency na ito.",
+		"NOT_FOUND":                     "Hindi nahanap ang hinihinging resource.",
+		"INTERNAL":                      "Nagkaproblema sa aming panig.",
+		"IDEMPOTENCY_KEY_INVALID":       "Ang Idempotency-Key ay dapat 1 hanggang 255 na printable ASCII na character.",
+		"IDEMPOTENCY_KEY_REUSED":        "Nagamit na ang Idempotency-Key na ito sa ibang request.",
+		"IDEMPOTENCY_KEY_IN_PROGRESS":   "Pinoproseso pa ang request na may ganitong Idempotency-Key.",
+		"REQUEST_TOO_LARGE":             "Masyadong malaki ang request.",
+		"RATE_LIMITED":                  "Masyadong maraming request. Subukan muli mamaya.",
+		"PAYMENTS_UNAVAILABLE":          "Hindi available ang mga bayad ngayon. Subukan muli mamaya.",
+		"FORBIDDEN":                     "Hindi pinapayagan.",
+		"AUDIT_LOG_UNAVAILABLE":         "Hindi maitala ang request sa audit log, kaya hindi ito itinuloy.",
+		"DELIVERY_NOT_FOUND":            "Hindi nahanap ang webhook delivery.",
+		"REVIEW_NOT_FOUND":              "Hindi nahanap ang review ng loan.",
+		"REVIEW_CLAIMED":                "May ibang officer na humawak sa review ng loan na ito.",
+		"REVIEW_CLOSED":                 "Napagpasyahan na ang review ng loan na ito.",
+		// Field errors
+		"REQUIRED":       "Kailangan ang field na ito.",
+		"INVALID_VALUE":  "Hindi wasto ang value ng field na ito.",
+		"INVALID_FORMAT": "Mali ang format ng field na ito.",
+		"OUT_OF_RANGE":   "Wala sa pinapayagang saklaw ang value ng field na ito.",
+		"TOO_LONG":       "Masyadong mahaba ang field na ito.",
+		"UNDER_AGE":      "Kailangang nasa hustong gulang (18 pataas) ang borrower.",
+		"NOT_ALLOWED":    "Hindi dapat ipadala ang field na ito.",
+	},
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// Changes to a user's loans and QIN balance are published to an in-process bus and streamed to their clients as
+// Server-Sent Events. Recent events are kept per user so a client reconnecting with Last-Event-ID gets what it
+// missed; when that is no longer possible it gets a reset event and should reload its state.
+
+const (
+	kEventLoanState        string = "loan.state"        // The loan changed state
+	kEventLoanOffers       string = "loan.offers"       // Terms were offered for the loan
+	kEventLoanDisbursement string = "loan.disbursement" // The status of the payout changed
+	kEventLoanRepayment    string = "loan.repayment"    // A repayment was recorded
+	kEventQinBalance       string = "qin.balance"
+	kEventReset            string = "reset"
+)
+
+// How many events and for how long they are kept for resuming.
+const kEventBacklog int = 100
+const kEventRetention time.Duration = 10 * time.Minute
+
+// Streams are closed after this long so that clients reconnect with a token that has not expired.
+const kMaxEventStreamDuration time.Duration = time.Hour
+const kEventHeartbeatInterval time.Duration = 25 * time.Second
+const kEventSubscriberBuffer int = 64
+
+type Event struct {
+	Id         string      `json:"id"`
+	Type       string      `json:"type"`
+	Timestamp  int64       `json:"timestamp"` // Unix milliseconds
+	Loan       *LoanRecord `json:"loan,omitempty"`
+	Repayment  *Repayment  `json:"repayment,omitempty"`
+	QinBalance *float64    `json:"qinBalance,omitempty"`
+	seq        uint64
+}
+
+type userEvents struct {
+	recent      []Event
+	dropped     uint64 // Sequence number of the last event no longer in recent
+	subscribers map[chan Event]bool
+}
+
+type EventBus struct {
+	sync.Mutex
+	epoch  string // Tells event IDs from before a restart apart
+	next   uint64
+	users  map[string]*userEvents
+	closed bool
+}
+
+var eventBus = &EventBus{
+	epoch: strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 36),
+	users: make(map[string]*userEvents),
+}
+
+func (bus *EventBus) user(uid string) *userEvents {
+	events, ok := bus.users[uid]
+	if !ok {
+		events = &userEvents{subscribers: make(map[chan Event]bool)}
+		bus.users[uid] = events
+	}
+	return events
+}
+
+func (bus *EventBus) Publish(uid string, event Event) {
+	bus.Lock()
+	defer bus.Unlock()
+
+	bus.next++
+	event.seq = bus.next
+	event.Id = bus.epoch + "-" + strconv.FormatUint(event.seq, 10)
+	if event.Timestamp == 0 {
+		event.Timestamp = time.Now().Unix() * 1000
+	}
+
+	events := bus.user(uid)
+	events.recent = append(events.recent, event)
+	if len(events.recent) > kEventBacklog {
+		events.dropped = events.recent[0].seq
+		events.recent = events.recent[1:]
+	}
+
+	for subscriber := range events.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+			// Too slow to keep up; it will resume from its last event after reconnecting.
+			close(subscriber)
+			delete(events.subscribers, subscriber)
+		}
+	}
+}
+
+// Subscribes to a user's events. Returns the events after lastEventId, or a reset event if some are gone.
+func (bus *EventBus) Subscribe(uid string, lastEventId string) (chan Event, []Event) {
+	bus.Lock()
+	defer bus.Unlock()
+
+	events := bus.user(uid)
+	subscriber := make(chan Event, kEventSubscriberBuffer)
+	if bus.closed {
+		close(subscriber)
+	} else {
+		events.subscribers[subscriber] = true
+	}
+
+	if lastEventId == "" {
+		return subscriber, nil
+	}
+
+	var lastSeq uint64
+	parts := strings.SplitN(lastEventId, "-", 2)
+	complete := len(parts) == 2 && parts[0] == bus.epoch
+	if complete {
+		var err error
+		lastSeq, err = strconv.ParseUint(parts[1], 10, 64)
+		complete = err == nil && lastSeq >= events.dropped
+	}
+
+	if !complete {
+		reset := Event{
+			Id:        bus.epoch + "-" + strconv.FormatUint(bus.next, 10),
+			Type:      kEventReset,
+			Timestamp: time.Now().Unix() * 1000,
+		}
+		return subscriber, []Event{reset}
+	}
+
+	var missed []Event
+	for _, event := range events.recent {
+		if event.seq > lastSeq {
+			missed = append(missed, event)
+		}
+	}
+	return subscriber, missed
+}
+
+func (bus *EventBus) Unsubscribe(uid string, subscriber chan Event) {
+	bus.Lock()
+	defer bus.Unlock()
+
+	events := bus.user(uid)
+	if events.subscribers[subscriber] {
+		close(subscriber)
+		delete(events.subscribers, subscriber)
+	}
+}
+
+// Drops events too old to resume from, and users with nothing left.
+func (bus *EventBus) Prune(now time.Time) {
+	bus.Lock()
+	defer bus.Unlock()
+
+	cutoff := now.Add(-kEventRetention).Unix() * 1000
+	for uid, events := range bus.users {
+		for len(events.recent) > 0 && events.recent[0].Timestamp < cutoff {
+			events.dropped = events.recent[0].seq
+			events.recent = events.recent[1:]
+		}
+		if len(events.recent) == 0 && len(events.subscribers) == 0 {
+			delete(bus.users, uid)
+		}
+	}
+}
+
+// Ends every stream so that the server can shut down; clients reconnect to another instance.
+func (bus *EventBus) Close() {
+	bus.Lock()
+	defer bus.Unlock()
+
+	bus.closed = true
+	for _, events := range bus.users {
+		for subscriber := range events.subscribers {
+			close(subscriber)
+			delete(events.subscribers, subscriber)
+		}
+	}
+}
+
+func ManageEvents(ctx context.Context) {
+	for true {
+		if !SleepUntilDone(ctx, kEventRetention) {
+			return
+		}
+		eventBus.Prune(time.Now())
+	}
+}
+
+// Events for the differences between two versions of a loan; previous is nil for a new loan.
+func LoanEvents(previous *LoanRecord, loanRecord *LoanRecord) []Event {
+	loan := *loanRecord
+	loan.Request = nil
+
+	var events []Event
+	if previous == nil || previous.State != loan.State {
+		events = append(events, Event{Type: kEventLoanState, Loan: &loan})
+	}
+	if len(loan.Terms) > 0 && (previous == nil || len(previous.Terms) == 0) {
+		events = append(events, Event{Type: kEventLoanOffers, Loan: &loan})
+	}
+	if loan.Disbursement != nil && (previous == nil || previous.Disbursement == nil || previous.Disbursement.Status != loan.Disbursement.Status) {
+		events = append(events, Event{Type: kEventLoanDisbursement, Loan: &loan})
+	}
+	for i := range loan.Repayments {
+		if previous == nil || i >= len(previous.Repayments) {
+			repayment := loan.Repayments[i]
+			events = append(events, Event{Type: kEventLoanRepayment, Loan: &loan, Repayment: &repayment})
+		}
+	}
+	return events
+}
+
+func QinBalanceEvent(qinBalance float64) Event {
+	return Event{Type: kEventQinBalance, QinBalance: &qinBalance}
+}
+
+func writeEvent(w http.ResponseWriter, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.Id, event.Type, data)
+	return err
+}
+
+// EventSource cannot set headers, so the token of GET /events may also be passed as the token query parameter.
+func TokenFromQuery(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-firebase-token") == "" {
+			r.Header.Set("X-firebase-token", r.URL.Query().Get("token"))
+		}
+		handler(w, r)
+	}
+}
+
+// GET /events streams the signed in user's events.
+func GetEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		WriteError(w, r, ErrInternal)
+		return
+	}
+
+	uid := RequestUid(r)
+	lastEventId := r.Header.Get("Last-Event-ID")
+	if lastEventId == "" {
+		lastEventId = r.URL.Query().Get("lastEventId")
+	}
+
+	subscriber, missed := eventBus.Subscribe(uid, lastEventId)
+	defer eventBus.Unsubscribe(uid, subscriber)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // Keep proxies from holding events back
+	w.WriteHeader(http.StatusOK)
+
+	// Retry after 3 seconds if the connection drops.
+	fmt.Fprint(w, "retry: 3000\n\n")
+
+	for _, event := range missed {
+		writeEvent(w, event)
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(kEventHeartbeatInterval)
+	defer heartbeat.Stop()
+	deadline := time.NewTimer(kMaxEventStreamDuration)
+	defer deadline.Stop()
+
+	for true {
+		select {
+		case event, open := <-subscriber:
+			if !open {
+				return
+			}
+			if writeEvent(w, event) != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		case <-deadline.C:
+			return
+		case <-r.Context().Done():
+			return
+		}
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,608 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"firebase.google.com/go/auth"
+
+	"github.com/OneDaijo/sbc-demo-backend/sbcpb"
+)
+
+// The Borrowers gRPC service in sbcpb/borrowers.proto serves internal services on its own port. Callers
+// authenticate with a client certificate signed by -grpc-client-ca and act for the user named in each request.
+// There is no Firebase token, but the user is still looked up in Firebase and held to the same checks as REST
+// requests before the call goes through the same domain functions as the REST handlers.
+
+const kErrorDomain string = "onedaijo.com"
+
+type GrpcConfig struct {
+	Addr     string
+	CertFile string
+	KeyFile  string
+	ClientCA string   // PEM bundle of the CAs that sign client certificates
+	Clients  []string // Names allowed in client certificates; empty allows any certificate from ClientCA
+}
+
+type borrowersServer struct {
+	sbcpb.UnimplementedBorrowersServer
+}
+
+// Sets up the Borrowers service and listens on its port; pass the listener to Serve to start it.
+func ListenGrpc(grpcConfig GrpcConfig) (*grpc.Server, net.Listener, error) {
+	cert, err := tls.LoadX509KeyPair(grpcConfig.CertFile, grpcConfig.KeyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pem, err := ioutil.ReadFile(grpcConfig.ClientCA)
+	if err != nil {
+		return nil, nil, err
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(pem) {
+		return nil, nil, fmt.Errorf("No certificates in %s", grpcConfig.ClientCA)
+	}
+
+	creds := credentials.NewTLS(&tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	})
+
+	firebaseAuth, err := NewFirebaseAuth(context.Background())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	listener, err := net.Listen("tcp", grpcConfig.Addr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	server := grpc.NewServer(grpc.Creds(creds), grpc.ChainUnaryInterceptor(
+		traceCalls, logCalls, authorizeClient(grpcConfig.Clients), authorizeUser(firebaseAuth),
+	))
+	sbcpb.RegisterBorrowersServer(server, &borrowersServer{})
+	return server, listener, nil
+}
+
+// Lets a traceparent in incoming metadata be read by the propagator.
+type metadataCarrier metadata.MD
+
+func (carrier metadataCarrier) Get(key string) string {
+	if values := metadata.MD(carrier).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (carrier metadataCarrier) Set(key string, value string) {
+	metadata.MD(carrier).Set(key, value)
+}
+
+func (carrier metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(carrier))
+	for key := range carrier {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Starts a span for every call, continuing the caller's trace if it sent one.
+func traceCalls(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	}
+	ctx, span := tracer.Start(ctx, info.FullMethod,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("rpc.method", info.FullMethod)),
+	)
+
+	resp, err := handler(ctx, req)
+	span.SetAttributes(attribute.String("rpc.grpc.status_code", status.Code(err).String()))
+	endSpan(span, err)
+	return resp, err
+}
+
+// Gives every call a request ID, kept from x-request-id metadata if it looks sane, and logs it once served. As
+// with REST requests, the handler's context is not canceled if the caller goes away.
+func logCalls(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+
+	callInfo := &requestInfo{}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(kRequestIdHeader); len(ids) > 0 && requestIdPattern.MatchString(ids[0]) {
+			callInfo.id = ids[0]
+		}
+	}
+	if callInfo.id == "" {
+		callInfo.id = newRequestId()
+	}
+	if withUser, ok := req.(interface{ GetUserId() string }); ok {
+		callInfo.uid = withUser.GetUserId()
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(kRequestIdHeader, callInfo.id))
+
+	ctx = DetachContext(withRequestInfo(ctx, callInfo))
+	resp, err := handler(ctx, req)
+
+	level := slog.LevelInfo
+	code := status.Code(err)
+	if code == codes.Internal || code == codes.Unknown {
+		level = slog.LevelError
+	}
+	Logger(ctx).LogAttrs(ctx, level, "call",
+		slog.String("method", info.FullMethod),
+		slog.String("code", code.String()),
+		slog.Duration("latency", time.Since(start)),
+	)
+	return resp, err
+}
+
+// Turns away clients whose certificate names none of the allowed clients.
+func authorizeClient(clients []string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if len(clients) == 0 {
+			return handler(ctx, req)
+		}
+
+		if p, ok := peer.FromContext(ctx); ok {
+			if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.PeerCertificates) > 0 {
+				cert := tlsInfo.State.PeerCertificates[0]
+				names := append([]string{cert.Subject.CommonName}, cert.DNSNames...)
+				for _, name := range names {
+					for _, client := range clients {
+						if name == client {
+							return handler(ctx, req)
+						}
+					}
+				}
+			}
+		}
+
+		return nil, status.Error(codes.PermissionDenied, "Client certificate is not allowed.")
+	}
+}
+
+// Calls that, like their REST routes behind RequireUser, are allowed before the user has verified their email.
+var unverifiedMethods = map[string]bool{
+	sbcpb.Borrowers_GetUser_FullMethodName:    true,
+	sbcpb.Borrowers_CreateUser_FullMethodName: true,
+}
+
+// Turns away calls for a user who doesn't exist in Firebase, is disabled or, as RequireVerifiedUser does, has not
+// verified their email, so a client can't act for any user id it likes.
+func authorizeUser(firebaseAuth *auth.Client) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		withUser, ok := req.(interface{ GetUserId() string })
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		err := requireUserId(withUser.GetUserId())
+		if err != nil {
+			return nil, grpcError(ctx, err)
+		}
+
+		userRecord, err := firebaseAuth.GetUser(ctx, withUser.GetUserId())
+		switch {
+		case auth.IsUserNotFound(err):
+			err = ErrUserNotFound
+		case err != nil:
+		case userRecord.Disabled:
+			err = ErrUserDisabled
+		case !userRecord.EmailVerified && !unverifiedMethods[info.FullMethod]:
+			err = ErrEmailNotValidated
+		}
+		if err != nil {
+			return nil, grpcError(ctx, err)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// Maps an error onto a gRPC status carrying the REST error code and field errors.
+func grpcError(ctx context.Context, err error) error {
+	apiErr := AsAPIError(err)
+	if apiErr.Status >= http.StatusInternalServerError {
+		Logger(ctx).Error("Internal error", "err", err)
+	}
+
+	var code codes.Code
+	switch apiErr.Status {
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge:
+		code = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict:
+		code = codes.AlreadyExists
+	case http.StatusUnprocessableEntity:
+		code = codes.FailedPrecondition
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		code = codes.Unavailable
+	default:
+		code = codes.Internal
+	}
+
+	st := status.New(code, apiErr.Message)
+	if withInfo, detail_err := st.WithDetails(&errdetails.ErrorInfo{Reason: apiErr.Code, Domain: kErrorDomain}); detail_err == nil {
+		st = withInfo
+	}
+
+	if len(apiErr.Fields) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, field := range apiErr.Fields {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Description: field.Code + ": " + field.Message,
+			})
+		}
+		if withFields, detail_err := st.WithDetails(badRequest); detail_err == nil {
+			st = withFields
+		}
+	}
+	return st.Err()
+}
+
+func requireUserId(userId string) error {
+	var v Validator
+	v.Required("userId", userId)
+	return v.Err()
+}
+
+func (s *borrowersServer) GetUser(ctx context.Context, req *sbcpb.GetUserRequest) (*sbcpb.User, error) {
+	err := requireUserId(req.UserId)
+
+	var user *User
+	if err == nil {
+		user, err = ReadUser(ctx, req.UserId)
+	}
+
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return userToProto(user), nil
+}
+
+func (s *borrowersServer) CreateUser(ctx context.Context, req *sbcpb.CreateUserRequest) (*sbcpb.User, error) {
+	user := userFromProto(req.User)
+
+	var v Validator
+	v.Required("userId", req.UserId)
+	user.Validate(&v, "", time.Now())
+	err := v.Err()
+
+	var createdUser *User
+	if err == nil {
+		createdUser, err = RegisterUser(ctx, req.UserId, user)
+	}
+
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return userToProto(createdUser), nil
+}
+
+func (s *borrowersServer) UpdateUser(ctx context.Context, req *sbcpb.UpdateUserRequest) (*sbcpb.User, error) {
+	user := userFromProto(req.User)
+
+	var v Validator
+	v.Required("userId", req.UserId)
+	user.ValidatePatch(&v, time.Now())
+	err := v.Err()
+
+	var finalizedUser *User
+	if err == nil {
+		finalizedUser, err = UpdateUser(ctx, req.UserId, user)
+	}
+
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return userToProto(finalizedUser), nil
+}
+
+func (s *borrowersServer) RequestLoan(ctx context.Context, req *sbcpb.RequestLoanRequest) (*sbcpb.Loan, error) {
+	loanRequest := LoanRequest{
+		LoanAmount:  req.LoanAmount,
+		Currency:    req.CurrencyCode,
+		LoanMemo:    req.LoanMemo,
+		LoanPurpose: req.LoanPurpose,
+		TermsAgreed: req.TermsAgreed,
+	}
+
+	var v Validator
+	v.Required("userId", req.UserId)
+	loanRequest.Validate(&v)
+	err := v.Err()
+
+	var loanRecord *LoanRecord
+	if err == nil {
+		loanRecord, err = RequestLoan(ctx, req.UserId, loanRequest)
+	}
+
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return loanToProto(loanRecord), nil
+}
+
+func (s *borrowersServer) GetLoan(ctx context.Context, req *sbcpb.GetLoanRequest) (*sbcpb.Loan, error) {
+	err := requireUserId(req.UserId)
+
+	var loanRecord *LoanRecord
+	if err == nil {
+		loanRecord, err = ReadLoan(ctx, req.UserId, req.LoanId)
+	}
+
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return loanToProto(loanRecord), nil
+}
+
+func (s *borrowersServer) SelectOffer(ctx context.Context, req *sbcpb.SelectOfferRequest) (*sbcpb.Loan, error) {
+	selection := LoanSelectRequest{
+		SelectedTerm: req.SelectedTerm,
+		PartnerId:    req.PartnerId,
+	}
+	if req.PickupLocation != nil {
+		selection.Location = PickupLocation{LocationId: req.PickupLocation.LocationId, LocationName: req.PickupLocation.LocationName}
+	}
+
+	var v Validator
+	v.Required("userId", req.UserId)
+	selection.Validate(&v)
+	err := v.Err()
+
+	var loanRecord *LoanRecord
+	if err == nil {
+		loanRecord, err = SelectLoanTerms(ctx, req.UserId, req.LoanId, selection)
+	}
+
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return loanToProto(loanRecord), nil
+}
+
+func (s *borrowersServer) Repay(ctx context.Context, req *sbcpb.RepayRequest) (*sbcpb.RepayResponse, error) {
+	err := requireUserId(req.UserId)
+
+	var loanRecord *LoanRecord
+	var done bool
+	if err == nil {
+		loanRecord, done, err = RepayLoan(ctx, req.UserId, req.LoanId, RepayRequest{PartnerId: req.PartnerId})
+	}
+
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+	return &sbcpb.RepayResponse{Loan: loanToProto(loanRecord), Done: done}, nil
+}
+
+func (s *borrowersServer) ListLoans(ctx context.Context, req *sbcpb.ListLoansRequest) (*sbcpb.ListLoansResponse, error) {
+	// Same parameters as GET /v2/loans, so they are checked the same way.
+	values := url.Values{}
+	if req.Limit != 0 {
+		values.Set("limit", strconv.Itoa(int(req.Limit)))
+	}
+	values.Set("cursor", req.Cursor)
+	values.Set("state", req.State)
+	values.Set("sort", req.Sort)
+	if req.From != nil {
+		values.Set("from", strconv.FormatInt(*req.From, 10))
+	}
+	if req.To != nil {
+		values.Set("to", strconv.FormatInt(*req.To, 10))
+	}
+
+	err := requireUserId(req.UserId)
+
+	var loanQuery LoanQuery
+	if err == nil {
+		loanQuery, err = ParseLoanQuery(values, "-created", kDefaultLoanPageSize)
+	}
+
+	var loanPage *LoanPage
+	if err == nil {
+		loanPage, err = ListLoans(ctx, req.UserId, loanQuery)
+	}
+
+	if err != nil {
+		return nil, grpcError(ctx, err)
+	}
+
+	resp := &sbcpb.ListLoansResponse{
+		NextCursor: loanPage.NextCursor,
+		Summary:    summaryToProto(&loanPage.Summary),
+	}
+	for i := range loanPage.LoanRecords {
+		resp.Loans = append(resp.Loans, loanToProto(&loanPage.LoanRecords[i]))
+	}
+	return resp, nil
+}
+
+func userFromProto(user *sbcpb.User) User {
+	if user == nil {
+		return User{}
+	}
+
+	converted := User{
+		Firstname:      user.FirstName,
+		Lastname:       user.LastName,
+		PhoneNum:       user.PhoneNumber,
+		DateOfBirth:    user.DateOfBirth,
+		StellarAddress: user.StellarAddress,
+	}
+	if info := user.EmploymentInfo; info != nil {
+		converted.EmploymentInfo = &EmploymentInfo{
+			EmploymentStatus:     info.EmploymentStatus,
+			EmploymentJobTitle:   info.EmploymentJobTitle,
+			EmploymentStartMonth: info.EmploymentStartMonth,
+			EmploymentStartYear:  info.EmploymentStartYear,
+			EmploymentIncome:     info.EmploymentIncome,
+			EmploymentEducation:  info.EmploymentEducation,
+		}
+	}
+	if info := user.ResidenceInfo; info != nil {
+		converted.ResidenceInfo = &ResidenceInfo{
+			ResidenceAddr1:    info.ResidenceAddr1,
+			ResidenceAddr2:    info.ResidenceAddr2,
+			ResidenceDistrict: info.ResidenceDistrict,
+			ResidenceCity:     info.ResidenceCity,
+			ResidencePostal:   info.ResidencePostal,
+			ResidenceProvince: info.ResidenceProvince,
+			ResidenceStatus:   info.ResidenceStatus,
+			ResidenceRentAmt:  info.ResidenceRentAmt,
+		}
+	}
+	return converted
+}
+
+func userToProto(user *User) *sbcpb.User {
+	converted := &sbcpb.User{
+		FirstName:      user.Firstname,
+		LastName:       user.Lastname,
+		PhoneNumber:    user.PhoneNum,
+		DateOfBirth:    user.DateOfBirth,
+		QinBalance:     user.QinBalance,
+		StellarAddress: user.StellarAddress,
+		Created:        user.DateCreated,
+	}
+	if info := user.EmploymentInfo; info != nil {
+		converted.EmploymentInfo = &sbcpb.EmploymentInfo{
+			EmploymentStatus:     info.EmploymentStatus,
+			EmploymentJobTitle:   info.EmploymentJobTitle,
+			EmploymentStartMonth: info.EmploymentStartMonth,
+			EmploymentStartYear:  info.EmploymentStartYear,
+			EmploymentIncome:     info.EmploymentIncome,
+			EmploymentEducation:  info.EmploymentEducation,
+		}
+	}
+	if info := user.ResidenceInfo; info != nil {
+		converted.ResidenceInfo = &sbcpb.ResidenceInfo{
+			ResidenceAddr1:    info.ResidenceAddr1,
+			ResidenceAddr2:    info.ResidenceAddr2,
+			ResidenceDistrict: info.ResidenceDistrict,
+			ResidenceCity:     info.ResidenceCity,
+			ResidencePostal:   info.ResidencePostal,
+			ResidenceProvince: info.ResidenceProvince,
+			ResidenceStatus:   info.ResidenceStatus,
+			ResidenceRentAmt:  info.ResidenceRentAmt,
+		}
+	}
+	return converted
+}
+
+func termsToProto(terms *LoanTerms) *sbcpb.LoanTerms {
+	if terms == nil {
+		return nil
+	}
+
+	converted := &sbcpb.LoanTerms{
+		Id:           terms.TermId,
+		InterestRate: terms.InterestRate,
+		QinReward:    terms.QinReward,
+		QinRequired:  terms.QinRequired,
+		AmountOwed:   terms.AmountOwed,
+		OfferedBy:    terms.OfferedBy,
+	}
+	if valuation := terms.Valuation; valuation != nil {
+		converted.Valuation = &sbcpb.TermsValuation{
+			CurrencyCode:      valuation.CurrencyCode,
+			QinReward:         valuation.QinReward,
+			QinRequired:       valuation.QinRequired,
+			EraInterestReward: valuation.EraInterestReward,
+		}
+	}
+	return converted
+}
+
+func transferToProto(transfer *AnchorTransfer) *sbcpb.AnchorTransfer {
+	if transfer == nil {
+		return nil
+	}
+
+	return &sbcpb.AnchorTransfer{
+		PartnerId:      transfer.PartnerId,
+		TransactionId:  transfer.TransactionId,
+		InteractiveUrl: transfer.InteractiveUrl,
+		Instructions:   transfer.Instructions,
+		Status:         transfer.Status,
+	}
+}
+
+// Leaves out the loan request, as the REST API does.
+func loanToProto(loanRecord *LoanRecord) *sbcpb.Loan {
+	converted := &sbcpb.Loan{
+		Id:              loanRecord.LoanId,
+		Amount:          loanRecord.Amount,
+		CurrencyCode:    loanRecord.CurrencyCode,
+		DueDate:         loanRecord.DueDate,
+		AcceptedTerms:   termsToProto(loanRecord.AcceptedTerms),
+		State:           loanRecord.State,
+		PickupCode:      loanRecord.PickupCode,
+		Memo:            loanRecord.Memo,
+		RepaidDate:      loanRecord.RepaidDate,
+		Created:         loanRecord.DateCreated,
+		CollateralState: loanRecord.CollateralState,
+		Disbursement:    transferToProto(loanRecord.Disbursement),
+		CashIn:          transferToProto(loanRecord.CashIn),
+	}
+	for i := range loanRecord.Terms {
+		converted.LoanTerms = append(converted.LoanTerms, termsToProto(&loanRecord.Terms[i]))
+	}
+	if location := loanRecord.Location; location != nil {
+		converted.PickupLocation = &sbcpb.PickupLocation{LocationId: location.LocationId, LocationName: location.LocationName}
+	}
+	for _, repayment := range loanRecord.Repayments {
+		converted.Repayments = append(converted.Repayments, &sbcpb.Repayment{Amount: repayment.Amount, Timestamp: repayment.Timestamp})
+	}
+	return converted
+}
+
+func summaryToProto(summary *LoanSummary) *sbcpb.LoanSummary {
+	converted := &sbcpb.LoanSummary{
+		ActiveLoanId:    summary.ActiveLoanId,
+		NumLoans:        summary.NumLoans,
+		NumRepaidOnTime: summary.NumRepaidOnTime,
+		OnTimeRate:      summary.OnTimeRate,
+	}
+	for _, stateCount := range summary.StateCounts {
+		converted.CountsByState = append(converted.CountsByState, &sbcpb.StateCount{State: stateCount.State, Count: stateCount.Count})
+	}
+	for _, total := range summary.Totals {
+		converted.Totals = append(converted.Totals, &sbcpb.CurrencyTotal{CurrencyCode: total.CurrencyCode, Borrowed: total.Borrowed, Repaid: total.Repaid})
+	}
+	return converted
+}
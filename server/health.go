@@ -0,0 +1,253 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"cloud.google.com/go/datastore"
+	"firebase.google.com/go/auth"
+)
+
+// /hc/live answers as long as the process serves requests. /hc/ready reports the dependencies, which a
+// background worker checks every kHealthCheckInterval so that probes never wait on them. Without Datastore or
+// Firebase nothing works and the server is not ready. Without Horizon or the signer, or with -degraded, it is
+// degraded: reads keep being served but selecting terms and repaying, which move money, are turned away.
+
+const (
+	kHealthCheckInterval time.Duration = 15 * time.Second
+	kHealthCheckTimeout  time.Duration = 5 * time.Second
+)
+
+const (
+	kHealthOk       string = "ok"
+	kHealthDegraded string = "degraded"
+	kHealthDown     string = "down"
+)
+
+const (
+	kDependencyDatastore string = "datastore"
+	kDependencyFirebase  string = "firebase"
+	kDependencyHorizon   string = "horizon"
+	kDependencySigner    string = "signer"
+)
+
+// Looked up to reach Datastore and Firebase; neither exists.
+const kHealthCheckKind string = "HealthCheck"
+const kHealthCheckUid string = "sbc-health-check"
+
+var ErrPaymentsUnavailable = NewAPIError(http.StatusServiceUnavailable, "PAYMENTS_UNAVAILABLE", "Payments are unavailable right now. Try again later.")
+
+type DependencyHealth struct {
+	Status    string `json:"status"` // ok or down
+	LatencyMs int64  `json:"latencyMs"`
+}
+
+type HealthReport struct {
+	Status       string                       `json:"status"` // ok, degraded or down
+	CheckedAt    *time.Time                   `json:"checkedAt,omitempty"`
+	Dependencies map[string]*DependencyHealth `json:"dependencies"`
+}
+
+type healthCheck struct {
+	dependency string
+	payments   bool // Only payments need it
+	check      func(ctx context.Context) error
+}
+
+var healthChecks = []healthCheck{
+	{kDependencyDatastore, false, checkDatastore},
+	{kDependencyFirebase, false, checkFirebase},
+	{kDependencyHorizon, true, checkHorizon},
+	{kDependencySigner, true, checkSigner},
+}
+
+var (
+	healthLock   sync.Mutex
+	healthReport = &HealthReport{Status: kHealthDown, Dependencies: map[string]*DependencyHealth{}}
+
+	paymentsDown atomic.Bool
+)
+
+// Implemented by signers held outside this process.
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
+func checkDatastore(ctx context.Context) error {
+	// The pool being empty for the whole timeout is as bad as Datastore being down.
+	var dbClient *datastore.Client
+	select {
+	case dbClient = <-getDbClient:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { returnDbClient <- dbClient }()
+
+	var entity datastore.PropertyList
+	err := dbClient.Get(ctx, datastore.NameKey(kHealthCheckKind, kHealthCheckKind, nil), &entity)
+	if err == datastore.ErrNoSuchEntity {
+		return nil
+	}
+	return err
+}
+
+func checkFirebase(ctx context.Context) error {
+	client, err := NewFirebaseAuth(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = client.GetUser(ctx, kHealthCheckUid)
+	if auth.IsUserNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func checkHorizon(ctx context.Context) error {
+	_, err := NewHorizonClient(ctx).Root()
+	return err
+}
+
+func checkSigner(ctx context.Context) error {
+	if serverSigner == nil {
+		return ErrSignerNotConfigured
+	}
+	if signer, ok := serverSigner.(pinger); ok {
+		return signer.Ping(ctx)
+	}
+	return nil
+}
+
+// Runs a check, giving up after kHealthCheckTimeout even if it ignores ctx, as Horizon's client does.
+func runHealthCheck(ctx context.Context, check healthCheck) (*DependencyHealth, error) {
+	ctx, cancel := context.WithTimeout(ctx, kHealthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	result := make(chan error, 1)
+	go func() {
+		result <- check.check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-result:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	health := &DependencyHealth{Status: kHealthOk, LatencyMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		health.Status = kHealthDown
+	}
+	return health, err
+}
+
+// Checks every dependency at once and replaces the report.
+func CheckHealth(ctx context.Context) {
+	type checked struct {
+		check  healthCheck
+		health *DependencyHealth
+		err    error
+	}
+
+	results := make(chan checked, len(healthChecks))
+	for _, check := range healthChecks {
+		go func(check healthCheck) {
+			health, err := runHealthCheck(ctx, check)
+			results <- checked{check, health, err}
+		}(check)
+	}
+
+	report := &HealthReport{Status: kHealthOk, Dependencies: make(map[string]*DependencyHealth)}
+	now := time.Now()
+	report.CheckedAt = &now
+
+	healthLock.Lock()
+	previousReport := healthReport
+	healthLock.Unlock()
+
+	payments := !config.Degraded
+	for range healthChecks {
+		result := <-results
+		report.Dependencies[result.check.dependency] = result.health
+
+		up := result.err == nil
+		if up {
+			dependencyUp.WithLabelValues(result.check.dependency).Set(1)
+		} else {
+			dependencyUp.WithLabelValues(result.check.dependency).Set(0)
+		}
+
+		previous, checkedBefore := previousReport.Dependencies[result.check.dependency]
+		if !up && (!checkedBefore || previous.Status == kHealthOk) {
+			logger.Warn("Dependency is down", "dependency", result.check.dependency, "err", result.err)
+		} else if up && checkedBefore && previous.Status != kHealthOk {
+			logger.Info("Dependency is back up", "dependency", result.check.dependency)
+		}
+
+		switch {
+		case up:
+		case result.check.payments:
+			payments = false
+		default:
+			report.Status = kHealthDown
+		}
+	}
+
+	if !payments && report.Status == kHealthOk {
+		report.Status = kHealthDegraded
+	}
+	paymentsDown.Store(!payments)
+
+	healthLock.Lock()
+	healthReport = report
+	healthLock.Unlock()
+}
+
+func MonitorHealth(ctx context.Context) {
+	for true {
+		CheckHealth(ctx)
+
+		if !SleepUntilDone(ctx, kHealthCheckInterval) {
+			return
+		}
+	}
+}
+
+// Fails while payments are down or -degraded is set, before anything has been written.
+func CheckPaymentsAvailable() error {
+	if config.Degraded || paymentsDown.Load() {
+		return ErrPaymentsUnavailable
+	}
+	return nil
+}
+
+// Answers as long as the process can serve requests; dependencies don't matter.
+func Liveness(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(HealthReport{Status: kHealthOk, Dependencies: map[string]*DependencyHealth{}})
+}
+
+// The last health report, with a 503 if the server can't serve requests at all. Degraded still counts as ready.
+func Readiness(w http.ResponseWriter, r *http.Request) {
+	healthLock.Lock()
+	report := healthReport
+	healthLock.Unlock()
+
+	if report.Status == kHealthDown {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(report)
+}
+
+// Kept for clients of the original API.
+func HealthCheck(w http.ResponseWriter, r *http.Request) {
+	var resp LoanDeleteResponse
+	resp.Success = true
+	json.NewEncoder(w).Encode(resp)
+}
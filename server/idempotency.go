@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"cloud.google.com/go/datastore"
+)
+
+// Mutating requests may carry an Idempotency-Key header. The first final response to a key is stored for the
+// user and replayed when the request is retried within the idempotency-window setting; reusing the key for a
+// different request is rejected. Responses that say to try again, such as the 202 of a cash-in that is still
+// pending, release the key instead so that the retry is handled afresh.
+
+const kIdempotencyKeyHeader string = "Idempotency-Key"
+const kIdempotencyReplayedHeader string = "Idempotent-Replayed"
+const kIdempotencyKind string = "idempotencyKey"
+
+// A request still marked in progress after this long is assumed to have died with its server.
+const kIdempotencyLockTimeout time.Duration = 2 * time.Minute
+const kIdempotencySweepInterval time.Duration = time.Hour
+
+// Bodies of mutating requests are small; anything larger is not worth storing a hash of.
+const kMaxIdempotentBodySize int64 = 1 << 20
+
+var idempotencyKeyPattern = regexp.MustCompile(`^[\x21-\x7e]{1,255}$`)
+
+var (
+	ErrIdempotencyKeyInvalid    = NewAPIError(http.StatusBadRequest, "IDEMPOTENCY_KEY_INVALID", "Idempotency-Key must be 1 to 255 printable ASCII characters.")
+	ErrIdempotencyKeyReused     = NewAPIError(http.StatusUnprocessableEntity, "IDEMPOTENCY_KEY_REUSED", "Idempotency-Key was already used for a different request.")
+	ErrIdempotencyKeyInProgress = NewAPIError(http.StatusConflict, "IDEMPOTENCY_KEY_IN_PROGRESS", "A request with this Idempotency-Key is still being processed.")
+	ErrRequestTooLarge          = NewAPIError(http.StatusRequestEntityTooLarge, "REQUEST_TOO_LARGE", "Request body is too large.")
+)
+
+type IdempotencyRecord struct {
+	RequestHash string `datastore:",noindex"` // Of the method, path and body
+	Created     time.Time
+	Done        bool   `datastore:",noindex"`
+	Status      int    `datastore:",noindex"`
+	ContentType string `datastore:",noindex"`
+	Body        []byte `datastore:",noindex"`
+}
+
+// Records the response while passing it through.
+type recordingWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *recordingWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recordingWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.body.Write(p)
+	return w.ResponseWriter.Write(p)
+}
+
+func IdempotencyKey(uid string, key string) *datastore.Key {
+	return datastore.NameKey(kIdempotencyKind, key, UserKey(uid))
+}
+
+func requestHash(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s %s\n", r.Method, r.URL.Path)
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Claims a key for a request. Returns the stored record if the request was already answered.
+func claimIdempotencyKey(ctx context.Context, uid string, key string, hash string, now time.Time) (*IdempotencyRecord, error) {
+	dbClient := GetDbClient()
+
+	recordKey := IdempotencyKey(uid, key)
+	var stored *IdempotencyRecord
+
+	_, err := RunInTransaction(ctx, dbClient, "ClaimIdempotencyKey", func(tx *datastore.Transaction) error {
+		var record IdempotencyRecord
+		get_err := tx.Get(recordKey, &record)
+		if get_err != nil && get_err != datastore.ErrNoSuchEntity {
+			return get_err
+		}
+
+		if get_err == nil && record.Created.Add(config.IdempotencyWindow).After(now) {
+			if record.RequestHash != hash {
+				return ErrIdempotencyKeyReused
+			}
+			if record.Done {
+				stored = &record
+				return nil
+			}
+			if record.Created.Add(kIdempotencyLockTimeout).After(now) {
+				return ErrIdempotencyKeyInProgress
+			}
+		}
+
+		_, put_err := tx.Put(recordKey, &IdempotencyRecord{RequestHash: hash, Created: now})
+		return put_err
+	})
+
+	returnDbClient <- dbClient
+
+	if err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+// Whether a response is the request's final outcome and can be replayed: success other than 202 Accepted, or a
+// client error other than being rate limited.
+func isFinalOutcome(status int) bool {
+	switch {
+	case status == http.StatusAccepted, status == http.StatusTooManyRequests:
+		return false
+	case status >= 200 && status < 300:
+		return true
+	case status >= 400 && status < 500:
+		return true
+	}
+	return false
+}
+
+// Stores the response to a claimed key, or releases the key if the outcome is pending or unknown so the request
+// can be retried.
+func completeIdempotencyKey(ctx context.Context, uid string, key string, record *IdempotencyRecord) error {
+	dbClient := GetDbClient()
+
+	var err error
+	if !isFinalOutcome(record.Status) {
+		err = dbClient.Delete(ctx, IdempotencyKey(uid, key))
+	} else {
+		_, err = dbClient.Put(ctx, IdempotencyKey(uid, key), record)
+	}
+
+	returnDbClient <- dbClient
+	return err
+}
+
+// Wraps a mutating handler so that it honors Idempotency-Key. Keys belong to a user, so it goes inside
+// RequireUser or RequireVerifiedUser.
+func Idempotent(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(kIdempotencyKeyHeader)
+		uid := RequestUid(r)
+		if key == "" || uid == "" {
+			handler(w, r)
+			return
+		}
+
+		if !idempotencyKeyPattern.MatchString(key) {
+			WriteError(w, r, ErrIdempotencyKeyInvalid)
+			return
+		}
+
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, kMaxIdempotentBodySize))
+		if err != nil {
+			WriteError(w, r, ErrRequestTooLarge)
+			return
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		now := time.Now()
+		hash := requestHash(r, body)
+
+		stored, err := claimIdempotencyKey(RequestContext(r), uid, key, hash, now)
+		if err != nil {
+			WriteError(w, r, err)
+			return
+		}
+
+		if stored != nil {
+			if stored.ContentType != "" {
+				w.Header().Set("Content-Type", stored.ContentType)
+			}
+			w.Header().Set(kIdempotencyReplayedHeader, "true")
+			w.WriteHeader(stored.Status)
+			w.Write(stored.Body)
+			return
+		}
+
+		recorder := &recordingWriter{ResponseWriter: w}
+		handler(recorder, r)
+
+		if recorder.status == 0 {
+			recorder.status = http.StatusOK
+		}
+
+		err = completeIdempotencyKey(RequestContext(r), uid, key, &IdempotencyRecord{
+			RequestHash: hash,
+			Created:     now,
+			Done:        true,
+			Status:      recorder.status,
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		})
+		if err != nil {
+			Logger(r.Context()).Error("Failed to store response for idempotency key", "err", err)
+		}
+	}
+}
+
+// Periodically deletes stored responses that can no longer be replayed.
+func ExpireIdempotencyKeys(ctx context.Context) {
+	for true {
+		if !SleepUntilDone(ctx, kIdempotencySweepInterval) {
+			return
+		}
+
+		dbClient := GetDbClient()
+
+		query := datastore.NewQuery(kIdempotencyKind).Filter("Created <", time.Now().Add(-config.IdempotencyWindow)).KeysOnly()
+		keys, err := dbClient.GetAll(ctx, query, nil)
+		if err != nil {
+			logger.Error("Failed to list expired idempotency keys", "err", err)
+		}
+
+		// Deletes are limited to 500 keys per call.
+		for start := 0; start < len(keys); start += 500 {
+			end := start + 500
+			if end > len(keys) {
+				end = len(keys)
+			}
+			if err := dbClient.DeleteMulti(ctx, keys[start:end]); err != nil {
+				logger.Error("Failed to delete expired idempotency keys", "err", err)
+			}
+		}
+
+		returnDbClient <- dbClient
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"firebase.google.com/go/auth"
+	"golang.org/x/net/context"
+)
+
+func TestIsFinalOutcome(t *testing.T) {
+	tests := []struct {
+		status int
+		want   bool
+	}{
+		{http.StatusOK, true},
+		{http.StatusCreated, true},
+		{http.StatusNoContent, true},
+		{http.StatusAccepted, false}, // e.g. a cash-in that is still pending
+		{http.StatusBadRequest, true},
+		{http.StatusConflict, true},
+		{http.StatusUnprocessableEntity, true},
+		{http.StatusTooManyRequests, false},
+		{http.StatusInternalServerError, false},
+		{http.StatusBadGateway, false},
+		{http.StatusServiceUnavailable, false},
+	}
+
+	for _, test := range tests {
+		if got := isFinalOutcome(test.status); got != test.want {
+			t.Errorf("isFinalOutcome(%d) = %v, want %v", test.status, got, test.want)
+		}
+	}
+}
+
+// Covers the requests Idempotent settles before claiming the key, which needs Datastore.
+func TestIdempotent(t *testing.T) {
+	tests := []struct {
+		name        string
+		uid         string
+		key         string
+		body        string
+		wantStatus  int
+		wantHandled bool
+	}{
+		{"no key", kTestUid, "", "{}", http.StatusOK, true},
+		{"no user", "", "retry-1", "{}", http.StatusOK, true},
+		{"key with spaces", kTestUid, "retry 1", "{}", http.StatusBadRequest, false},
+		{"key too long", kTestUid, strings.Repeat("k", 256), "{}", http.StatusBadRequest, false},
+		{"body too large", kTestUid, "retry-1", strings.Repeat(" ", int(kMaxIdempotentBodySize)+1), http.StatusRequestEntityTooLarge, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handled := false
+			handler := Idempotent(func(w http.ResponseWriter, r *http.Request) {
+				handled = true
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/v2/loans", bytes.NewBufferString(test.body))
+			if test.key != "" {
+				req.Header.Set(kIdempotencyKeyHeader, test.key)
+			}
+			if test.uid != "" {
+				authResponse := FirebaseAuthResponse{Success: true, UserInfo: auth.UserInfo{UID: test.uid}}
+				req = req.WithContext(context.WithValue(req.Context(), authContextKey{}, authResponse))
+			}
+
+			w := httptest.NewRecorder()
+			handler(w, req)
+
+			if w.Code != test.wantStatus {
+				t.Errorf("got status %d, want %d", w.Code, test.wantStatus)
+			}
+			if handled != test.wantHandled {
+				t.Errorf("handler called: %v, want %v", handled, test.wantHandled)
+			}
+		})
+	}
+}
@@ -25,13 +25,13 @@ func (KivaERA) predictProbDefault(borrower_app BorrowerApp) float64 {
 }
 
 func (KivaERA) predictInterestRate(prob_default float64) float64 {
-	return prob_default * MAX_INTEREST_RATE // uses linear scaling
+	return prob_default * config.Era.MaxInterestRate // uses linear scaling
 }
 
 func (KivaERA) computeQinCollateral(prob_default float64, num_successful_loans uint64) float64 {
 	// The collateral is a linear scaling between the max and min collateral wrt probability of default
 	// and based on the number of successful loans they have had, they need to post less collateral
-	return prob_default * MAX_QIN_COLLATERAL * (1.0 / (float64(num_successful_loans) + 1.0))
+	return prob_default * config.Era.MaxQinCollateral * (1.0 / (float64(num_successful_loans) + 1.0))
 }
 
 func (KivaERA) computeQinReward(prob_default float64, interest_reward float64) float64 {
@@ -41,5 +41,5 @@ func (KivaERA) computeQinReward(prob_default float64, interest_reward float64) f
 }
 
 func (KivaERA) rejectBorrower(prob_default float64) bool {
-	return prob_default > 0.9
+	return prob_default > config.Era.Kiva.RejectAbove
 }
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"google.golang.org/grpc"
+)
+
+// On SIGINT or SIGTERM the server stops taking requests and waits for those in flight, then stops the background
+// workers, then the Firebase auth and Datastore services the workers use, and finally flushes trace spans. Each
+// stage shares one -shutdown-timeout, after which the process exits regardless.
+
+var (
+	workersCtx, stopWorkers   = context.WithCancel(context.Background())
+	servicesCtx, stopServices = context.WithCancel(context.Background())
+
+	workers  sync.WaitGroup
+	services sync.WaitGroup
+)
+
+// Runs a background worker until shutdown. Workers should finish what they are doing and return once ctx is done.
+func StartWorker(worker func(ctx context.Context)) {
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		worker(workersCtx)
+	}()
+}
+
+// Runs a service that workers depend on; it is stopped after them.
+func StartService(service func(ctx context.Context)) {
+	services.Add(1)
+	go func() {
+		defer services.Done()
+		service(servicesCtx)
+	}()
+}
+
+// Runs work started by a request that should outlive it, such as settling collateral after the response was sent.
+// Shutdown waits for it along with the workers.
+func RunInBackground(task func()) {
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		task()
+	}()
+}
+
+// Sleeps for d. Returns false without waiting the rest of d if ctx is done first.
+func SleepUntilDone(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func waitUntilDone(ctx context.Context, group *sync.WaitGroup, what string) {
+	done := make(chan bool)
+	go func() {
+		group.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		logger.Warn("Gave up waiting for "+what, "err", ctx.Err())
+	}
+}
+
+// Shuts the server down in order. grpcServer is nil if the gRPC API is off.
+func Shutdown(grpcServer *grpc.Server, servers ...*http.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+	defer cancel()
+
+	// Event streams never finish on their own.
+	eventBus.Close()
+
+	for _, srv := range servers {
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Warn("Gave up waiting for requests", "err", err)
+			srv.Close()
+		}
+	}
+
+	if grpcServer != nil {
+		stopped := make(chan bool)
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			logger.Warn("Gave up waiting for gRPC calls", "err", ctx.Err())
+			grpcServer.Stop()
+		}
+	}
+
+	stopWorkers()
+	waitUntilDone(ctx, &workers, "background work")
+
+	stopServices()
+	waitUntilDone(ctx, &services, "auth and Datastore clients")
+
+	ShutdownTracing(ctx)
+}
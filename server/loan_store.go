@@ -0,0 +1,426 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"golang.org/x/net/context"
+
+	"cloud.google.com/go/datastore"
+	"google.golang.org/api/iterator"
+)
+
+// Every loan is its own entity under the user's key, so a page of loans can be read without loading the rest.
+// A summary entity next to them tracks the active loan and the totals shown with GET /loans, and is updated
+// in the same transaction as the loans it counts.
+
+const kLoanKind string = "loan"
+const kLoanSummaryKind string = "loanSummary"
+const kLoanSummaryName string = "summary"
+
+type StateCount struct {
+	State string `json:"state"`
+	Count int64  `json:"count"`
+}
+
+type CurrencyTotal struct {
+	CurrencyCode string  `json:"currencyCode"`
+	Borrowed     float64 `json:"borrowed"` // Principal of every loan that was sent
+	Repaid       float64 `json:"repaid"`
+}
+
+type LoanSummary struct {
+	ActiveLoanId    string          `json:"activeLoanId,omitempty"`
+	NumLoans        int64           `json:"numLoans"` // Also numbers new loans, so it never goes down
+	StateCounts     []StateCount    `json:"countsByState"`
+	Totals          []CurrencyTotal `json:"totals"`
+	NumRepaidOnTime int64           `json:"numRepaidOnTime"`
+	OnTimeRate      *float64        `json:"onTimeRate,omitempty" datastore:"-"` // Of the loans that were repaid or defaulted
+}
+
+func UserKey(uid string) *datastore.Key {
+	return datastore.NameKey(kUserKind, uid, nil)
+}
+
+func LoanKey(uid string, loanId string) *datastore.Key {
+	return datastore.NameKey(kLoanKind, loanId, UserKey(uid))
+}
+
+func LoanSummaryKey(uid string) *datastore.Key {
+	return datastore.NameKey(kLoanSummaryKind, kLoanSummaryName, UserKey(uid))
+}
+
+func (summary *LoanSummary) Count(state string) int64 {
+	for _, stateCount := range summary.StateCounts {
+		if stateCount.State == state {
+			return stateCount.Count
+		}
+	}
+	return 0
+}
+
+// Adds (sign 1) or removes (sign -1) a loan's contribution to the summary.
+func (summary *LoanSummary) add(loanRecord *LoanRecord, sign int64) {
+	found := false
+	for i := range summary.StateCounts {
+		if summary.StateCounts[i].State == loanRecord.State {
+			summary.StateCounts[i].Count += sign
+			found = true
+		}
+	}
+	if !found {
+		summary.StateCounts = append(summary.StateCounts, StateCount{State: loanRecord.State, Count: sign})
+	}
+
+	var borrowed, repaid float64
+	switch loanRecord.State {
+	case "SENT", "REPAID", "DEFAULTED":
+		borrowed = loanRecord.Amount
+	}
+	for _, repayment := range loanRecord.Repayments {
+		repaid += repayment.Amount
+	}
+
+	if borrowed != 0.0 || repaid != 0.0 {
+		found = false
+		for i := range summary.Totals {
+			if summary.Totals[i].CurrencyCode == loanRecord.CurrencyCode {
+				// Rounded so that adding and removing the same loan leaves no residue.
+				summary.Totals[i].Borrowed = Round((summary.Totals[i].Borrowed+float64(sign)*borrowed)*10000.0) / 10000.0
+				summary.Totals[i].Repaid = Round((summary.Totals[i].Repaid+float64(sign)*repaid)*10000.0) / 10000.0
+				found = true
+			}
+		}
+		if !found {
+			summary.Totals = append(summary.Totals, CurrencyTotal{
+				CurrencyCode: loanRecord.CurrencyCode,
+				Borrowed:     float64(sign) * borrowed,
+				Repaid:       float64(sign) * repaid,
+			})
+		}
+	}
+
+	if loanRecord.State == "REPAID" && loanRecord.RepaidDate <= loanRecord.DueDate {
+		summary.NumRepaidOnTime += sign
+	}
+
+	// Drop states with no loans left.
+	stateCounts := summary.StateCounts[:0]
+	for _, stateCount := range summary.StateCounts {
+		if stateCount.Count != 0 {
+			stateCounts = append(stateCounts, stateCount)
+		}
+	}
+	summary.StateCounts = stateCounts
+}
+
+// Fills in the fields that are derived rather than stored.
+func (summary *LoanSummary) Complete() {
+	finished := summary.Count("REPAID") + summary.Count("DEFAULTED")
+	if finished > 0 {
+		onTimeRate := float64(summary.NumRepaidOnTime) / float64(finished)
+		summary.OnTimeRate = &onTimeRate
+	}
+	if summary.StateCounts == nil {
+		summary.StateCounts = make([]StateCount, 0)
+	}
+	if summary.Totals == nil {
+		summary.Totals = make([]CurrencyTotal, 0)
+	}
+}
+
+// Reads and writes a user's loans within a datastore transaction, keeping their summary up to date.
+type LoanTx struct {
+	tx      *datastore.Transaction
+	uid     string
+	Summary LoanSummary
+	loans   map[string]*LoanRecord
+	read    map[string]LoanRecord // As read, to take back out of the summary when the loan is written
+	events  []Event               // Published once the transaction commits
+	silent  bool                  // Writes without events or webhooks, for migrations
+}
+
+func NewLoanTx(tx *datastore.Transaction, uid string) (*LoanTx, error) {
+	loanTx := &LoanTx{
+		tx:    tx,
+		uid:   uid,
+		loans: make(map[string]*LoanRecord),
+		read:  make(map[string]LoanRecord),
+	}
+
+	err := tx.Get(LoanSummaryKey(uid), &loanTx.Summary)
+	if err != nil && err != datastore.ErrNoSuchEntity {
+		return nil, err
+	}
+	return loanTx, nil
+}
+
+// Gets the loan with loanId, or the active loan if loanId is empty. Changes are saved with Put.
+func (loanTx *LoanTx) Get(loanId string) (*LoanRecord, error) {
+	if loanId == "" {
+		if loanTx.Summary.ActiveLoanId == "" {
+			return nil, ErrNoActiveLoan
+		}
+		loanId = loanTx.Summary.ActiveLoanId
+	}
+
+	if loanRecord, ok := loanTx.loans[loanId]; ok {
+		return loanRecord, nil
+	}
+
+	loanRecord := new(LoanRecord)
+	err := loanTx.tx.Get(LoanKey(loanTx.uid, loanId), loanRecord)
+	if err == datastore.ErrNoSuchEntity {
+		return nil, ErrLoanNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	loanTx.loans[loanId] = loanRecord
+	loanTx.read[loanId] = *loanRecord
+	return loanRecord, nil
+}
+
+// ID for the next loan the user takes out.
+func (loanTx *LoanTx) NextLoanId() string {
+	return loanTx.uid + "-" + strconv.FormatInt(loanTx.Summary.NumLoans, 10)
+}
+
+// Writes a new or changed loan along with the summary.
+func (loanTx *LoanTx) Put(loanRecord *LoanRecord) error {
+	isActive, err := IsLoanActive(loanRecord)
+	if err != nil {
+		return err
+	}
+
+	var previous *LoanRecord
+	if read, ok := loanTx.read[loanRecord.LoanId]; ok {
+		previous = &read
+		loanTx.Summary.add(previous, -1)
+	} else {
+		loanTx.Summary.NumLoans++
+	}
+	loanTx.Summary.add(loanRecord, 1)
+
+	if !loanTx.silent {
+		loanTx.events = append(loanTx.events, LoanEvents(previous, loanRecord)...)
+		if err := PutWebhookDeliveries(loanTx.tx, loanTx.uid, previous, loanRecord); err != nil {
+			return err
+		}
+	}
+
+	if isActive {
+		if loanTx.Summary.ActiveLoanId != "" && loanTx.Summary.ActiveLoanId != loanRecord.LoanId {
+			return fmt.Errorf("Loan %s would be active alongside %s", loanRecord.LoanId, loanTx.Summary.ActiveLoanId)
+		}
+		loanTx.Summary.ActiveLoanId = loanRecord.LoanId
+	} else if loanTx.Summary.ActiveLoanId == loanRecord.LoanId {
+		loanTx.Summary.ActiveLoanId = ""
+	}
+
+	_, err = loanTx.tx.Put(LoanKey(loanTx.uid, loanRecord.LoanId), loanRecord)
+	if err != nil {
+		return err
+	}
+
+	_, err = loanTx.tx.Put(LoanSummaryKey(loanTx.uid), &loanTx.Summary)
+	if err != nil {
+		return err
+	}
+
+	loanTx.loans[loanRecord.LoanId] = loanRecord
+	loanTx.read[loanRecord.LoanId] = *loanRecord
+	return nil
+}
+
+// Queues an event for the user that is published if the transaction commits.
+func (loanTx *LoanTx) Notify(event Event) {
+	loanTx.events = append(loanTx.events, event)
+}
+
+// Runs f in a transaction with the user's loans and publishes the events of the attempt that commits.
+func RunLoanTransaction(ctx context.Context, dbClient *datastore.Client, uid string, f func(tx *datastore.Transaction, loanTx *LoanTx) error) error {
+	var loanTx *LoanTx
+
+	_, err := RunInTransaction(ctx, dbClient, "RunLoanTransaction", func(tx *datastore.Transaction) error {
+		var loan_err error
+		loanTx, loan_err = NewLoanTx(tx, uid)
+		if loan_err != nil {
+			return loan_err
+		}
+
+		return f(tx, loanTx)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	for _, event := range loanTx.events {
+		eventBus.Publish(uid, event)
+	}
+	return nil
+}
+
+// Defaults the active loan if it is overdue. Returns whether it did.
+func (loanTx *LoanTx) DefaultActiveLoanIfNecessary() (bool, error) {
+	activeLoan, err := loanTx.Get("")
+	if err == ErrNoActiveLoan {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	didModify, err := DefaultLoanIfNecessary(activeLoan)
+	if err != nil || !didModify {
+		return false, err
+	}
+
+	return true, loanTx.Put(activeLoan)
+}
+
+// Selects a page of a user's loans. Each combination of filter and sort needs an index from index.yaml.
+type LoanQuery struct {
+	State      string
+	SortBy     string // LoanRecord field, DateCreated or DueDate
+	Descending bool
+	From       *int64 // Bounds on SortBy, inclusive
+	To         *int64
+	Limit      int
+	Cursor     *datastore.Cursor
+}
+
+// Returns a page of loans and the cursor of the next page, which is empty after the last one.
+func QueryLoans(ctx context.Context, dbClient *datastore.Client, uid string, loanQuery LoanQuery) ([]LoanRecord, string, error) {
+	query := datastore.NewQuery(kLoanKind).Ancestor(UserKey(uid))
+	if loanQuery.State != "" {
+		query = query.Filter("State =", loanQuery.State)
+	}
+	if loanQuery.From != nil {
+		query = query.Filter(loanQuery.SortBy+" >=", *loanQuery.From)
+	}
+	if loanQuery.To != nil {
+		query = query.Filter(loanQuery.SortBy+" <=", *loanQuery.To)
+	}
+	if loanQuery.Descending {
+		query = query.Order("-" + loanQuery.SortBy)
+	} else {
+		query = query.Order(loanQuery.SortBy)
+	}
+	if loanQuery.Cursor != nil {
+		query = query.Start(*loanQuery.Cursor)
+	}
+
+	// One more than asked for tells whether there is another page.
+	return readLoanPage(dbClient.Run(ctx, query.Limit(loanQuery.Limit+1)), loanQuery.Limit)
+}
+
+// The part of *datastore.Iterator that paging uses.
+type loanIterator interface {
+	Next(dst interface{}) (*datastore.Key, error)
+	Cursor() (datastore.Cursor, error)
+}
+
+// Reads up to limit loans from it, along with the cursor of the next page if it has more.
+func readLoanPage(it loanIterator, limit int) ([]LoanRecord, string, error) {
+	loanRecords := make([]LoanRecord, 0, limit)
+	for len(loanRecords) < limit {
+		var loanRecord LoanRecord
+		_, err := it.Next(&loanRecord)
+		if err == iterator.Done {
+			return loanRecords, "", nil
+		} else if err != nil {
+			return nil, "", err
+		}
+		loanRecords = append(loanRecords, loanRecord)
+	}
+
+	cursor, err := it.Cursor()
+	if err != nil {
+		return nil, "", err
+	}
+
+	var next LoanRecord
+	_, err = it.Next(&next)
+	if err == iterator.Done {
+		return loanRecords, "", nil
+	} else if err != nil {
+		return nil, "", err
+	}
+	return loanRecords, cursor.String(), nil
+}
+
+// Loans whose collateral still has to move on-chain. Reads within tx if it is not nil.
+func LoansAwaitingCollateral(ctx context.Context, dbClient *datastore.Client, uid string, tx *datastore.Transaction) ([]LoanRecord, error) {
+	var awaiting []LoanRecord
+
+	for _, collateralState := range []string{kCollateralPendingLock, kCollateralLocked} {
+		query := datastore.NewQuery(kLoanKind).Ancestor(UserKey(uid)).Filter("CollateralState =", collateralState)
+		if tx != nil {
+			query = query.Transaction(tx)
+		}
+
+		var loanRecords []LoanRecord
+		if _, err := dbClient.GetAll(ctx, query, &loanRecords); err != nil {
+			return nil, err
+		}
+
+		for _, loanRecord := range loanRecords {
+			if NextCollateralState(&loanRecord) != "" {
+				awaiting = append(awaiting, loanRecord)
+			}
+		}
+	}
+
+	return awaiting, nil
+}
+
+// Loans used to be stored together as one LoanHistory entity per user. Moves any that are left over into loan
+// entities; run before serving.
+func MigrateLoanHistories() error {
+	dbClient := GetDbClient()
+	defer func() { returnDbClient <- dbClient }()
+
+	ctx := context.Background()
+	keys, err := dbClient.GetAll(ctx, datastore.NewQuery(kLoanHistoryKind).KeysOnly(), nil)
+	if err != nil {
+		return err
+	}
+
+	for _, loanHistoryKey := range keys {
+		uid := loanHistoryKey.Name
+
+		_, err := RunInTransaction(ctx, dbClient, "MigrateLoanHistory", func(tx *datastore.Transaction) error {
+			var loanHistory LoanHistory
+			get_err := tx.Get(loanHistoryKey, &loanHistory)
+			if get_err == datastore.ErrNoSuchEntity {
+				return nil
+			} else if get_err != nil {
+				return get_err
+			}
+
+			loanTx, loan_err := NewLoanTx(tx, uid)
+			if loan_err != nil {
+				return loan_err
+			}
+			loanTx.silent = true
+
+			for i := range loanHistory.LoanRecords {
+				if put_err := loanTx.Put(&loanHistory.LoanRecords[i]); put_err != nil {
+					return put_err
+				}
+			}
+
+			return tx.Delete(loanHistoryKey)
+		})
+
+		if err != nil {
+			return fmt.Errorf("Failed to migrate loans of %s: %v", uid, err)
+		}
+	}
+
+	if len(keys) > 0 {
+		logger.Info("Migrated loan histories", "users", len(keys))
+	}
+	return nil
+}
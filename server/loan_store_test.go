@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/datastore"
+	"google.golang.org/api/iterator"
+)
+
+// Iterates over loans in memory, like a query limited to limit results. The cursor after the nth loan encodes n.
+type fakeLoanIterator struct {
+	loans  []LoanRecord
+	limit  int
+	read   int
+	err    error // Returned instead of the loan at failAt
+	failAt int
+}
+
+func (it *fakeLoanIterator) Next(dst interface{}) (*datastore.Key, error) {
+	if it.err != nil && it.read == it.failAt {
+		return nil, it.err
+	}
+	if it.read >= len(it.loans) || it.read >= it.limit {
+		return nil, iterator.Done
+	}
+	*dst.(*LoanRecord) = it.loans[it.read]
+	it.read++
+	return datastore.NameKey(kLoanKind, it.loans[it.read-1].LoanId, nil), nil
+}
+
+func (it *fakeLoanIterator) Cursor() (datastore.Cursor, error) {
+	return fakeCursor(it.read), nil
+}
+
+func fakeCursor(position int) datastore.Cursor {
+	cursor, _ := datastore.DecodeCursor(base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprint(position))))
+	return cursor
+}
+
+func TestReadLoanPage(t *testing.T) {
+	loans := func(n int) []LoanRecord {
+		records := make([]LoanRecord, n)
+		for i := range records {
+			records[i].LoanId = fmt.Sprint("loan-", i)
+		}
+		return records
+	}
+	failure := errors.New("datastore unavailable")
+
+	tests := []struct {
+		name       string
+		stored     int
+		limit      int
+		failAt     int
+		wantLoans  int
+		wantCursor string
+		wantErr    error
+	}{
+		{"no loans", 0, 3, -1, 0, "", nil},
+		{"fewer than a page", 2, 3, -1, 2, "", nil},
+		{"exactly a page", 3, 3, -1, 3, "", nil},
+		{"more than a page", 4, 3, -1, 3, fakeCursor(3).String(), nil},
+		{"several more pages", 10, 3, -1, 3, fakeCursor(3).String(), nil},
+		{"fails within the page", 4, 3, 1, 0, "", failure},
+		{"fails looking ahead", 4, 3, 3, 0, "", failure},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			it := &fakeLoanIterator{loans: loans(test.stored), limit: test.limit + 1, failAt: test.failAt}
+			if test.failAt >= 0 {
+				it.err = failure
+			}
+
+			page, cursor, err := readLoanPage(it, test.limit)
+			if err != test.wantErr {
+				t.Fatalf("got error %v, want %v", err, test.wantErr)
+			}
+			if len(page) != test.wantLoans {
+				t.Errorf("got %d loans, want %d", len(page), test.wantLoans)
+			}
+			if cursor != test.wantCursor {
+				t.Errorf("got cursor %q, want %q", cursor, test.wantCursor)
+			}
+			if test.wantErr == nil && !reflect.DeepEqual(page, loans(test.stored)[:test.wantLoans]) {
+				t.Errorf("got loans %v, want the first %d in order", page, test.wantLoans)
+			}
+		})
+	}
+}
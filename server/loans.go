@@ -0,0 +1,711 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"cloud.google.com/go/datastore"
+)
+
+// Loan operations shared by the API versions. v1 only ever acts on the active loan and passes an empty loan
+// ID; v2 addresses loans by ID. Callers strip loanRecord.Request before returning a loan to the client.
+
+var ErrLoanNotFound = NewAPIError(http.StatusNotFound, "LOAN_NOT_FOUND", "Loan was not found.")
+
+const kDefaultLoanPageSize int = 20
+const kMaxLoanPageSize int = 100
+
+var loanStates = []string{"PENDING", "PENDING_REVIEW", "APPROVED", "REJECTED", "ACCEPTED", "SENT", "REPAID", "DEFAULTED", "CANCELED"}
+
+// Sort parameter values and the fields they sort on. A leading "-" sorts newest first.
+var loanSortFields = map[string]string{
+	"created": "DateCreated",
+	"dueDate": "DueDate",
+}
+
+type LoanPage struct {
+	LoanRecords []LoanRecord `json:"loans"`
+	NextCursor  string       `json:"nextCursor,omitempty"` // Pass as cursor to get the next page
+	Summary     LoanSummary  `json:"summary"`              // Covers every loan, whatever the filters
+}
+
+// Reads the query parameters of GET /loans: limit, cursor, state, sort, and from and to, which are Unix
+// milliseconds bounding the sorted date.
+func ParseLoanQuery(values url.Values, defaultSort string, defaultLimit int) (LoanQuery, error) {
+	var v Validator
+	var loanQuery LoanQuery
+
+	loanQuery.Limit = defaultLimit
+	if limit := values.Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil {
+			v.Fail("limit", kFieldInvalidValue, "Must be a whole number.")
+		} else {
+			v.Range("limit", float64(parsed), 1, float64(kMaxLoanPageSize))
+			loanQuery.Limit = parsed
+		}
+	}
+
+	if cursor := values.Get("cursor"); cursor != "" {
+		parsed, err := datastore.DecodeCursor(cursor)
+		if err != nil {
+			v.Fail("cursor", kFieldInvalidValue, "Must be a nextCursor from a previous page.")
+		}
+		loanQuery.Cursor = &parsed
+	}
+
+	if state := values.Get("state"); state != "" {
+		v.OneOf("state", state, loanStates...)
+		loanQuery.State = state
+	}
+
+	sort := values.Get("sort")
+	if sort == "" {
+		sort = defaultSort
+	}
+	loanQuery.Descending = strings.HasPrefix(sort, "-")
+	loanQuery.SortBy = loanSortFields[strings.TrimPrefix(sort, "-")]
+	if loanQuery.SortBy == "" {
+		v.OneOf("sort", sort, "created", "-created", "dueDate", "-dueDate")
+	}
+
+	for _, bound := range []string{"from", "to"} {
+		value := values.Get(bound)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			v.Fail(bound, kFieldInvalidValue, "Must be a Unix timestamp in milliseconds.")
+		} else if bound == "from" {
+			loanQuery.From = &parsed
+		} else {
+			loanQuery.To = &parsed
+		}
+	}
+
+	return loanQuery, v.Err()
+}
+
+// Applies for a loan with a validated request. The ERAs' offers come back on the new loan.
+func RequestLoan(ctx context.Context, uid string, loanRequest LoanRequest) (*LoanRecord, error) {
+	loanRecord := new(LoanRecord)
+	loanRecord.Request = &loanRequest
+
+	if loanRecord.Request.Currency == "" {
+		loanRecord.Request.Currency = kDefaultCurrency
+	}
+
+	currency, err := CurrencyForCode(loanRecord.Request.Currency)
+
+	if err == nil {
+		err = currency.ValidateLoanAmount(loanRecord.Request.LoanAmount)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// ERAs score every application in the base currency.
+	basePrincipal, err := ToBaseCurrency(loanRecord.Request.LoanAmount, currency.Code)
+
+	if err != nil {
+		return nil, err
+	}
+
+	loanRecord.Memo = loanRecord.Request.LoanMemo
+	loanRecord.Amount = loanRecord.Request.LoanAmount
+	loanRecord.CurrencyCode = currency.Code
+
+	loanRecord.DateCreated = time.Now().Unix() * 1000
+
+	// Note: this should be reset when the loan is accepted or rejected by the ERA.
+	loanRecord.State = "PENDING"
+
+	dbClient := GetDbClient()
+
+	userKey := UserKey(uid)
+	var review *LoanReview
+
+	err = RunLoanTransaction(ctx, dbClient, uid, func(tx *datastore.Transaction, loanTx *LoanTx) error {
+		var user User
+
+		get_err := tx.Get(userKey, &user)
+		if get_err == datastore.ErrNoSuchEntity {
+			return ErrUserNotRegistered
+		} else if get_err != nil {
+			return get_err
+		}
+
+		if user.EmploymentInfo == nil || user.ResidenceInfo == nil {
+			return ErrUserDataNotFound
+		}
+
+		loanRecord.Request.User = &user
+
+		// An overdue loan defaults here rather than blocking the new one.
+		_, default_err := loanTx.DefaultActiveLoanIfNecessary()
+
+		if default_err != nil {
+			return default_err
+		}
+
+		if loanTx.Summary.ActiveLoanId != "" {
+			return ErrLoanAlreadyExists
+		}
+
+		// Set loan ID
+		loanRecord.LoanId = loanTx.NextLoanId()
+
+		var borrowerInfo BorrowerInformation
+		borrowerInfo.earned_qin = user.QinBalance
+		borrowerInfo.successful_loans = uint64(loanTx.Summary.Count("REPAID"))
+		borrowerInfo.no_loans = uint64(loanTx.Summary.Count("REPAID") + loanTx.Summary.Count("DEFAULTED"))
+
+		var borrowerApp BorrowerApp
+		borrowerApp.principal_amount = basePrincipal
+		borrowerApp.borrower_id = uid
+
+		// Handle all them pointers
+		if income := loanRecord.Request.User.EmploymentInfo.EmploymentIncome; income == nil {
+			borrowerApp.stated_monthly_income = 0
+		} else {
+			borrowerApp.stated_monthly_income = *income
+		}
+
+		if startMonth := loanRecord.Request.User.EmploymentInfo.EmploymentStartMonth; startMonth == nil {
+			borrowerApp.employment_start_month = 0
+		} else {
+			borrowerApp.employment_start_month = *startMonth
+		}
+
+		if startYear := loanRecord.Request.User.EmploymentInfo.EmploymentStartYear; startYear == nil {
+			borrowerApp.employment_start_year = 0
+		} else {
+			borrowerApp.employment_start_year = *startYear
+		}
+
+		borrowerApp.employment_status = loanRecord.Request.User.EmploymentInfo.EmploymentStatus
+
+		// fmt.Printf("Borrower App Struct:\n%+v\n", &borrowerApp)
+		// fmt.Printf("Borrower Info Struct:\n%+v\n", &borrowerInfo)
+
+		era_terms, num_not_nil := processBorrowerRequest(ctx, eraDriver, borrowerApp, borrowerInfo)
+
+		loanRecord.State = "APPROVED"
+
+		if num_not_nil > 0 {
+			loanRecord.State = "APPROVED"
+
+			loanRecord.Terms = make([]LoanTerms, num_not_nil)
+
+			var currentIndex int
+			currentIndex = 0
+			for _, terms := range era_terms {
+				if terms != nil { // skip rejected eras
+					// fmt.Printf("ERA Terms %i:\n%+v\n", i, terms)
+					loanRecord.Terms[currentIndex].TermId = loanRecord.LoanId + "-" + strconv.Itoa(currentIndex)
+					// Round to 4 decimal places (or round the percentage to 2 decimal places)
+					loanRecord.Terms[currentIndex].InterestRate = Round(terms.interest_rate*10000.0) / 10000.0
+					// Round QIN to nearest 0.01 QIN.
+					loanRecord.Terms[currentIndex].QinReward = Round(terms.qin_reward*100.0) / 100.0
+					loanRecord.Terms[currentIndex].QinRequired = Round(terms.qin_collateral*100.0) / 100.0
+					loanRecord.Terms[currentIndex].AmountOwed = currency.Round((1.0 + loanRecord.Terms[currentIndex].InterestRate) * loanRecord.Amount)
+					loanRecord.Terms[currentIndex].OfferedBy = terms.offered_by
+					loanRecord.Terms[currentIndex].Valuation = ValueTerms(&loanRecord.Terms[currentIndex], terms.interest_reward)
+					currentIndex++
+				}
+			}
+
+		} else {
+			loanRecord.Terms = make([]LoanTerms, 1)
+
+			loanRecord.Terms[0].TermId = loanRecord.LoanId + "-0"
+			loanRecord.Terms[0].InterestRate = 0.05
+			// Round QIN to nearest 0.01 QIN.
+			loanRecord.Terms[0].QinReward = 0.1
+			loanRecord.Terms[0].QinRequired = 0.0
+			loanRecord.Terms[0].AmountOwed = currency.Round((1.0 + loanRecord.Terms[0].InterestRate) * loanRecord.Amount)
+			loanRecord.Terms[0].OfferedBy = "OneDaijo"
+			loanRecord.Terms[0].Valuation = ValueTerms(&loanRecord.Terms[0], 0.0)
+		}
+
+		// Some applications wait for a loan officer before the borrower sees any terms.
+		review = nil
+		if reasons := ReviewReasons(basePrincipal, &user, borrowerInfo.no_loans, loanRecord.Terms); len(reasons) > 0 {
+			var review_err error
+			review, review_err = HoldForReview(tx, uid, loanRecord, reasons)
+			if review_err != nil {
+				return review_err
+			}
+		}
+
+		put_err := loanTx.Put(loanRecord)
+		if put_err != nil {
+			return put_err
+		}
+
+		return nil
+	})
+
+	returnDbClient <- dbClient
+
+	if err != nil {
+		return nil, err
+	}
+
+	if review != nil {
+		ObserveReviewHeld(review)
+	}
+
+	// A previous loan may have just defaulted.
+	SettleQinCollateralAsync(ctx, uid)
+
+	return loanRecord, nil
+}
+
+// Reads a page of a user's loans along with their summary, defaulting the active loan first if it is overdue.
+func ListLoans(ctx context.Context, uid string, loanQuery LoanQuery) (*LoanPage, error) {
+	dbClient := GetDbClient()
+
+	var loanPage LoanPage
+	var didModify bool
+
+	err := RunLoanTransaction(ctx, dbClient, uid, func(tx *datastore.Transaction, loanTx *LoanTx) error {
+		var default_err error
+		didModify, default_err = loanTx.DefaultActiveLoanIfNecessary()
+
+		loanPage.Summary = loanTx.Summary
+		return default_err
+	})
+
+	if err == nil {
+		loanPage.LoanRecords, loanPage.NextCursor, err = QueryLoans(ctx, dbClient, uid, loanQuery)
+	}
+
+	returnDbClient <- dbClient
+
+	if err != nil {
+		return nil, err
+	}
+
+	if didModify {
+		SettleQinCollateralAsync(ctx, uid)
+	}
+
+	for i := range loanPage.LoanRecords {
+		// Remove the loan request because the client doesn't want that
+		loanPage.LoanRecords[i].Request = nil
+	}
+	loanPage.Summary.Complete()
+
+	return &loanPage, nil
+}
+
+// Reads every loan of a user, oldest first, for v1 clients that expect the whole list at once. Defaults the
+// active loan first if it is overdue.
+func ListAllLoans(ctx context.Context, uid string) (*LoanHistory, error) {
+	dbClient := GetDbClient()
+
+	var didModify bool
+
+	err := RunLoanTransaction(ctx, dbClient, uid, func(tx *datastore.Transaction, loanTx *LoanTx) error {
+		var default_err error
+		didModify, default_err = loanTx.DefaultActiveLoanIfNecessary()
+		return default_err
+	})
+
+	var loanHistory LoanHistory
+	loanQuery := LoanQuery{SortBy: "DateCreated", Limit: kMaxLoanPageSize}
+
+	// Read page by page so no single query is unbounded.
+	for err == nil {
+		var loanRecords []LoanRecord
+		var next string
+		loanRecords, next, err = QueryLoans(ctx, dbClient, uid, loanQuery)
+		if err != nil {
+			break
+		}
+		loanHistory.LoanRecords = append(loanHistory.LoanRecords, loanRecords...)
+
+		if next == "" {
+			break
+		}
+		cursor, cursor_err := datastore.DecodeCursor(next)
+		if cursor_err != nil {
+			err = cursor_err
+			break
+		}
+		loanQuery.Cursor = &cursor
+	}
+
+	returnDbClient <- dbClient
+
+	if err != nil {
+		return nil, err
+	}
+
+	if didModify {
+		SettleQinCollateralAsync(ctx, uid)
+	}
+
+	for i := range loanHistory.LoanRecords {
+		// Remove the loan request because the client doesn't want that
+		loanHistory.LoanRecords[i].Request = nil
+	}
+
+	return &loanHistory, nil
+}
+
+// Reads a loan, first defaulting the active loan if it is overdue.
+func ReadLoan(ctx context.Context, uid string, loanId string) (*LoanRecord, error) {
+	dbClient := GetDbClient()
+
+	var loanRecord *LoanRecord
+	var didModify bool
+
+	err := RunLoanTransaction(ctx, dbClient, uid, func(tx *datastore.Transaction, loanTx *LoanTx) error {
+		var default_err error
+		didModify, default_err = loanTx.DefaultActiveLoanIfNecessary()
+
+		if default_err != nil {
+			return default_err
+		}
+
+		var get_err error
+		loanRecord, get_err = loanTx.Get(loanId)
+		return get_err
+	})
+
+	returnDbClient <- dbClient
+
+	if err != nil {
+		return nil, err
+	}
+
+	if didModify {
+		SettleQinCollateralAsync(ctx, uid)
+	}
+
+	return loanRecord, nil
+}
+
+// Sends a loan for pickup through a partner, due in 30 days. Its payout is written with it.
+func markLoanSent(loanRecord *LoanRecord, partnerId string, now time.Time) {
+	// Adds 30 days, gets the unix timestamps rounds down to the nearest day and multiplies by 1000 to get it in milliseconds
+	loanRecord.DueDate = (now.AddDate(0, 0, 30).Unix() / 86400 * 86400) * 1000
+	loanRecord.State = "SENT"
+	loanRecord.Disbursement = NewDisbursement(partnerId)
+}
+
+// Accepts terms and/or a pickup location for an approved loan. Choosing a location sends the loan.
+func SelectLoanTerms(ctx context.Context, uid string, loanId string, selection LoanSelectRequest) (*LoanRecord, error) {
+	if err := CheckPaymentsAvailable(); err != nil {
+		return nil, err
+	}
+
+	hasLocation := selection.Location.LocationId != "" || selection.Location.LocationName != ""
+
+	var location *Location
+	var err error
+
+	if hasLocation {
+		location, err = LocationForSelection(selection.Location)
+
+		if err == nil && selection.PartnerId == "" {
+			selection.PartnerId = location.PartnerId
+		} else if err == nil && selection.PartnerId != location.PartnerId {
+			err = ErrLocationPartnerMismatch
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	partner, err := PartnerForId(selection.PartnerId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	dbClient := GetDbClient()
+
+	userKey := datastore.NameKey(kUserKind, uid, nil)
+	var activeLoan *LoanRecord
+
+	err = RunLoanTransaction(ctx, dbClient, uid, func(tx *datastore.Transaction, loanTx *LoanTx) error {
+		var user User
+
+		get_err := tx.Get(userKey, &user)
+		if get_err == datastore.ErrNoSuchEntity {
+			return ErrUserNotRegistered
+		} else if get_err != nil {
+			return get_err
+		}
+
+		activeLoan, get_err = loanTx.Get(loanId)
+		if get_err != nil {
+			return get_err
+		}
+
+		if activeLoan.State != "APPROVED" {
+			return ErrLoanInWrongState
+		}
+
+		// Loan terms must be selected before or at the same time as pickup location
+		if selection.SelectedTerm != "" {
+			// Loan terms cannot be provided twice.
+			if activeLoan.AcceptedTerms != nil {
+				return ErrBadJsonPopulation
+			}
+
+			terms := LoanTermsForId(selection.SelectedTerm, activeLoan)
+			if terms == nil {
+				return ErrInvalidId
+			}
+
+			if terms.QinRequired > user.QinBalance {
+				return ErrNotEnoughQin
+			}
+
+			if config.QinOnChain && user.StellarAddress == "" {
+				return ErrStellarAccountNotLinked
+			}
+
+			activeLoan.AcceptedTerms = terms
+		}
+
+		if location != nil {
+			if activeLoan.AcceptedTerms == nil {
+				return ErrBadJsonPopulation
+			}
+
+			if !location.SupportsCurrency(activeLoan.CurrencyCode) {
+				return ErrLocationCurrency
+			}
+
+			if _, asset_err := partner.AssetForCurrency(activeLoan.CurrencyCode); asset_err != nil {
+				return asset_err
+			}
+
+			location_err := ReserveLocationCapacity(tx, location, activeLoan.Amount, time.Now())
+			if location_err != nil {
+				return location_err
+			}
+
+			code, code_err := IssuePickupCode(tx, uid, activeLoan.LoanId, location.LocationId)
+			if code_err != nil {
+				return code_err
+			}
+
+			activeLoan.PickupCode = code
+			activeLoan.Location = &PickupLocation{LocationId: location.LocationId, LocationName: location.Name}
+
+			// Ignore money sending errors for the demo for demo
+			// if err != nil {
+			// 	return err
+			// }
+
+			markLoanSent(activeLoan, partner.PartnerId, time.Now())
+
+			if user.QinBalance < activeLoan.AcceptedTerms.QinRequired {
+				return errors.New("Internal Error: user has less QIN than when loan was selected.")
+			}
+
+			user.QinBalance -= activeLoan.AcceptedTerms.QinRequired
+
+			// The balance above mirrors the chain; the collateral itself moves once this transaction commits.
+			if config.QinOnChain {
+				activeLoan.CollateralState = kCollateralPendingLock
+			}
+
+			_, put_err := tx.Put(userKey, &user)
+			if put_err != nil {
+				return put_err
+			}
+			loanTx.Notify(QinBalanceEvent(user.QinBalance))
+
+		}
+
+		put_err := loanTx.Put(activeLoan)
+		if put_err != nil {
+			return put_err
+		}
+
+		return nil
+	})
+
+	returnDbClient <- dbClient
+
+	if err != nil {
+		return nil, err
+	}
+
+	if activeLoan.State == "SENT" {
+		activeLoan = StartPayout(ctx, uid, activeLoan)
+		SettleQinCollateralAsync(ctx, uid)
+	}
+
+	return activeLoan, nil
+}
+
+// Repays a sent loan, instantly or by cash-in through a partner. Returns false while a cash-in is pending.
+func RepayLoan(ctx context.Context, uid string, loanId string, repayRequest RepayRequest) (*LoanRecord, bool, error) {
+	if err := CheckPaymentsAvailable(); err != nil {
+		return nil, false, err
+	}
+
+	if repayRequest.PartnerId != "" {
+		pendingLoan, done, cash_in_err := StartOrCheckCashIn(ctx, uid, loanId, repayRequest.PartnerId)
+
+		if cash_in_err != nil {
+			return nil, false, cash_in_err
+		}
+
+		if !done {
+			return pendingLoan, false, nil
+		}
+	}
+
+	dbClient := GetDbClient()
+
+	userKey := datastore.NameKey(kUserKind, uid, nil)
+	var activeLoan *LoanRecord
+
+	var repaid bool
+
+	err := RunLoanTransaction(ctx, dbClient, uid, func(tx *datastore.Transaction, loanTx *LoanTx) error {
+		var user User
+
+		get_err := tx.Get(userKey, &user)
+		if get_err == datastore.ErrNoSuchEntity {
+			return ErrUserNotRegistered
+		} else if get_err != nil {
+			return get_err
+		}
+
+		activeLoan, get_err = loanTx.Get(loanId)
+		if get_err != nil {
+			return get_err
+		}
+
+		if activeLoan.State != "SENT" {
+			return ErrLoanInWrongState
+		}
+
+		didModify, default_err := DefaultLoanIfNecessary(activeLoan)
+
+		if default_err != nil {
+			return default_err
+		}
+
+		// If it was modified above, this loan is no longer active and should not be repaid
+		if !didModify {
+			var timestamp int64
+			timestamp = time.Now().Unix() * 1000
+			// Instant repayment for demo
+			activeLoan.Repayments = append(activeLoan.Repayments, Repayment{Amount: activeLoan.AcceptedTerms.AmountOwed, Timestamp: timestamp})
+			activeLoan.RepaidDate = timestamp
+			activeLoan.State = "REPAID"
+
+			// Return the collateral and give the reward
+			user.QinBalance += activeLoan.AcceptedTerms.QinRequired + activeLoan.AcceptedTerms.QinReward
+
+			// This is the only place where we modify user, so only write in this if
+			_, put_err := tx.Put(userKey, &user)
+			if put_err != nil {
+				return put_err
+			}
+			loanTx.Notify(QinBalanceEvent(user.QinBalance))
+
+			repaid = true
+
+		} else {
+			repaid = false
+		}
+
+		put_err := loanTx.Put(activeLoan)
+
+		if put_err != nil {
+			return put_err
+		}
+
+		return nil
+
+	})
+
+	returnDbClient <- dbClient
+
+	// Collateral is released on repayment or forfeited on default.
+	if err == nil {
+		SettleQinCollateralAsync(ctx, uid)
+	}
+
+	if err == nil && !repaid {
+		err = ErrLoanInDefault
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return activeLoan, true, nil
+}
+
+// Cancels a loan that has not been sent yet.
+func CancelLoan(ctx context.Context, uid string, loanId string) (*LoanRecord, error) {
+	dbClient := GetDbClient()
+
+	var canceledLoan *LoanRecord
+	var review *LoanReview
+
+	err := RunLoanTransaction(ctx, dbClient, uid, func(tx *datastore.Transaction, loanTx *LoanTx) error {
+		activeLoan, get_err := loanTx.Get(loanId)
+		if get_err != nil {
+			return get_err
+		}
+
+		review = nil
+		switch activeLoan.State {
+		case "APPROVED", "PENDING":
+		case "PENDING_REVIEW":
+			var review_err error
+			review, review_err = CancelLoanReview(tx, uid, activeLoan.LoanId)
+			if review_err != nil {
+				return review_err
+			}
+		default:
+			return ErrLoanInWrongState
+		}
+
+		activeLoan.State = "CANCELED"
+		canceledLoan = activeLoan
+
+		put_err := loanTx.Put(activeLoan)
+
+		if put_err != nil {
+			return put_err
+		}
+
+		return nil
+
+	})
+
+	returnDbClient <- dbClient
+
+	if err != nil {
+		return nil, err
+	}
+
+	if review != nil {
+		ObserveReviewDecision(review)
+	}
+	return canceledLoan, nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestParseLoanQuery(t *testing.T) {
+	cursor := fakeCursor(3).String()
+
+	tests := []struct {
+		name           string
+		query          string
+		wantErr        bool
+		wantLimit      int
+		wantSortBy     string
+		wantDescending bool
+		wantCursor     string
+	}{
+		{"defaults", "", false, kDefaultLoanPageSize, "DateCreated", true, ""},
+		{"next page", "limit=5&cursor=" + cursor, false, 5, "DateCreated", true, cursor},
+		{"by due date", "sort=dueDate", false, kDefaultLoanPageSize, "DueDate", false, ""},
+		{"largest page", "limit=100", false, kMaxLoanPageSize, "DateCreated", true, ""},
+		{"empty page", "limit=0", true, 0, "", false, ""},
+		{"page too large", "limit=101", true, 0, "", false, ""},
+		{"limit not a number", "limit=ten", true, 0, "", false, ""},
+		{"cursor not base64", "cursor=%25", true, 0, "", false, ""},
+		{"unknown sort", "sort=amount", true, 0, "", false, ""},
+		{"unknown state", "state=LOST", true, 0, "", false, ""},
+		{"bound not a number", "from=yesterday", true, 0, "", false, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			values, err := url.ParseQuery(test.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			loanQuery, err := ParseLoanQuery(values, "-created", kDefaultLoanPageSize)
+			if test.wantErr {
+				if apiErr, ok := err.(*APIError); !ok || apiErr.Code != ErrBadJsonPopulation.Code {
+					t.Errorf("got error %v, want %s", err, ErrBadJsonPopulation.Code)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if loanQuery.Limit != test.wantLimit || loanQuery.SortBy != test.wantSortBy || loanQuery.Descending != test.wantDescending {
+				t.Errorf("got limit %d sorted by %s (descending %v), want %d by %s (descending %v)",
+					loanQuery.Limit, loanQuery.SortBy, loanQuery.Descending, test.wantLimit, test.wantSortBy, test.wantDescending)
+			}
+
+			gotCursor := ""
+			if loanQuery.Cursor != nil {
+				gotCursor = loanQuery.Cursor.String()
+			}
+			if gotCursor != test.wantCursor {
+				t.Errorf("got cursor %q, want %q", gotCursor, test.wantCursor)
+			}
+		})
+	}
+}
@@ -0,0 +1,325 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"cloud.google.com/go/datastore"
+)
+
+const kLocationKind string = "location"
+const kLocationUsageKind string = "location-usage"
+const kPickupCodeKind string = "pickup-code"
+
+const kLocationRefreshInterval time.Duration = 10 * time.Minute
+const kPickupCodeDigits int = 8
+const kPickupCodeAttempts int = 5
+const kEarthRadiusKm float64 = 6371.0
+
+var (
+	ErrInvalidLocation          = NewAPIError(http.StatusBadRequest, "INVALID_LOCATION", "Pickup location was not found.")
+	ErrLocationPartnerMismatch  = NewAPIError(http.StatusBadRequest, "LOCATION_PARTNER_MISMATCH", "Pickup location does not belong to the selected partner.")
+	ErrLocationCurrency         = NewAPIError(http.StatusBadRequest, "LOCATION_CURRENCY_UNSUPPORTED", "Pickup location does not support the loan currency.")
+	ErrLocationLimitReached     = NewAPIError(http.StatusConflict, "LOCATION_LIMIT_REACHED", "Pickup location has reached its daily limit.")
+	ErrPickupCodeUnavailable    = errors.New("Could not issue a pickup code.")
+	ErrBadLocationSearchRequest = NewAPIError(http.StatusBadRequest, "INVALID_LOCATION_SEARCH", "Location search parameters were invalid.")
+)
+
+// Location is a remittance center or pickup point where borrowers can collect cash.
+type Location struct {
+	LocationId string   `json:"id"`
+	PartnerId  string   `json:"partnerId"`
+	Name       string   `json:"name"`
+	Address    string   `json:"address"`
+	City       string   `json:"city,omitempty"`
+	Province   string   `json:"province"`
+	Latitude   float64  `json:"latitude"`
+	Longitude  float64  `json:"longitude"`
+	Hours      string   `json:"hours,omitempty"` // e.g. "Mon-Sat 09:00-18:00"
+	Currencies []string `json:"currencies"`
+	DailyLimit float64  `json:"dailyLimit"` // 0 means unlimited
+}
+
+type LocationResult struct {
+	*Location
+	DistanceKm *float64 `json:"distanceKm,omitempty"`
+}
+
+type LocationSearchResponse struct {
+	Locations []LocationResult `json:"locations"`
+}
+
+// Running total of cash handed out at a location on one day.
+type LocationUsage struct {
+	LocationId string
+	Date       string
+	Total      float64
+}
+
+// Reserves a pickup code so that no two loans share one.
+type PickupCodeRecord struct {
+	Uid        string
+	LoanId     string
+	LocationId string
+	Created    int64
+}
+
+var locationDirectory = struct {
+	sync.RWMutex
+	locations map[string]*Location
+}{locations: make(map[string]*Location)}
+
+func (location *Location) SupportsCurrency(currencyCode string) bool {
+	for _, supported := range location.Currencies {
+		if supported == currencyCode {
+			return true
+		}
+	}
+	return false
+}
+
+// Upserts the locations in the given file. A missing file is not an error.
+func ImportLocations(dbClient *datastore.Client, path string) error {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var locations []*Location
+	if err := json.Unmarshal(bytes, &locations); err != nil {
+		return err
+	}
+
+	keys := make([]*datastore.Key, len(locations))
+	for i, location := range locations {
+		if location.LocationId == "" {
+			return errors.New("Location without an ID in " + path)
+		}
+		keys[i] = datastore.NameKey(kLocationKind, location.LocationId, nil)
+	}
+
+	_, err = dbClient.PutMulti(context.Background(), keys, locations)
+	return err
+}
+
+func RefreshLocations(dbClient *datastore.Client) error {
+	var locations []*Location
+	_, err := dbClient.GetAll(context.Background(), datastore.NewQuery(kLocationKind), &locations)
+	if err != nil {
+		return err
+	}
+
+	directory := make(map[string]*Location)
+	for _, location := range locations {
+		directory[location.LocationId] = location
+	}
+
+	locationDirectory.Lock()
+	locationDirectory.locations = directory
+	locationDirectory.Unlock()
+	return nil
+}
+
+// Imports the locations file and keeps the in-memory directory fresh.
+func ManageLocations(ctx context.Context) {
+	dbClient := GetDbClient()
+	if err := ImportLocations(dbClient, config.LocationsFile); err != nil {
+		logger.Error("Failed to import locations", "err", err)
+	}
+	returnDbClient <- dbClient
+
+	for true {
+		dbClient := GetDbClient()
+		err := RefreshLocations(dbClient)
+		returnDbClient <- dbClient
+
+		if err != nil {
+			logger.Error("Failed to refresh locations", "err", err)
+		}
+
+		if !SleepUntilDone(ctx, kLocationRefreshInterval) {
+			return
+		}
+	}
+}
+
+func LocationForId(locationId string) (*Location, error) {
+	locationDirectory.RLock()
+	defer locationDirectory.RUnlock()
+
+	location, ok := locationDirectory.locations[locationId]
+	if !ok {
+		return nil, ErrInvalidLocation
+	}
+	return location, nil
+}
+
+// Resolves a borrower's choice against the directory. Older clients only send a name, which must match exactly.
+func LocationForSelection(pickupLocation PickupLocation) (*Location, error) {
+	if pickupLocation.LocationId != "" {
+		return LocationForId(pickupLocation.LocationId)
+	}
+
+	locationDirectory.RLock()
+	defer locationDirectory.RUnlock()
+
+	for _, location := range locationDirectory.locations {
+		if location.Name == pickupLocation.LocationName {
+			return location, nil
+		}
+	}
+	return nil, ErrInvalidLocation
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180.0
+}
+
+// Great-circle distance between two points using the haversine formula.
+func DistanceKm(lat1 float64, lng1 float64, lat2 float64, lng2 float64) float64 {
+	dLat := radians(lat2 - lat1)
+	dLng := radians(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(radians(lat1))*math.Cos(radians(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return kEarthRadiusKm * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// Reserves amount against the location's daily limit as part of tx.
+func ReserveLocationCapacity(tx *datastore.Transaction, location *Location, amount float64, now time.Time) error {
+	if location.DailyLimit <= 0.0 {
+		return nil
+	}
+
+	date := now.Format("2006-01-02")
+	usageKey := datastore.NameKey(kLocationUsageKind, location.LocationId+"-"+date, nil)
+
+	var usage LocationUsage
+	get_err := tx.Get(usageKey, &usage)
+	if get_err != nil && get_err != datastore.ErrNoSuchEntity {
+		return get_err
+	}
+
+	if usage.Total+amount > location.DailyLimit {
+		return ErrLocationLimitReached
+	}
+
+	usage.LocationId = location.LocationId
+	usage.Date = date
+	usage.Total += amount
+
+	_, put_err := tx.Put(usageKey, &usage)
+	return put_err
+}
+
+func randomPickupCode() (string, error) {
+	max := big.NewInt(int64(math.Pow10(kPickupCodeDigits)))
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", kPickupCodeDigits, n.Int64()), nil
+}
+
+// Issues a pickup code that no other loan has, as part of tx.
+func IssuePickupCode(tx *datastore.Transaction, uid string, loanId string, locationId string) (string, error) {
+	for i := 0; i < kPickupCodeAttempts; i++ {
+		code, err := randomPickupCode()
+		if err != nil {
+			return "", err
+		}
+
+		codeKey := datastore.NameKey(kPickupCodeKind, code, nil)
+		var existing PickupCodeRecord
+		get_err := tx.Get(codeKey, &existing)
+		if get_err == nil {
+			continue
+		} else if get_err != datastore.ErrNoSuchEntity {
+			return "", get_err
+		}
+
+		record := PickupCodeRecord{Uid: uid, LoanId: loanId, LocationId: locationId, Created: time.Now().Unix() * 1000}
+		if _, put_err := tx.Put(codeKey, &record); put_err != nil {
+			return "", put_err
+		}
+		return code, nil
+	}
+	return "", ErrPickupCodeUnavailable
+}
+
+func parseOptionalFloat(value string) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// GET /locations?province=&partnerId=&currency=&lat=&lng=&radiusKm=
+func GetLocations(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	province := query.Get("province")
+	partnerId := query.Get("partnerId")
+	currency := query.Get("currency")
+
+	lat, lat_err := parseOptionalFloat(query.Get("lat"))
+	lng, lng_err := parseOptionalFloat(query.Get("lng"))
+	radius, radius_err := parseOptionalFloat(query.Get("radiusKm"))
+
+	if lat_err != nil || lng_err != nil || radius_err != nil || (lat == nil) != (lng == nil) || (radius != nil && lat == nil) {
+		WriteError(w, r, ErrBadLocationSearchRequest)
+		return
+	}
+
+	var response LocationSearchResponse
+	response.Locations = make([]LocationResult, 0)
+
+	locationDirectory.RLock()
+	for _, location := range locationDirectory.locations {
+		if province != "" && !strings.EqualFold(location.Province, province) {
+			continue
+		}
+		if partnerId != "" && location.PartnerId != partnerId {
+			continue
+		}
+		if currency != "" && !location.SupportsCurrency(currency) {
+			continue
+		}
+
+		result := LocationResult{Location: location}
+		if lat != nil {
+			distance := DistanceKm(*lat, *lng, location.Latitude, location.Longitude)
+			if radius != nil && distance > *radius {
+				continue
+			}
+			result.DistanceKm = &distance
+		}
+		response.Locations = append(response.Locations, result)
+	}
+	locationDirectory.RUnlock()
+
+	// Nearest first when searching by distance, otherwise alphabetical.
+	sort.Slice(response.Locations, func(i, j int) bool {
+		a, b := response.Locations[i], response.Locations[j]
+		if a.DistanceKm != nil && b.DistanceKm != nil && *a.DistanceKm != *b.DistanceKm {
+			return *a.DistanceKm < *b.DistanceKm
+		}
+		return a.Name < b.Name
+	})
+
+	json.NewEncoder(w).Encode(response)
+}
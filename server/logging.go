@@ -0,0 +1,219 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Logs are structured and leveled, as text in the local profile and JSON elsewhere. Every API request has an ID,
+// kept from X-Request-Id if a proxy set a sane one, which is logged with every line about the request, returned
+// in error bodies and passed on to Horizon and partner anchors. Attributes that identify a person are redacted.
+
+const (
+	kLogFormatText string = "text"
+	kLogFormatJson string = "json"
+)
+
+const kRedacted string = "[redacted]"
+
+// Attribute keys whose values are never logged.
+var redactedKeys = map[string]bool{
+	"firstName":      true,
+	"lastName":       true,
+	"phoneNumber":    true,
+	"dateOfBirth":    true,
+	"email":          true,
+	"stellarAddress": true,
+	"residenceAddr1": true,
+	"residenceAddr2": true,
+	"token":          true,
+}
+
+var logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+func redact(groups []string, attr slog.Attr) slog.Attr {
+	if redactedKeys[attr.Key] {
+		return slog.String(attr.Key, kRedacted)
+	}
+	return attr
+}
+
+// Replaces the default logger with one for the configured format and level.
+func SetupLogging(format string, level slog.Level) {
+	options := &slog.HandlerOptions{Level: level, ReplaceAttr: redact}
+	if format == kLogFormatJson {
+		logger = slog.New(slog.NewJSONHandler(os.Stdout, options))
+	} else {
+		logger = slog.New(slog.NewTextHandler(os.Stdout, options))
+	}
+}
+
+// Logs the parts of a user that don't identify them.
+func (user User) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.Float64("qinBalance", user.QinBalance),
+		slog.Bool("stellarLinked", user.StellarAddress != ""),
+	}
+	if user.EmploymentInfo != nil {
+		attrs = append(attrs, slog.String("employmentStatus", user.EmploymentStatus))
+	}
+	if user.ResidenceInfo != nil {
+		attrs = append(attrs, slog.String("residenceCity", user.ResidenceCity))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// What is known about the request being served; the user is filled in once authenticated.
+type requestInfo struct {
+	id  string
+	uid string
+}
+
+type requestInfoKey struct{}
+
+func requestInfoFrom(ctx context.Context) *requestInfo {
+	info, _ := ctx.Value(requestInfoKey{}).(*requestInfo)
+	return info
+}
+
+func withRequestInfo(ctx context.Context, info *requestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+func newRequestId() string {
+	var random [16]byte
+	rand.Read(random[:])
+	return hex.EncodeToString(random[:])
+}
+
+// The ID of the request ctx belongs to, or "" for background work.
+func RequestIdFrom(ctx context.Context) string {
+	if info := requestInfoFrom(ctx); info != nil {
+		return info.id
+	}
+	return ""
+}
+
+// Records the authenticated user of the request, for its log lines.
+func SetRequestUser(ctx context.Context, uid string) {
+	if info := requestInfoFrom(ctx); info != nil {
+		info.uid = uid
+	}
+}
+
+// The logger for work done for the request ctx belongs to, tagged with its ID, user and trace.
+func Logger(ctx context.Context) *slog.Logger {
+	info := requestInfoFrom(ctx)
+	if info == nil {
+		return logger
+	}
+
+	args := []interface{}{"requestId", info.id}
+	if info.uid != "" {
+		args = append(args, "uid", info.uid)
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		args = append(args, "traceId", spanContext.TraceID().String())
+	}
+	return logger.With(args...)
+}
+
+// A context with the values of its parent but none of its deadline or cancelation.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+func (c detachedContext) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
+}
+
+// The context to run domain functions with. It carries the request's ID and user but is not canceled when the
+// client goes away, so that a payout or on-chain transaction is never abandoned halfway.
+func DetachContext(ctx context.Context) context.Context {
+	return detachedContext{parent: ctx}
+}
+
+func RequestContext(r *http.Request) context.Context {
+	return DetachContext(r.Context())
+}
+
+// Tags a call to another service with the request and trace it is made for, so that both sides' logs and spans
+// can be matched.
+func SetOutgoingRequestId(ctx context.Context, req *http.Request) {
+	if id := RequestIdFrom(ctx); id != "" {
+		req.Header.Set(kRequestIdHeader, id)
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+}
+
+// Tags every call of a client whose methods don't take a context, such as Horizon's, with one request ID.
+type requestIdTransport struct {
+	requestId string
+}
+
+func (t requestIdTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.requestId != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set(kRequestIdHeader, t.requestId)
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// The route template of a matched request, e.g. /v2/loans/{id}.
+func routeTemplate(r *http.Request) string {
+	if current := mux.CurrentRoute(r); current != nil {
+		if template, err := current.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+	return "unknown"
+}
+
+// Router middleware giving every request an ID and logging it once served.
+func LogRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		info := &requestInfo{id: RequestId(w, r)}
+		r = r.WithContext(withRequestInfo(r.Context(), info))
+
+		writer := &statusWriter{ResponseWriter: w}
+		next.ServeHTTP(writer, r)
+
+		if writer.status == 0 {
+			writer.status = http.StatusOK
+		}
+		level := slog.LevelInfo
+		if writer.status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		Logger(r.Context()).LogAttrs(r.Context(), level, "request",
+			slog.String("method", r.Method),
+			slog.String("route", routeTemplate(r)),
+			slog.Int("status", writer.status),
+			slog.Duration("latency", time.Since(start)),
+		)
+	})
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"cloud.google.com/go/datastore"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Prometheus metrics are served at /metrics on -metrics-addr, a plain HTTP port that should only be reachable
+// by the scraper. The loan book gauges are recomputed from Datastore every kLoanBookMetricsInterval rather than
+// on every scrape.
+
+const kLoanBookMetricsInterval time.Duration = time.Minute
+
+// Results of verifying a Firebase token.
+const (
+	kAuthResultOk               string = "ok"
+	kAuthResultInvalidToken     string = "invalid_token"
+	kAuthResultLookupFailed     string = "lookup_failed"
+	kAuthResultDisabled         string = "disabled"
+	kAuthResultEmailNotVerified string = "email_not_verified"
+)
+
+var (
+	httpRequests = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "sbc_http_request_duration_seconds",
+		Help: "Time to serve HTTP requests, by route template, method and status.",
+	}, []string{"route", "method", "status"})
+
+	authVerification = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "sbc_auth_verification_duration_seconds",
+		Help: "Time to verify a Firebase token and look up its user, by result.",
+	}, []string{"result"})
+
+	dbPoolWait = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "sbc_db_pool_wait_seconds",
+		Help:    "Time spent waiting for a Datastore client from the pool.",
+		Buckets: []float64{0.0001, 0.001, 0.01, 0.05, 0.1, 0.5, 1, 5},
+	})
+
+	eraDecisions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sbc_era_decisions_total",
+		Help: "Loan requests assessed by each ERA, by decision: offered or rejected.",
+	}, []string{"era", "decision"})
+
+	eraInterestRates = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "sbc_era_offered_interest_rate",
+		Help:    "Interest rates offered by each ERA; sum over count is the average.",
+		Buckets: prometheus.LinearBuckets(0.01, 0.01, 10),
+	}, []string{"era"})
+
+	disbursements = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sbc_disbursements_total",
+		Help: "Payouts through cash-out partners, by partner and result: started, sent, unknown, completed or failed.",
+	}, []string{"partner", "result"})
+
+	loansByState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sbc_loans",
+		Help: "Loans by state.",
+	}, []string{"state"})
+
+	outstandingPrincipal = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sbc_outstanding_principal",
+		Help: "Principal of sent loans not yet repaid, by currency.",
+	}, []string{"currency"})
+
+	qinCollateralLocked = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "sbc_qin_collateral_locked",
+		Help: "QIN held as collateral for sent loans.",
+	})
+
+	rateLimited = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sbc_rate_limited_total",
+		Help: "Requests turned away by rate limits, by scope (ip or user) and route template.",
+	}, []string{"scope", "route"})
+
+	dependencyUp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sbc_dependency_up",
+		Help: "Whether the last health check reached a dependency: datastore, firebase, horizon or signer.",
+	}, []string{"dependency"})
+
+	loanReviewsHeld = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sbc_loan_reviews_held_total",
+		Help: "Loan applications held for review, by each rule they matched.",
+	}, []string{"reason"})
+
+	loanReviewDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "sbc_loan_review_duration_seconds",
+		Help:    "Time from application to decision of held loans, by status: APPROVED, REJECTED or CANCELED.",
+		Buckets: prometheus.ExponentialBuckets(60, 4, 8),
+	}, []string{"status"})
+
+	loanReviewsOpen = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sbc_loan_reviews",
+		Help: "Undecided loan reviews, by status: OPEN or CLAIMED.",
+	}, []string{"status"})
+
+	loanReviewsOverdue = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "sbc_loan_reviews_overdue",
+		Help: "Undecided loan reviews past their SLA.",
+	})
+)
+
+// Records the status of a response while passing it through. Streaming handlers can still flush it.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *statusWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Router middleware timing every matched request by its route template, e.g. /v2/loans/{id}.
+func InstrumentRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := routeTemplate(r)
+
+		start := time.Now()
+		writer := &statusWriter{ResponseWriter: w}
+		next.ServeHTTP(writer, r)
+
+		if writer.status == 0 {
+			writer.status = http.StatusOK
+		}
+		httpRequests.WithLabelValues(route, r.Method, strconv.Itoa(writer.status)).Observe(time.Since(start).Seconds())
+	})
+}
+
+// Counts an ERA's decision on a loan request; terms is nil if it rejected the borrower.
+func ObserveEraDecision(era string, terms *ERATerms) {
+	if terms == nil {
+		eraDecisions.WithLabelValues(era, "rejected").Inc()
+		return
+	}
+	eraDecisions.WithLabelValues(era, "offered").Inc()
+	eraInterestRates.WithLabelValues(era).Observe(terms.interest_rate)
+}
+
+// Counts a payout reaching a new status. transfer may be nil if it failed before the partner knew of it.
+func ObserveDisbursement(partnerId string, transfer *AnchorTransfer, err error) {
+	result := "started"
+	switch {
+	case err != nil || transfer == nil || transfer.Status == kTransferFailed || transfer.Status == kAnchorStatusError:
+		result = "failed"
+	case transfer.Status == kTransferSubmitted:
+		result = "sent"
+	case transfer.Status == kTransferUnknown:
+		result = "unknown"
+	case transfer.Status == kAnchorStatusCompleted:
+		result = "completed"
+	}
+	disbursements.WithLabelValues(partnerId, result).Inc()
+}
+
+func ObserveReviewHeld(review *LoanReview) {
+	for _, reason := range review.Reasons {
+		loanReviewsHeld.WithLabelValues(reason).Inc()
+	}
+}
+
+func ObserveReviewDecision(review *LoanReview) {
+	loanReviewDuration.WithLabelValues(review.Status).Observe(float64(review.Decided-review.Entered) / 1000.0)
+}
+
+// Recomputes the loan book gauges from every user's loan summary, the loans that are out and the undecided
+// reviews.
+func UpdateLoanBookMetrics(ctx context.Context, dbClient *datastore.Client) error {
+	var summaries []LoanSummary
+	if _, err := dbClient.GetAll(ctx, datastore.NewQuery(kLoanSummaryKind), &summaries); err != nil {
+		return err
+	}
+
+	var sentLoans []LoanRecord
+	if _, err := dbClient.GetAll(ctx, datastore.NewQuery(kLoanKind).Filter("State =", "SENT"), &sentLoans); err != nil {
+		return err
+	}
+
+	now := nowMillis()
+	overdue := 0
+	for _, status := range []string{kReviewOpen, kReviewClaimed} {
+		var reviews []LoanReview
+		if _, err := dbClient.GetAll(ctx, datastore.NewQuery(kLoanReviewKind).Filter("Status =", status), &reviews); err != nil {
+			return err
+		}
+		loanReviewsOpen.WithLabelValues(status).Set(float64(len(reviews)))
+		for i := range reviews {
+			if reviews[i].IsOverdue(now) {
+				overdue++
+			}
+		}
+	}
+	loanReviewsOverdue.Set(float64(overdue))
+
+	counts := make(map[string]int64)
+	for _, summary := range summaries {
+		for _, stateCount := range summary.StateCounts {
+			counts[stateCount.State] += stateCount.Count
+		}
+	}
+
+	principal := make(map[string]float64)
+	collateral := 0.0
+	for _, loanRecord := range sentLoans {
+		owed := loanRecord.Amount
+		for _, repayment := range loanRecord.Repayments {
+			owed -= repayment.Amount
+		}
+		principal[loanRecord.CurrencyCode] += owed
+
+		// On-chain collateral only counts once the lock has gone through.
+		if loanRecord.AcceptedTerms != nil && (!config.QinOnChain || loanRecord.CollateralState == kCollateralLocked) {
+			collateral += loanRecord.AcceptedTerms.QinRequired
+		}
+	}
+
+	// Reset so that states and currencies with nothing left drop to zero rather than keep their last value.
+	loansByState.Reset()
+	for state, count := range counts {
+		loansByState.WithLabelValues(state).Set(float64(count))
+	}
+	outstandingPrincipal.Reset()
+	for currencyCode, owed := range principal {
+		outstandingPrincipal.WithLabelValues(currencyCode).Set(owed)
+	}
+	qinCollateralLocked.Set(collateral)
+	return nil
+}
+
+func ManageLoanBookMetrics(ctx context.Context) {
+	for true {
+		dbClient := GetDbClient()
+		err := UpdateLoanBookMetrics(ctx, dbClient)
+		returnDbClient <- dbClient
+
+		if err != nil && ctx.Err() == nil {
+			logger.Error("Failed to update loan book metrics", "err", err)
+		}
+
+		if !SleepUntilDone(ctx, kLoanBookMetricsInterval) {
+			return
+		}
+	}
+}
+
+// The server for /metrics, on its own port.
+func NewMetricsServer(addr string) *http.Server {
+	handler := http.NewServeMux()
+	handler.Handle("/metrics", promhttp.Handler())
+	return &http.Server{Addr: addr, Handler: handler}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"golang.org/x/net/context"
+)
+
+// Every route runs behind the router's middleware: tracing, logging, metrics, panic recovery, CORS and
+// security headers, then the per IP rate limits. Routes for signed in users wrap their handler in RequireUser
+// or RequireVerifiedUser, which also apply the per user rate limits, and admin routes in RequireRole, so
+// handlers hold only their own logic and read the user with RequestUid.
+
+const kHstsValue string = "max-age=63072000; includeSubDomains"
+
+type authContextKey struct{}
+
+// Sets the CORS headers for origins matching -allowed-origins.
+func AllowOrigins(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if config.allowedOrigins.MatchString(origin) {
+			w.Header().Add("Access-Control-Allow-Origin", origin)
+			w.Header().Add("Access-Control-Allow-Credentials", "true") // Allow cookies to be used in requests
+			w.Header().Add("Access-Control-Expose-Headers", kRequestIdHeader+", "+kIdempotencyReplayedHeader)
+			w.Header().Add("Vary", "Origin") // use cached response based on origin
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Answers CORS preflight requests for every route; AllowOrigins decides whether the origin may go ahead.
+func HandleOptions(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+	w.Header().Add("Access-Control-Allow-Headers", "Content-type, X-firebase-token, Idempotency-Key, Last-Event-ID, X-Request-Id")
+}
+
+// Responses are JSON unless the handler sets another type, which nosniff makes browsers hold it to.
+func SecureHeaders(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Add("Strict-Transport-Security", kHstsValue)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("X-Frame-Options", "DENY")
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Turns a panicking handler into an INTERNAL error for the client, logged with its stack, instead of a dropped
+// connection.
+func RecoverPanics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writer := &statusWriter{ResponseWriter: w}
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			// Used by handlers to abort a response on purpose.
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+
+			Logger(r.Context()).Error("Handler panicked", "stack", string(debug.Stack()))
+			if writer.status == 0 {
+				WriteError(writer, r, fmt.Errorf("panic: %v", recovered))
+			}
+		}()
+
+		next.ServeHTTP(writer, r)
+	})
+}
+
+func requireAuth(handler http.HandlerFunc, requireEmailVerification bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		authResponse, err := DoAuth(r, requireEmailVerification)
+		if err != nil {
+			WriteError(w, r, err)
+			return
+		}
+
+		if !TakeRateLimit(w, r, kRateLimitScopeUser, authResponse.UserInfo.UID) {
+			return
+		}
+
+		handler(w, r.WithContext(context.WithValue(r.Context(), authContextKey{}, authResponse)))
+	}
+}
+
+// Turns away requests without a valid Firebase token. Users who have not verified their email yet get through,
+// e.g. to create their profile.
+func RequireUser(handler http.HandlerFunc) http.HandlerFunc {
+	return requireAuth(handler, false)
+}
+
+// Turns away requests without a valid Firebase token of a user with a verified email.
+func RequireVerifiedUser(handler http.HandlerFunc) http.HandlerFunc {
+	return requireAuth(handler, true)
+}
+
+// The user a request was authenticated as, or "" outside RequireUser and RequireVerifiedUser.
+func RequestUid(r *http.Request) string {
+	authResponse, _ := r.Context().Value(authContextKey{}).(FirebaseAuthResponse)
+	return authResponse.UserInfo.UID
+}
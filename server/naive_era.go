@@ -8,13 +8,13 @@ func (NaiveERA) predictProbDefault(borrower_app BorrowerApp) float64 {
 }
 
 func (NaiveERA) predictInterestRate(prob_default float64) float64 {
-	return prob_default * MAX_INTEREST_RATE // uses linear scaling
+	return prob_default * config.Era.MaxInterestRate // uses linear scaling
 }
 
 func (NaiveERA) computeQinCollateral(prob_default float64, num_successful_loans uint64) float64 {
 	// The collateral is a linear scaling between the max and min collateral wrt probability of default
 	// and based on the number of successful loans they have had, they need to post less collateral
-	return prob_default * MAX_QIN_COLLATERAL * (1.0 / (float64(num_successful_loans) + 1.0))
+	return prob_default * config.Era.MaxQinCollateral * (1.0 / (float64(num_successful_loans) + 1.0))
 }
 
 func (NaiveERA) computeQinReward(prob_default float64, interest_reward float64) float64 {
@@ -24,5 +24,5 @@ func (NaiveERA) computeQinReward(prob_default float64, interest_reward float64)
 }
 
 func (NaiveERA) rejectBorrower(prob_default float64) bool {
-	return prob_default > 1.0 // naive never rejects
+	return prob_default > config.Era.Naive.RejectAbove // naive never rejects
 }
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+)
+
+// The API is described in openapi.json, which the generated client in client/ is built from and the server's
+// responses are tested against. Keep it in step with the handlers.
+
+var openApiDocument []byte
+
+func LoadOpenApi(path string) error {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	// Fail at startup rather than serving a broken document.
+	var document map[string]interface{}
+	if err := json.Unmarshal(bytes, &document); err != nil {
+		return err
+	}
+
+	openApiDocument = bytes
+	return nil
+}
+
+// GET /openapi.json
+func GetOpenApi(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openApiDocument)
+}
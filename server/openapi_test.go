@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+// Serves the router and checks its responses, errors included, against openapi.json. Only routes that answer
+// without Datastore are exercised.
+func TestResponsesMatchOpenApi(t *testing.T) {
+	srv := httptest.NewServer(NewRouter())
+	defer srv.Close()
+
+	doc, err := openapi3.NewLoader().LoadFromData(openApiDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	doc.Servers = openapi3.Servers{{URL: srv.URL}}
+	specRouter, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path   string
+		token  string
+		status int
+	}{
+		{"/openapi.json", "", http.StatusOK},
+		{"/hc/live", "", http.StatusOK},
+		{"/v1/user", "", http.StatusBadRequest},
+		{"/v1/user", "not-a-token", http.StatusUnauthorized},
+		{"/v1/loans", kTestUnverifiedToken, http.StatusBadRequest},
+		{"/v1/partners", kTestVerifiedToken, http.StatusOK},
+		{"/v1/locations?lat=14.5995", kTestVerifiedToken, http.StatusBadRequest},
+		{"/v2/partners", kTestVerifiedToken, http.StatusOK},
+		{"/v2/loans?limit=0", kTestVerifiedToken, http.StatusBadRequest},
+		{"/v2/loans?cursor=%25", kTestVerifiedToken, http.StatusBadRequest},
+		{"/v2/loans?sort=amount", kTestVerifiedToken, http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, srv.URL+test.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.token != "" {
+				req.Header.Set("X-firebase-token", test.token)
+			}
+
+			route, pathParams, err := specRouter.FindRoute(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := srv.Client().Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.StatusCode != test.status {
+				t.Fatalf("got %d %s, want %d", resp.StatusCode, body, test.status)
+			}
+
+			options := &openapi3filter.Options{
+				IncludeResponseStatus: true,
+				AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+			}
+			err = openapi3filter.ValidateResponse(context.Background(), &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: &openapi3filter.RequestValidationInput{
+					Request:    req,
+					PathParams: pathParams,
+					Route:      route,
+					Options:    options,
+				},
+				Status:  resp.StatusCode,
+				Header:  resp.Header,
+				Body:    ioutil.NopCloser(bytes.NewReader(body)),
+				Options: options,
+			})
+			if err != nil {
+				t.Errorf("%d %s: %v", resp.StatusCode, body, err)
+			}
+		})
+	}
+}
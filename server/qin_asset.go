@@ -0,0 +1,602 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"cloud.google.com/go/datastore"
+	b "github.com/stellar/go/build"
+	"github.com/stellar/go/clients/horizon"
+	"github.com/stellar/go/keypair"
+
+	"github.com/OneDaijo/sbc-demo-backend/stellarutil"
+)
+
+// When QIN is issued on-chain, the server account is the issuer and collateral is held in a separate
+// escrow account whose only signer is the server account (master weight 0). Borrowers link an account
+// that trusts QIN and lists the server account as a signer so collateral can be moved on their behalf.
+
+const kQinAssetCode string = "QIN"
+const kQinReconcileInterval time.Duration = 5 * time.Minute
+
+// Collateral states recorded on a loan when QIN is on-chain.
+const (
+	kCollateralPendingLock string = "PENDING_LOCK"
+	kCollateralLocked      string = "LOCKED"
+	kCollateralReleased    string = "RELEASED"
+	kCollateralForfeited   string = "FORFEITED"
+)
+
+var (
+	ErrStellarAddressInvalid    = NewAPIError(http.StatusBadRequest, "STELLAR_ADDRESS_INVALID", "Stellar address is not a valid account ID.")
+	ErrStellarAccountNotLinked  = NewAPIError(http.StatusBadRequest, "STELLAR_ACCOUNT_NOT_LINKED", "A Stellar account must be linked before QIN collateral can be locked.")
+	ErrStellarAccountNotReady   = NewAPIError(http.StatusBadRequest, "STELLAR_ACCOUNT_NOT_READY", "Stellar account must trust QIN and list the server account as a signer.")
+	ErrStellarAccountNotFound   = NewAPIError(http.StatusNotFound, "STELLAR_ACCOUNT_NOT_FOUND", "Stellar account was not found on the network.")
+	ErrQinEscrowNotConfigured   = errors.New("QIN escrow account is not configured.")
+	ErrQinCollateralUnsupported = errors.New("Collateral state is not recognized.")
+	ErrCollateralSettling       = errors.New("QIN is being settled by another request.")
+)
+
+// An on-chain collateral transition, signed and saved on the loan before it is submitted. Until it is known to
+// have been applied or turned away, the same transaction is submitted again rather than a new one built, so
+// the collateral moves once however many times settlement runs, on whichever replica.
+type CollateralIntent struct {
+	Next     string // Collateral state once the transaction is applied
+	TxHash   string
+	Envelope string `datastore:",noindex"`
+}
+
+// QIN a user earned off-chain before linking a Stellar account, being minted to the account the same way.
+type QinMigration struct {
+	Amount   float64
+	TxHash   string
+	Envelope string `datastore:",noindex"`
+}
+
+const kQinDiscrepancyKind string = "qinDiscrepancy"
+
+// A user whose off-chain QIN balance disagrees with their linked account's, found by ReconcileQinBalance.
+type QinDiscrepancy struct {
+	Uid            string  `json:"uid"`
+	StellarAddress string  `json:"stellarAddress" datastore:",noindex"`
+	OffChain       float64 `json:"offChain" datastore:",noindex"`
+	OnChain        float64 `json:"onChain" datastore:",noindex"`
+	FirstFound     int64   `json:"firstFound"` // Unix milliseconds
+	LastFound      int64   `json:"lastFound" datastore:",noindex"`
+}
+
+func QinDiscrepancyKey(uid string) *datastore.Key {
+	return datastore.NameKey(kQinDiscrepancyKind, uid, UserKey(uid))
+}
+
+// A Horizon client whose calls carry the ID of the request ctx belongs to.
+func NewHorizonClient(ctx context.Context) *horizon.Client {
+	return &horizon.Client{
+		URL: config.network.HorizonURL,
+		HTTP: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: requestIdTransport{requestId: RequestIdFrom(ctx)},
+		},
+	}
+}
+
+func ServerAddress() (string, error) {
+	if serverSigner == nil {
+		return "", ErrSignerNotConfigured
+	}
+	return serverSigner.Address(), nil
+}
+
+func FormatQinAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 7, 64)
+}
+
+// Checks that a borrower's account exists, trusts QIN from the server account and lets the server sign for it.
+func VerifyStellarAccountForQin(ctx context.Context, address string) error {
+	if _, err := keypair.Parse(address); err != nil || address[0] != 'G' {
+		return ErrStellarAddressInvalid
+	}
+
+	if !config.QinOnChain {
+		return nil
+	}
+
+	issuer, err := ServerAddress()
+	if err != nil {
+		return err
+	}
+
+	account, err := NewHorizonClient(ctx).LoadAccount(address)
+	if err != nil {
+		if herr, isHorizonError := err.(*horizon.Error); isHorizonError && herr.Problem.Status == http.StatusNotFound {
+			return ErrStellarAccountNotFound
+		}
+		return err
+	}
+
+	hasTrustline := false
+	for _, balance := range account.Balances {
+		if balance.Asset.Code == kQinAssetCode && balance.Asset.Issuer == issuer {
+			hasTrustline = true
+		}
+	}
+
+	hasSigner := false
+	for _, signer := range account.Signers {
+		if signer.PublicKey == issuer && signer.Weight >= int32(account.Thresholds.MedThreshold) {
+			hasSigner = true
+		}
+	}
+
+	if !hasTrustline || !hasSigner {
+		return ErrStellarAccountNotReady
+	}
+
+	return nil
+}
+
+// Reads the QIN balance of an account from Horizon.
+func OnChainQinBalance(hc *horizon.Client, address string) (float64, error) {
+	issuer, err := ServerAddress()
+	if err != nil {
+		return 0, err
+	}
+
+	account, err := hc.LoadAccount(address)
+	if err != nil {
+		return 0, err
+	}
+
+	balance := account.GetCreditBalance(kQinAssetCode, issuer)
+	if balance == "" {
+		return 0, ErrStellarAccountNotReady
+	}
+
+	return strconv.ParseFloat(balance, 64)
+}
+
+// Moves collateral from the borrower's account into escrow. The server account pays the fee.
+func QinLockTransaction(hc *horizon.Client, borrowerAddress string, amount float64) (*b.TransactionBuilder, error) {
+	if config.QinEscrow == "" {
+		return nil, ErrQinEscrowNotConfigured
+	}
+
+	issuer, err := ServerAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Transaction(
+		b.SourceAccount{AddressOrSeed: issuer},
+		config.network.Mutator(),
+		b.AutoSequence{SequenceProvider: hc},
+		b.Payment(
+			b.SourceAccount{AddressOrSeed: borrowerAddress},
+			b.Destination{AddressOrSeed: config.QinEscrow},
+			b.CreditAmount{Code: kQinAssetCode, Issuer: issuer, Amount: FormatQinAmount(amount)},
+		),
+		b.MemoText{Value: "qin collateral lock"},
+	)
+}
+
+// Returns collateral from escrow and issues the repayment reward, or nil if there is neither.
+func QinReleaseTransaction(hc *horizon.Client, borrowerAddress string, collateral float64, reward float64) (*b.TransactionBuilder, error) {
+	if config.QinEscrow == "" {
+		return nil, ErrQinEscrowNotConfigured
+	}
+
+	if collateral <= 0.0 && reward <= 0.0 {
+		return nil, nil
+	}
+
+	issuer, err := ServerAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	muts := []b.TransactionMutator{
+		b.SourceAccount{AddressOrSeed: issuer},
+		config.network.Mutator(),
+		b.AutoSequence{SequenceProvider: hc},
+		b.MemoText{Value: "qin collateral release"},
+	}
+
+	if collateral > 0.0 {
+		muts = append(muts, b.Payment(
+			b.SourceAccount{AddressOrSeed: config.QinEscrow},
+			b.Destination{AddressOrSeed: borrowerAddress},
+			b.CreditAmount{Code: kQinAssetCode, Issuer: issuer, Amount: FormatQinAmount(collateral)},
+		))
+	}
+
+	// Payments from the issuer mint new QIN.
+	if reward > 0.0 {
+		muts = append(muts, b.Payment(
+			b.Destination{AddressOrSeed: borrowerAddress},
+			b.CreditAmount{Code: kQinAssetCode, Issuer: issuer, Amount: FormatQinAmount(reward)},
+		))
+	}
+
+	return b.Transaction(muts...)
+}
+
+// Sends forfeited collateral from escrow back to the issuer, which burns it.
+func QinForfeitTransaction(hc *horizon.Client, amount float64) (*b.TransactionBuilder, error) {
+	if config.QinEscrow == "" {
+		return nil, ErrQinEscrowNotConfigured
+	}
+
+	issuer, err := ServerAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Transaction(
+		b.SourceAccount{AddressOrSeed: issuer},
+		config.network.Mutator(),
+		b.AutoSequence{SequenceProvider: hc},
+		b.Payment(
+			b.SourceAccount{AddressOrSeed: config.QinEscrow},
+			b.Destination{AddressOrSeed: issuer},
+			b.CreditAmount{Code: kQinAssetCode, Issuer: issuer, Amount: FormatQinAmount(amount)},
+		),
+		b.MemoText{Value: "qin collateral forfeit"},
+	)
+}
+
+// Mints QIN earned off-chain to a newly linked account.
+func QinMigrationTransaction(hc *horizon.Client, borrowerAddress string, amount float64) (*b.TransactionBuilder, error) {
+	issuer, err := ServerAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Transaction(
+		b.SourceAccount{AddressOrSeed: issuer},
+		config.network.Mutator(),
+		b.AutoSequence{SequenceProvider: hc},
+		b.Payment(
+			b.Destination{AddressOrSeed: borrowerAddress},
+			b.CreditAmount{Code: kQinAssetCode, Issuer: issuer, Amount: FormatQinAmount(amount)},
+		),
+		b.MemoText{Value: "qin balance migration"},
+	)
+}
+
+// Works out the next collateral state for a loan, or "" if nothing needs to happen on-chain.
+func NextCollateralState(loanRecord *LoanRecord) string {
+	switch loanRecord.CollateralState {
+	case kCollateralPendingLock:
+		// Lock even if the loan already finished so that release and forfeit always start from escrow.
+		return kCollateralLocked
+	case kCollateralLocked:
+		switch loanRecord.State {
+		case "REPAID":
+			return kCollateralReleased
+		case "DEFAULTED":
+			return kCollateralForfeited
+		}
+	}
+	return ""
+}
+
+// The transaction that moves a loan's collateral to next, or nil if nothing has to move on-chain.
+func collateralTransaction(hc *horizon.Client, user *User, loanRecord *LoanRecord, next string) (*b.TransactionBuilder, error) {
+	terms := loanRecord.AcceptedTerms
+	switch next {
+	case kCollateralLocked:
+		if user.StellarAddress == "" {
+			return nil, ErrStellarAccountNotLinked
+		}
+		if terms.QinRequired > 0.0 {
+			return QinLockTransaction(hc, user.StellarAddress, terms.QinRequired)
+		}
+	case kCollateralReleased:
+		return QinReleaseTransaction(hc, user.StellarAddress, terms.QinRequired, terms.QinReward)
+	case kCollateralForfeited:
+		if terms.QinRequired > 0.0 {
+			return QinForfeitTransaction(hc, terms.QinRequired)
+		}
+	default:
+		return nil, ErrQinCollateralUnsupported
+	}
+	return nil, nil
+}
+
+// Takes a loan's collateral one state further. The transaction is saved on the loan, guarded by the state it
+// was built for, before it is submitted, and the new state is only recorded once it has been applied.
+func settleCollateralStep(ctx context.Context, dbClient *datastore.Client, hc *horizon.Client, uid string, user *User, loanRecord *LoanRecord) (*LoanRecord, error) {
+	intent := loanRecord.CollateralIntent
+
+	if intent == nil {
+		next := NextCollateralState(loanRecord)
+		tx, err := collateralTransaction(hc, user, loanRecord, next)
+		if err != nil {
+			return nil, err
+		}
+
+		intent = &CollateralIntent{Next: next}
+		if tx != nil {
+			intent.TxHash, intent.Envelope, err = signTransaction(tx)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		collateralState := loanRecord.CollateralState
+		loanRecord, err = UpdateLoanRecordWith(ctx, dbClient, uid, loanRecord.LoanId, func(loanRecord *LoanRecord) error {
+			if loanRecord.CollateralState != collateralState || loanRecord.CollateralIntent != nil {
+				return ErrCollateralSettling
+			}
+			if intent.TxHash == "" {
+				// Nothing to move, so the new state can be recorded right away.
+				loanRecord.CollateralState = intent.Next
+			} else {
+				loanRecord.CollateralIntent = intent
+			}
+			return nil
+		})
+		if err != nil || intent.TxHash == "" {
+			return loanRecord, err
+		}
+	}
+
+	apply_err := applyTransaction(ctx, hc, intent.TxHash, intent.Envelope)
+	if apply_err != nil && !stellarutil.IsRejected(apply_err) {
+		return nil, apply_err
+	}
+
+	// Either way the intent is done with: applied, or turned away and to be built again next time.
+	updated, err := UpdateLoanRecordWith(ctx, dbClient, uid, loanRecord.LoanId, func(loanRecord *LoanRecord) error {
+		if loanRecord.CollateralIntent == nil || loanRecord.CollateralIntent.TxHash != intent.TxHash {
+			return ErrCollateralSettling
+		}
+		if apply_err == nil {
+			loanRecord.CollateralState = intent.Next
+		}
+		loanRecord.CollateralIntent = nil
+		return nil
+	})
+	if apply_err != nil {
+		return nil, apply_err
+	}
+	return updated, err
+}
+
+// Mints the QIN a user earned before linking a Stellar account, with the transaction saved on the user first.
+func migrateQinBalance(ctx context.Context, dbClient *datastore.Client, hc *horizon.Client, uid string, user *User) error {
+	userKey := UserKey(uid)
+	migration := *user.QinMigration
+
+	if migration.TxHash == "" {
+		tx, err := QinMigrationTransaction(hc, user.StellarAddress, migration.Amount)
+		if err != nil {
+			return err
+		}
+		migration.TxHash, migration.Envelope, err = signTransaction(tx)
+		if err != nil {
+			return err
+		}
+
+		_, err = RunInTransaction(ctx, dbClient, "SaveQinMigration", func(tx *datastore.Transaction) error {
+			var user User
+			if get_err := tx.Get(userKey, &user); get_err != nil {
+				return get_err
+			}
+			if user.QinMigration == nil || user.QinMigration.TxHash != "" {
+				return ErrCollateralSettling
+			}
+			user.QinMigration = &migration
+			_, put_err := tx.Put(userKey, &user)
+			return put_err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	apply_err := applyTransaction(ctx, hc, migration.TxHash, migration.Envelope)
+	if apply_err != nil && !stellarutil.IsRejected(apply_err) {
+		return apply_err
+	}
+
+	_, err := RunInTransaction(ctx, dbClient, "FinishQinMigration", func(tx *datastore.Transaction) error {
+		var user User
+		if get_err := tx.Get(userKey, &user); get_err != nil {
+			return get_err
+		}
+		if user.QinMigration == nil || user.QinMigration.TxHash != migration.TxHash {
+			return ErrCollateralSettling
+		}
+		if apply_err == nil {
+			user.QinMigration = nil
+		} else {
+			// Turned away, e.g. because the account stopped trusting QIN; build it again next time.
+			user.QinMigration = &QinMigration{Amount: migration.Amount}
+		}
+		_, put_err := tx.Put(userKey, &user)
+		return put_err
+	})
+	if apply_err != nil {
+		return apply_err
+	}
+	return err
+}
+
+// Performs the on-chain side of any collateral transitions for a user's loans, after first migrating QIN
+// they earned before linking an account.
+func SettleQinCollateral(ctx context.Context, dbClient *datastore.Client, uid string) error {
+	if !config.QinOnChain {
+		return nil
+	}
+
+	var user User
+	if err := dbClient.Get(ctx, UserKey(uid), &user); err != nil {
+		return err
+	}
+
+	hc := NewHorizonClient(ctx)
+
+	// Collateral is taken from the linked account, so the QIN has to be there first.
+	if user.QinMigration != nil {
+		if err := migrateQinBalance(ctx, dbClient, hc, uid, &user); err != nil {
+			return err
+		}
+	}
+
+	loanRecords, err := LoansAwaitingCollateral(ctx, dbClient, uid, nil)
+	if err != nil {
+		return err
+	}
+
+	for i := range loanRecords {
+		loanRecord := &loanRecords[i]
+		if loanRecord.AcceptedTerms == nil {
+			continue
+		}
+
+		for NextCollateralState(loanRecord) != "" {
+			updated, settle_err := settleCollateralStep(ctx, dbClient, hc, uid, &user, loanRecord)
+			if settle_err == ErrCollateralSettling {
+				// Another replica got there first and will finish it.
+				break
+			} else if settle_err != nil {
+				Logger(ctx).Error("Failed to settle QIN collateral", "loanId", loanRecord.LoanId, "err", settle_err)
+				break
+			}
+			loanRecord = updated
+		}
+	}
+
+	return nil
+}
+
+// Runs collateral settlement for a user on a pooled client, for use after a handler's transaction.
+func SettleQinCollateralAsync(ctx context.Context, uid string) {
+	if !config.QinOnChain {
+		return
+	}
+
+	ctx = DetachContext(ctx)
+	RunInBackground(func() {
+		dbClient := GetDbClient()
+		err := SettleQinCollateral(ctx, dbClient, uid)
+		returnDbClient <- dbClient
+		if err != nil {
+			Logger(ctx).Error("QIN collateral settlement failed", "uid", uid, "err", err)
+		}
+	})
+}
+
+// Compares the off-chain QIN balance of a user with their on-chain balance. The off-chain ledger is never
+// overwritten: a difference is recorded as a discrepancy for finance to look into, and cleared once the two
+// agree again.
+func ReconcileQinBalance(ctx context.Context, dbClient *datastore.Client, hc *horizon.Client, uid string) error {
+	if err := SettleQinCollateral(ctx, dbClient, uid); err != nil {
+		return err
+	}
+
+	userKey := UserKey(uid)
+
+	var user User
+	if err := dbClient.Get(ctx, userKey, &user); err != nil {
+		return err
+	}
+
+	if user.StellarAddress == "" {
+		return nil
+	}
+
+	onChain, err := OnChainQinBalance(hc, user.StellarAddress)
+	if err != nil {
+		return err
+	}
+
+	discrepancyKey := QinDiscrepancyKey(uid)
+
+	_, err = RunInTransaction(ctx, dbClient, "ReconcileQinBalance", func(tx *datastore.Transaction) error {
+		var user User
+		get_err := tx.Get(userKey, &user)
+		if get_err != nil {
+			return get_err
+		}
+
+		// Balances are only comparable once every transition has made it on-chain.
+		if user.QinMigration != nil {
+			return nil
+		}
+		awaiting, query_err := LoansAwaitingCollateral(ctx, dbClient, uid, tx)
+		if query_err != nil {
+			return query_err
+		}
+		if len(awaiting) > 0 {
+			return nil
+		}
+
+		var discrepancy QinDiscrepancy
+		get_err = tx.Get(discrepancyKey, &discrepancy)
+		if get_err != nil && get_err != datastore.ErrNoSuchEntity {
+			return get_err
+		}
+		found := get_err == nil
+
+		if math.Abs(user.QinBalance-onChain) < 0.0000001 {
+			if found {
+				Logger(ctx).Info("QIN balances agree again", "uid", uid, "qinBalance", onChain)
+				return tx.Delete(discrepancyKey)
+			}
+			return nil
+		}
+
+		now := time.Now().UnixNano() / int64(time.Millisecond)
+		if !found {
+			Logger(ctx).Warn("QIN balances disagree", "uid", uid, "offChain", user.QinBalance, "onChain", onChain)
+			discrepancy = QinDiscrepancy{Uid: uid, FirstFound: now}
+		}
+		discrepancy.StellarAddress = user.StellarAddress
+		discrepancy.OffChain = user.QinBalance
+		discrepancy.OnChain = onChain
+		discrepancy.LastFound = now
+
+		_, put_err := tx.Put(discrepancyKey, &discrepancy)
+		return put_err
+	})
+
+	return err
+}
+
+// Periodically settles outstanding collateral and reconciles every linked user's QIN balance.
+func ReconcileQinLedger(ctx context.Context) {
+	for true {
+		if !SleepUntilDone(ctx, kQinReconcileInterval) {
+			return
+		}
+
+		dbClient := GetDbClient()
+
+		query := datastore.NewQuery(kUserKind).KeysOnly()
+		keys, err := dbClient.GetAll(ctx, query, nil)
+		if err != nil {
+			logger.Error("Failed to list users for QIN reconciliation", "err", err)
+		}
+
+		hc := NewHorizonClient(ctx)
+		for _, key := range keys {
+			// Leave the rest for the next run after a restart.
+			if ctx.Err() != nil {
+				break
+			}
+			// Not canceled by shutdown, so that a settlement is always recorded once it is on-chain.
+			if err := ReconcileQinBalance(context.Background(), dbClient, hc, key.Name); err != nil {
+				logger.Error("Failed to reconcile QIN balance", "uid", key.Name, "err", err)
+			}
+		}
+
+		returnDbClient <- dbClient
+	}
+}
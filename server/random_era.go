@@ -11,13 +11,13 @@ func (RandomERA) predictProbDefault(borrower_app BorrowerApp) float64 {
 }
 
 func (RandomERA) predictInterestRate(prob_default float64) float64 {
-	return prob_default * MAX_INTEREST_RATE // uses linear scaling
+	return prob_default * config.Era.MaxInterestRate // uses linear scaling
 }
 
 func (RandomERA) computeQinCollateral(prob_default float64, num_successful_loans uint64) float64 {
 	// The collateral is a linear scaling between the max and min collateral wrt probability of default
 	// and based on the number of successful loans they have had, they need to post less collateral
-	return prob_default * MAX_QIN_COLLATERAL * (1.0 / (float64(num_successful_loans) + 1.0))
+	return prob_default * config.Era.MaxQinCollateral * (1.0 / (float64(num_successful_loans) + 1.0))
 }
 
 func (RandomERA) computeQinReward(prob_default float64, interest_reward float64) float64 {
@@ -27,5 +27,5 @@ func (RandomERA) computeQinReward(prob_default float64, interest_reward float64)
 }
 
 func (RandomERA) rejectBorrower(prob_default float64) bool {
-	return prob_default > 1.0
+	return prob_default > config.Era.Random.RejectAbove
 }
@@ -0,0 +1,325 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"cloud.google.com/go/datastore"
+)
+
+// Requests are rate limited with token buckets: every request per client IP, and requests of signed in users
+// per user as well. Routes listed in -rate-limits-file have buckets of their own; the rest share a default
+// bucket per IP and per user. Buckets are kept in memory, or in Datastore with -rate-limit-store datastore so
+// that replicas share them. If the store fails the request is let through.
+
+const (
+	kRateLimitStoreMemory    string = "memory"
+	kRateLimitStoreDatastore string = "datastore"
+)
+
+const (
+	kRateLimitScopeIp   string = "ip"
+	kRateLimitScopeUser string = "user"
+)
+
+const kRateLimitKind string = "RateLimitBucket"
+const kRateLimitSweepInterval time.Duration = 10 * time.Minute
+
+var ErrRateLimited = NewAPIError(http.StatusTooManyRequests, "RATE_LIMITED", "Too many requests. Try again later.")
+
+type RateLimit struct {
+	PerMinute float64 `json:"perMinute"` // Rate the bucket refills at; 0 turns the limit off
+	Burst     float64 `json:"burst"`     // Requests that can be made at once with a full bucket
+}
+
+type RouteRateLimits struct {
+	Routes  []string   `json:"routes"`            // Method and route template, e.g. "POST /v2/loans"; all share the buckets
+	PerIp   *RateLimit `json:"perIp,omitempty"`   // Defaults to the default per IP limit
+	PerUser *RateLimit `json:"perUser,omitempty"` // Defaults to the default per user limit
+}
+
+type RateLimits struct {
+	PerIp   RateLimit          `json:"perIp"`
+	PerUser RateLimit          `json:"perUser"`
+	Routes  []*RouteRateLimits `json:"routes"`
+}
+
+// Used when the rate limits file is missing.
+var defaultRateLimits = RateLimits{
+	PerIp:   RateLimit{PerMinute: 300, Burst: 100},
+	PerUser: RateLimit{PerMinute: 120, Burst: 60},
+	Routes: []*RouteRateLimits{
+		{
+			Routes:  []string{"POST /loan-request", "POST /v1/loan-request", "POST /v2/loans"},
+			PerUser: &RateLimit{PerMinute: 6, Burst: 3},
+		},
+		{
+			Routes:  []string{"POST /repay", "POST /v1/repay", "POST /v2/loans/{id}/repayments"},
+			PerUser: &RateLimit{PerMinute: 6, Burst: 3},
+		},
+		{
+			Routes:  []string{"PUT /active-loan", "PUT /v1/active-loan", "POST /v2/loans/{id}/offers/{termId}/accept", "PUT /v2/loans/{id}/pickup-location"},
+			PerUser: &RateLimit{PerMinute: 12, Burst: 5},
+		},
+	},
+}
+
+var rateLimits RateLimits
+
+// Route limits by method and route template.
+var routeRateLimits map[string]*RouteRateLimits
+
+var rateLimiter RateLimiter
+
+type RateLimiter interface {
+	// Takes a token from the bucket. If it is empty, returns how long until it has one.
+	Take(ctx context.Context, bucket string, limit RateLimit, now time.Time) (time.Duration, error)
+	// Forgets buckets that are full again by now, which is the same as never having used them.
+	Expire(ctx context.Context, now time.Time) error
+}
+
+type rateBucket struct {
+	Tokens  float64   `datastore:",noindex"`
+	Updated time.Time `datastore:",noindex"`
+	Full    time.Time // When the bucket is full again if no more tokens are taken
+}
+
+func (bucket *rateBucket) take(limit RateLimit, now time.Time) time.Duration {
+	perSecond := limit.PerMinute / 60
+	if bucket.Updated.IsZero() {
+		bucket.Tokens = limit.Burst
+	} else {
+		bucket.Tokens = math.Min(limit.Burst, bucket.Tokens+now.Sub(bucket.Updated).Seconds()*perSecond)
+	}
+	bucket.Updated = now
+
+	var wait time.Duration
+	if bucket.Tokens >= 1 {
+		bucket.Tokens--
+	} else {
+		wait = time.Duration((1 - bucket.Tokens) / perSecond * float64(time.Second))
+	}
+	bucket.Full = now.Add(time.Duration((limit.Burst - bucket.Tokens) / perSecond * float64(time.Second)))
+	return wait
+}
+
+// Buckets of this process only.
+type memoryRateLimiter struct {
+	sync.Mutex
+	buckets map[string]*rateBucket
+}
+
+func (limiter *memoryRateLimiter) Take(ctx context.Context, bucketName string, limit RateLimit, now time.Time) (time.Duration, error) {
+	limiter.Lock()
+	defer limiter.Unlock()
+
+	bucket, ok := limiter.buckets[bucketName]
+	if !ok {
+		bucket = &rateBucket{}
+		limiter.buckets[bucketName] = bucket
+	}
+	return bucket.take(limit, now), nil
+}
+
+func (limiter *memoryRateLimiter) Expire(ctx context.Context, now time.Time) error {
+	limiter.Lock()
+	defer limiter.Unlock()
+
+	for bucketName, bucket := range limiter.buckets {
+		if bucket.Full.Before(now) {
+			delete(limiter.buckets, bucketName)
+		}
+	}
+	return nil
+}
+
+// Buckets shared by every replica, at the cost of a transaction per request.
+type datastoreRateLimiter struct{}
+
+func (datastoreRateLimiter) Take(ctx context.Context, bucketName string, limit RateLimit, now time.Time) (time.Duration, error) {
+	dbClient := GetDbClient()
+
+	key := datastore.NameKey(kRateLimitKind, bucketName, nil)
+	var wait time.Duration
+
+	_, err := RunInTransaction(ctx, dbClient, "TakeRateLimitToken", func(tx *datastore.Transaction) error {
+		var bucket rateBucket
+		get_err := tx.Get(key, &bucket)
+		if get_err != nil && get_err != datastore.ErrNoSuchEntity {
+			return get_err
+		}
+
+		wait = bucket.take(limit, now)
+		_, put_err := tx.Put(key, &bucket)
+		return put_err
+	})
+
+	returnDbClient <- dbClient
+	return wait, err
+}
+
+func (datastoreRateLimiter) Expire(ctx context.Context, now time.Time) error {
+	dbClient := GetDbClient()
+	defer func() { returnDbClient <- dbClient }()
+
+	query := datastore.NewQuery(kRateLimitKind).Filter("Full <", now).KeysOnly()
+	keys, err := dbClient.GetAll(ctx, query, nil)
+	if err != nil {
+		return err
+	}
+
+	// Deletes are limited to 500 keys per call.
+	for start := 0; start < len(keys); start += 500 {
+		end := start + 500
+		if end > len(keys) {
+			end = len(keys)
+		}
+		if err := dbClient.DeleteMulti(ctx, keys[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func NewRateLimiter(store string) RateLimiter {
+	if store == kRateLimitStoreDatastore {
+		return datastoreRateLimiter{}
+	}
+	return &memoryRateLimiter{buckets: make(map[string]*rateBucket)}
+}
+
+func validRateLimit(limit *RateLimit) bool {
+	return limit == nil || limit.PerMinute == 0 || (limit.PerMinute > 0 && limit.Burst >= 1)
+}
+
+// Loads rate limits from a JSON file, falling back to the built-in ones when the file is missing.
+func LoadRateLimits(path string) error {
+	limits := defaultRateLimits
+
+	bytes, err := ioutil.ReadFile(path)
+	if err == nil {
+		limits = RateLimits{}
+		if err := json.Unmarshal(bytes, &limits); err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	if !validRateLimit(&limits.PerIp) || !validRateLimit(&limits.PerUser) {
+		return errors.New("Default rate limits need a burst of at least 1")
+	}
+
+	byRoute := make(map[string]*RouteRateLimits)
+	for _, routeLimits := range limits.Routes {
+		if len(routeLimits.Routes) == 0 {
+			return errors.New("Rate limits without routes")
+		}
+		if !validRateLimit(routeLimits.PerIp) || !validRateLimit(routeLimits.PerUser) {
+			return errors.New("Rate limits of " + routeLimits.Routes[0] + " need a burst of at least 1")
+		}
+		for _, route := range routeLimits.Routes {
+			if len(strings.Fields(route)) != 2 {
+				return errors.New("Rate limited route " + route + " should be a method and a route template")
+			}
+			byRoute[route] = routeLimits
+		}
+	}
+
+	rateLimits = limits
+	routeRateLimits = byRoute
+	return nil
+}
+
+// The address of the client, from -client-ip-header if a proxy in front of us sets it.
+func ClientIp(r *http.Request) string {
+	if config.ClientIpHeader != "" {
+		// Proxies append to the header, so only the last address was set by ours.
+		if forwarded := r.Header.Get(config.ClientIpHeader); forwarded != "" {
+			addresses := strings.Split(forwarded, ",")
+			return strings.TrimSpace(addresses[len(addresses)-1])
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// The limit for a request in a scope, and the name of its bucket.
+func rateLimitFor(r *http.Request, scope string) (RateLimit, string) {
+	limit, name := rateLimits.PerIp, "default"
+	if scope == kRateLimitScopeUser {
+		limit = rateLimits.PerUser
+	}
+
+	if routeLimits, ok := routeRateLimits[r.Method+" "+routeTemplate(r)]; ok {
+		routeLimit := routeLimits.PerIp
+		if scope == kRateLimitScopeUser {
+			routeLimit = routeLimits.PerUser
+		}
+		if routeLimit != nil {
+			limit, name = *routeLimit, routeLimits.Routes[0]
+		}
+	}
+	return limit, name
+}
+
+// Takes a token for the request from the bucket of identity in scope. Writes a 429 and returns false if there
+// was none.
+func TakeRateLimit(w http.ResponseWriter, r *http.Request, scope string, identity string) bool {
+	limit, name := rateLimitFor(r, scope)
+	if limit.PerMinute <= 0 {
+		return true
+	}
+
+	wait, err := rateLimiter.Take(RequestContext(r), scope+" "+identity+" "+name, limit, time.Now())
+	if err != nil {
+		Logger(r.Context()).Warn("Rate limiter failed, letting the request through", "err", err)
+		return true
+	}
+	if wait <= 0 {
+		return true
+	}
+
+	rateLimited.WithLabelValues(scope, routeTemplate(r)).Inc()
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	WriteError(w, r, ErrRateLimited)
+	return false
+}
+
+// Router middleware charging every request to its client IP. Preflight requests are free.
+func LimitRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodOptions && !TakeRateLimit(w, r, kRateLimitScopeIp, ClientIp(r)) {
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Periodically forgets buckets that have filled up again.
+func ExpireRateLimits(ctx context.Context) {
+	for true {
+		if !SleepUntilDone(ctx, kRateLimitSweepInterval) {
+			return
+		}
+
+		if err := rateLimiter.Expire(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			logger.Error("Failed to expire rate limit buckets", "err", err)
+		}
+	}
+}
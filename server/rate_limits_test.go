@@ -0,0 +1,128 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/gorilla/mux"
+)
+
+func TestRateBucketTake(t *testing.T) {
+	// A token a second, up to 2 at once.
+	limit := RateLimit{PerMinute: 60, Burst: 2}
+	start := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name  string
+		takes []time.Duration // Since start
+		want  []time.Duration // How long each take had to wait
+	}{
+		{"burst", []time.Duration{0, 0}, []time.Duration{0, 0}},
+		{"empty", []time.Duration{0, 0, 0}, []time.Duration{0, 0, time.Second}},
+		{"partly refilled", []time.Duration{0, 0, 250 * time.Millisecond}, []time.Duration{0, 0, 750 * time.Millisecond}},
+		{"refilled", []time.Duration{0, 0, time.Second}, []time.Duration{0, 0, 0}},
+		{"refills no more than the burst", []time.Duration{0, time.Hour, time.Hour, time.Hour}, []time.Duration{0, 0, 0, time.Second}},
+		{"waiting does not take a token", []time.Duration{0, 0, 0, 0}, []time.Duration{0, 0, time.Second, time.Second}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var bucket rateBucket
+			for i, since := range test.takes {
+				if wait := bucket.take(limit, start.Add(since)); wait != test.want[i] {
+					t.Errorf("take %d at %v: waited %v, want %v", i, since, wait, test.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRateBucketFull(t *testing.T) {
+	limit := RateLimit{PerMinute: 60, Burst: 2}
+	now := time.Unix(1700000000, 0)
+
+	var bucket rateBucket
+	bucket.take(limit, now)
+	if want := now.Add(time.Second); !bucket.Full.Equal(want) {
+		t.Errorf("full at %v after one take, want %v", bucket.Full, want)
+	}
+	bucket.take(limit, now)
+	if want := now.Add(2 * time.Second); !bucket.Full.Equal(want) {
+		t.Errorf("full at %v after two takes, want %v", bucket.Full, want)
+	}
+}
+
+func TestMemoryRateLimiter(t *testing.T) {
+	ctx := context.Background()
+	limit := RateLimit{PerMinute: 60, Burst: 1}
+	now := time.Unix(1700000000, 0)
+
+	limiter := NewRateLimiter(kRateLimitStoreMemory).(*memoryRateLimiter)
+
+	steps := []struct {
+		bucket string
+		at     time.Duration
+		want   time.Duration
+	}{
+		{"ip 1.2.3.4 default", 0, 0},
+		{"ip 1.2.3.4 default", 0, time.Second},
+		{"ip 5.6.7.8 default", 0, 0}, // Buckets are separate
+		{"user u default", 0, 0},
+		{"ip 1.2.3.4 default", time.Second, 0},
+	}
+	for i, step := range steps {
+		wait, err := limiter.Take(ctx, step.bucket, limit, now.Add(step.at))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if wait != step.want {
+			t.Errorf("step %d, %s: waited %v, want %v", i, step.bucket, wait, step.want)
+		}
+	}
+
+	// Only the bucket taken from last is still refilling.
+	if err := limiter.Expire(ctx, now.Add(1500*time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	if len(limiter.buckets) != 1 || limiter.buckets["ip 1.2.3.4 default"] == nil {
+		t.Errorf("kept buckets %v, want only ip 1.2.3.4 default", limiter.buckets)
+	}
+}
+
+func TestRateLimitFor(t *testing.T) {
+	tests := []struct {
+		route    string // Method and route template
+		path     string
+		scope    string
+		want     RateLimit
+		wantName string
+	}{
+		{"GET /v2/loans", "/v2/loans", kRateLimitScopeIp, defaultRateLimits.PerIp, "default"},
+		{"GET /v2/loans", "/v2/loans", kRateLimitScopeUser, defaultRateLimits.PerUser, "default"},
+		{"POST /v2/loans", "/v2/loans", kRateLimitScopeUser, RateLimit{PerMinute: 6, Burst: 3}, "POST /loan-request"},
+		{"POST /v2/loans/{id}/repayments", "/v2/loans/a/repayments", kRateLimitScopeUser, RateLimit{PerMinute: 6, Burst: 3}, "POST /repay"},
+		// These routes have no per IP limit of their own.
+		{"POST /v2/loans", "/v2/loans", kRateLimitScopeIp, defaultRateLimits.PerIp, "default"},
+	}
+
+	for _, test := range tests {
+		method, template := strings.Fields(test.route)[0], strings.Fields(test.route)[1]
+
+		var limit RateLimit
+		var name string
+		router := mux.NewRouter()
+		router.HandleFunc(template, func(w http.ResponseWriter, r *http.Request) {
+			limit, name = rateLimitFor(r, test.scope)
+		}).Methods(method)
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, test.path, nil))
+
+		if limit != test.want || name != test.wantName {
+			t.Errorf("%s in scope %s: got %v from %q, want %v from %q", test.route, test.scope, limit, name, test.want, test.wantName)
+		}
+	}
+}
@@ -4,17 +4,21 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"errors"
-	"fmt"
-	"io/ioutil"
+	"io"
 	"log"
 	"math"
+	"net"
 	"net/http"
-	"regexp"
-	"strconv"
+	"net/url"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/gorilla/mux"
 
+	"google.golang.org/grpc"
+
 	"golang.org/x/net/context"
 
 	"cloud.google.com/go/datastore"
@@ -22,28 +26,31 @@ import (
 	"firebase.google.com/go/auth"
 	b "github.com/stellar/go/build"
 	"github.com/stellar/go/clients/horizon"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/OneDaijo/sbc-demo-backend/stellarutil"
 )
 
-const kNumDbClients int64 = 5
 const kUserKind string = "user"
-const kLoanHistoryKind string = "loans"
+const kLoanHistoryKind string = "loans" // Only left to migrate from
 
 var (
-	ErrAuthFailed           = errors.New("Authentication failed.")
-	ErrEmailNotValidated    = errors.New("Email has not yet been verified.")
-	ErrUserDisabled         = errors.New("User account has been disabled.")
-	ErrAuthTokenNotProvided = errors.New("Auth token not provided.")
-	ErrUserNotFound         = errors.New("User was not found.")
-	ErrLoanInWrongState     = errors.New("Active loan was not in the correct state for this request.")
-	ErrNoActiveLoan         = errors.New("User has no active loan.")
-	ErrInvalidId            = errors.New("Provided ID was not found.")
-	ErrUserAlreadyExists    = errors.New("User already exists.")
-	ErrUserNotRegistered    = errors.New("User not registered.")
-	ErrLoanInDefault        = errors.New("Loan cannot be repaid as it is in default.")
-	ErrNotEnoughQin         = errors.New("Not enough QIN.")
-	ErrBadJsonPopulation    = errors.New("Some JSON fields were missing or populated incorrectly.")
-	ErrLoanAlreadyExists    = errors.New("Active loan already exists.")
-	ErrUserDataNotFound     = errors.New("Employment and residence information was not found for this user.")
+	ErrAuthFailed           = NewAPIError(http.StatusUnauthorized, "AUTH_FAILED", "Authentication failed.")
+	ErrEmailNotValidated    = NewAPIError(http.StatusBadRequest, "EMAIL_NOT_VERIFIED", "Email has not yet been verified.")
+	ErrUserDisabled         = NewAPIError(http.StatusBadRequest, "USER_DISABLED", "User account has been disabled.")
+	ErrAuthTokenNotProvided = NewAPIError(http.StatusBadRequest, "AUTH_TOKEN_MISSING", "Auth token not provided.")
+	ErrUserNotFound         = NewAPIError(http.StatusNotFound, "USER_NOT_FOUND", "User was not found.")
+	ErrLoanInWrongState     = NewAPIError(http.StatusBadRequest, "LOAN_WRONG_STATE", "Active loan was not in the correct state for this request.")
+	ErrNoActiveLoan         = NewAPIError(http.StatusNotFound, "NO_ACTIVE_LOAN", "User has no active loan.")
+	ErrInvalidId            = NewAPIError(http.StatusNotFound, "INVALID_ID", "Provided ID was not found.")
+	ErrUserAlreadyExists    = NewAPIError(http.StatusConflict, "USER_ALREADY_EXISTS", "User already exists.")
+	ErrUserNotRegistered    = NewAPIError(http.StatusNotFound, "USER_NOT_REGISTERED", "User not registered.")
+	ErrLoanInDefault        = NewAPIError(http.StatusBadRequest, "LOAN_IN_DEFAULT", "Loan cannot be repaid as it is in default.")
+	ErrNotEnoughQin         = NewAPIError(http.StatusBadRequest, "NOT_ENOUGH_QIN", "Not enough QIN.")
+	ErrBadJsonPopulation    = NewAPIError(http.StatusBadRequest, "INVALID_REQUEST_BODY", "Some JSON fields were missing or populated incorrectly.")
+	ErrLoanAlreadyExists    = NewAPIError(http.StatusConflict, "LOAN_ALREADY_EXISTS", "Active loan already exists.")
+	ErrUserDataNotFound     = NewAPIError(http.StatusNotFound, "USER_DATA_NOT_FOUND", "Employment and residence information was not found for this user.")
 )
 
 type EmploymentInfo struct {
@@ -73,9 +80,11 @@ type User struct {
 	PhoneNum        string  `json:"phoneNumber,omitempty"`
 	DateOfBirth     string  `json:"dateOfBirth,omitempty"`
 	QinBalance      float64 `json:"qinBalance"`
+	StellarAddress  string  `json:"stellarAddress,omitempty"` // Only used when QIN is on-chain
 	DateCreated     int64   `json:"created"`
 	*EmploymentInfo `json:"employmentInfo"`
 	*ResidenceInfo  `json:"residenceInfo"`
+	QinMigration    *QinMigration `json:"-" datastore:",noindex"` // Until QIN earned before linking is on-chain
 }
 
 type CreateUserResponse struct {
@@ -84,6 +93,7 @@ type CreateUserResponse struct {
 
 type LoanRequest struct {
 	LoanAmount  float64 `json:"loanAmount"`
+	Currency    string  `json:"currencyCode,omitempty"` // Defaults to PHP
 	LoanMemo    string  `json:"loanMemo,omitempty"`
 	LoanPurpose string  `json:"loanPurpose,omitempty"`
 	TermsAgreed bool    `json:"termsAgreed"`
@@ -91,15 +101,17 @@ type LoanRequest struct {
 }
 
 type LoanTerms struct {
-	TermId       string  `json:"id,omitempty"`
-	InterestRate float64 `json:"interestRate"`
-	QinReward    float64 `json:"qinReward"`
-	QinRequired  float64 `json:"qinRequired"`
-	AmountOwed   float64 `json:"amountOwed"`
-	OfferedBy    string  `json:"offeredBy,omitempty"`
+	TermId       string          `json:"id,omitempty"`
+	InterestRate float64         `json:"interestRate"`
+	QinReward    float64         `json:"qinReward"`
+	QinRequired  float64         `json:"qinRequired"`
+	AmountOwed   float64         `json:"amountOwed"`
+	OfferedBy    string          `json:"offeredBy,omitempty"`
+	Valuation    *TermsValuation `json:"valuation,omitempty"`
 }
 
 type PickupLocation struct {
+	LocationId   string `json:"locationId,omitempty"`
 	LocationName string `json:"locationName,omitempty"`
 }
 
@@ -111,19 +123,27 @@ type Repayment struct {
 type LoanRecord struct {
 	LoanId        string          `json:"id,omitempty"`
 	Amount        float64         `json:"amount"`
-	CurrencyCode  string          `json:"currencyCode,omitempty"` // PHP
-	DueDate       int64           `json:"dueDate,omitempty"`      // Unix milliseconds
-	Terms         []LoanTerms     `json:"loanTerms,omitempty"`
+	CurrencyCode  string          `json:"currencyCode,omitempty"`
+	DueDate       int64           `json:"dueDate,omitempty"` // Unix milliseconds
+	Terms         []LoanTerms     `json:"loanTerms,omitempty" datastore:",noindex"`
 	AcceptedTerms *LoanTerms      `json:"acceptedTerms,omitempty"`
 	State         string          `json:"state,omitempty"`
 	Location      *PickupLocation `json:"pickupLocation,omitempty"`
+	PickupCode    string          `json:"pickupCode,omitempty"` // Issued when the loan is SENT
 	Repayments    []Repayment     `json:"repayments,omitempty"`
-	Memo          string          `json:"memo,omitempty"`
-	Request       *LoanRequest    `json:"loanRequest,omitempty"`
+	Memo          string          `json:"memo,omitempty" datastore:",noindex"`
+	Request       *LoanRequest    `json:"loanRequest,omitempty" datastore:",noindex"`
 	RepaidDate    int64           `json:"repaidDate,omitempty"`
 	DateCreated   int64           `json:"created"`
+	// Only set when QIN is on-chain
+	CollateralState  string            `json:"collateralState,omitempty"`
+	CollateralIntent *CollateralIntent `json:"-" datastore:",noindex"` // While a collateral transaction is in flight
+	Disbursement     *AnchorTransfer   `json:"disbursement,omitempty"`
+	CashIn           *AnchorTransfer   `json:"cashIn,omitempty"`
 }
 
+// Storage format from before loans were their own entities, read by MigrateLoanHistories. Still the shape of
+// the v1 GET /loans response.
 type LoanHistory struct {
 	LoanRecords []LoanRecord `json:"loans,omitempty"`
 }
@@ -131,6 +151,11 @@ type LoanHistory struct {
 type LoanSelectRequest struct {
 	SelectedTerm string         `json:"selectedTerm,omitempty"`
 	Location     PickupLocation `json:"pickupLocation,omitempty"`
+	PartnerId    string         `json:"partnerId,omitempty"` // Defaults to Bloom
+}
+
+type RepayRequest struct {
+	PartnerId string `json:"partnerId,omitempty"` // Repay by cash-in through this partner
 }
 
 type LoanDeleteResponse struct {
@@ -145,6 +170,7 @@ type FirebaseAuthResponse struct {
 	Success  bool
 	Error    error
 	UserInfo auth.UserInfo
+	Roles    []string // Staff roles, from the user's custom claims
 }
 
 type FederationResponse struct {
@@ -153,8 +179,8 @@ type FederationResponse struct {
 	Memo      string `json:"memo,omitempty"`
 }
 
-// Account seed
-var from *string
+// Signs for the server account
+var serverSigner stellarutil.Signer
 
 // ERA
 var eraDriver *ERADriver
@@ -162,80 +188,32 @@ var eraDriver *ERADriver
 // Database Channels
 var getDbClient chan *datastore.Client
 var returnDbClient chan *datastore.Client
-var dbDone chan bool
 
 // Auth channels
 var authRequests chan FirebaseAuthRequest
 var authResponses chan FirebaseAuthResponse
-var authDone chan bool
 
 func Round(f float64) float64 {
 	return float64(int(f + math.Copysign(0.5, f)))
 }
 
-func GetErrorCode(err error) int {
-	switch err {
-	case ErrAuthFailed:
-		return http.StatusUnauthorized
-	case ErrEmailNotValidated:
-		return http.StatusBadRequest
-	case ErrUserDisabled:
-		return http.StatusBadRequest
-	case ErrAuthTokenNotProvided:
-		return http.StatusBadRequest
-	case ErrUserNotFound:
-		return http.StatusNotFound
-	case ErrLoanInWrongState:
-		return http.StatusBadRequest
-	case ErrNoActiveLoan:
-		return http.StatusNotFound
-	case ErrInvalidId:
-		return http.StatusNotFound
-	case ErrUserAlreadyExists:
-		return http.StatusConflict
-	case ErrUserNotRegistered:
-		return http.StatusNotFound
-	case ErrLoanInDefault:
-		return http.StatusBadRequest
-	case datastore.ErrNoSuchEntity:
-		return http.StatusNotFound
-	case ErrNotEnoughQin:
-		return http.StatusBadRequest
-	case ErrBadJsonPopulation:
-		return http.StatusBadRequest
-	case ErrLoanAlreadyExists:
-		return http.StatusConflict
-	case ErrUserDataNotFound:
-		return http.StatusNotFound
-	default:
-		// Log internal server errors.
-		fmt.Println(err)
-		return http.StatusInternalServerError
-	}
-}
-
-func CheckOrigin(w http.ResponseWriter, r *http.Request) {
-	origin := r.Header.Get("Origin")
-	match, _ := regexp.MatchString(`\.onedaijo.com(?::\d+)?$`, origin)
-	if match {
-		w.Header().Add("Access-Control-Allow-Origin", origin)
-		w.Header().Add("Access-Control-Allow-Credentials", "true") // Allow cookies to be used in requests
-		w.Header().Add("Vary", "Origin")                           // use cached response based on origin
-	}
+// Takes a client from the pool, recording how long it had to wait for one.
+func GetDbClient() *datastore.Client {
+	start := time.Now()
+	dbClient := <-getDbClient
+	dbPoolWait.Observe(time.Since(start).Seconds())
+	return dbClient
 }
 
-func ManageDbClients() {
-	// Intialize database connection
-	ctx := context.Background()
+func ManageDbClients(ctx context.Context) {
+	projectID := config.ProjectId
 
-	// Set your Google Cloud Platform project ID.
-	projectID := "testfaketest-a6c57"
-
-	for i := int64(0); i < kNumDbClients; i++ {
+	for i := 0; i < config.NumDbClients; i++ {
 		// Creates a client.
-		client, err := datastore.NewClient(ctx, projectID)
+		client, err := datastore.NewClient(context.Background(), projectID)
 		if err != nil {
-			log.Fatalf("Failed to create client: %v", err)
+			logger.Error("Failed to create Datastore client", "err", err)
+			os.Exit(1)
 		}
 
 		getDbClient <- client
@@ -248,28 +226,57 @@ func ManageDbClients() {
 				getDbClient <- clientToRecycle
 			} else {
 				// Creates new client since the old one was returned as nil because of a problem.
-				client, err := datastore.NewClient(ctx, projectID)
+				client, err := datastore.NewClient(context.Background(), projectID)
 				if err != nil {
-					fmt.Printf("Failed to create client: %v", err)
+					logger.Error("Failed to create Datastore client", "err", err)
 				}
 				getDbClient <- client
 			}
-		case <-dbDone:
-			break
+		case <-ctx.Done():
+			closeDbClients()
+			return
 		}
 	}
 }
 
-func Auth() {
+// Closes the clients in the pool. Clients still checked out are left to exit with the process.
+func closeDbClients() {
+	for true {
+		var client *datastore.Client
+		select {
+		case client = <-getDbClient:
+		case client = <-returnDbClient:
+		default:
+			return
+		}
+		if client != nil {
+			client.Close()
+		}
+	}
+}
+
+// A Firebase auth client for work outside the Auth service, such as looking users up by email.
+func NewFirebaseAuth(ctx context.Context) (*auth.Client, error) {
+	// Pulls credentials from env var
+	app, err := firebase.NewApp(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return app.Auth(ctx)
+}
+
+func Auth(ctx context.Context) {
 	for true {
 		// Pulls credentials from env var
 		app, err := firebase.NewApp(context.Background(), nil)
 		if err != nil {
-			log.Fatalf("firebase app creation error")
+			logger.Error("Failed to create Firebase app", "err", err)
+			os.Exit(1)
 		}
 		client, err := app.Auth(context.Background())
 		if err != nil {
-			log.Fatalf("error getting Auth client")
+			logger.Error("Failed to get Firebase auth client", "err", err)
+			os.Exit(1)
 		}
 		// This is just custom token generation sample code for refernce.
 		// token, err := client.CustomToken("8KvH0XdKOicatw4Fv5tnAONsCgl2")
@@ -281,31 +288,39 @@ func Auth() {
 		select {
 		case authRequest := <-authRequests:
 			var response FirebaseAuthResponse
+			start := time.Now()
+			result := kAuthResultOk
 			tokenObj, err := client.VerifyIDToken(authRequest.Token)
 			if err != nil {
 				response.Success = false
 				response.Error = ErrAuthFailed
+				result = kAuthResultInvalidToken
 			} else {
 				userObj, err := client.GetUser(context.Background(), tokenObj.UID)
 				if err != nil {
 					response.Error = ErrAuthFailed
 					response.Success = false
+					result = kAuthResultLookupFailed
 				} else {
 					if userObj.Disabled {
 						response.Success = false
 						response.Error = ErrUserDisabled
+						result = kAuthResultDisabled
 					} else if !userObj.EmailVerified {
 						response.Success = false
 						response.Error = ErrEmailNotValidated
+						result = kAuthResultEmailNotVerified
 					} else {
 						response.Success = true
 					}
 					response.UserInfo = *userObj.UserInfo
+					response.Roles = rolesFromClaims(userObj.CustomClaims)
 				}
 			}
+			authVerification.WithLabelValues(result).Observe(time.Since(start).Seconds())
 			authResponses <- response
-		case <-authDone:
-			break
+		case <-ctx.Done():
+			return
 		}
 	}
 }
@@ -317,8 +332,15 @@ func DoAuth(r *http.Request, requireEmailVerification bool) (FirebaseAuthRespons
 		return FirebaseAuthResponse{}, ErrAuthTokenNotProvided
 	}
 	authReq.Token = token
+
+	// Includes the wait for the Auth goroutine.
+	_, span := tracer.Start(r.Context(), "firebase.VerifyIDToken")
 	authRequests <- authReq
 	response := <-authResponses
+	endSpan(span, response.Error)
+	if response.UserInfo.UID != "" {
+		SetRequestUser(r.Context(), response.UserInfo.UID)
+	}
 	if !response.Success {
 		if !requireEmailVerification && response.Error == ErrEmailNotValidated {
 			return response, nil
@@ -329,223 +351,144 @@ func DoAuth(r *http.Request, requireEmailVerification bool) (FirebaseAuthRespons
 }
 
 func GetUser(w http.ResponseWriter, r *http.Request) {
-	CheckOrigin(w, r)
-
-	w.Header().Add("Strict-Transport-Security", "max-age=63072000; includeSubDomains")
-	authResponse, err := DoAuth(r, false)
+	user, err := ReadUser(RequestContext(r), RequestUid(r))
 
 	if err != nil {
-		http.Error(w, err.Error(), GetErrorCode(err))
+		WriteError(w, r, err)
 		return
 	}
 
-	dbClient := <-getDbClient
-
-	ctx := context.Background()
-	userKey := datastore.NameKey(kUserKind, authResponse.UserInfo.UID, nil)
-	var readUser *User
-
-	_, err = dbClient.RunInTransaction(ctx, func(tx *datastore.Transaction) error {
-
-		var user User
-
-		get_err := tx.Get(userKey, &user)
-
-		if get_err != nil {
-			return get_err
-		}
-
-		readUser = &user
-
-		return nil
-
-	})
-
-	returnDbClient <- dbClient
-
-	if err != nil {
-		http.Error(w, err.Error(), GetErrorCode(err))
-		return
-	}
-
-	if readUser.EmploymentInfo == nil {
-		readUser.EmploymentInfo = new(EmploymentInfo)
-	}
-
-	if readUser.ResidenceInfo == nil {
-		readUser.ResidenceInfo = new(ResidenceInfo)
-	}
-
-	json.NewEncoder(w).Encode(readUser)
+	json.NewEncoder(w).Encode(user)
 }
 
 func CreateUser(w http.ResponseWriter, r *http.Request) {
-	CheckOrigin(w, r)
+	var user User
+	err := DecodeRequest(r, &user)
 
-	w.Header().Add("Strict-Transport-Security", "max-age=63072000; includeSubDomains")
-	authResponse, err := DoAuth(r, false)
+	if err == nil {
+		var v Validator
+		user.Validate(&v, "", time.Now())
+		err = v.Err()
+	}
 
 	if err != nil {
-		http.Error(w, err.Error(), GetErrorCode(err))
+		WriteError(w, r, err)
 		return
 	}
 
-	var user User
-	err = json.NewDecoder(r.Body).Decode(&user)
+	createdUser, err := RegisterUser(RequestContext(r), RequestUid(r), user)
 
-	if err != nil || user.Firstname == "" || user.Lastname == "" || user.DateOfBirth == "" || user.PhoneNum == "" {
-		err = ErrBadJsonPopulation
-		http.Error(w, err.Error(), GetErrorCode(err))
+	if err != nil {
+		WriteError(w, r, err)
 		return
 	}
 
-	user.QinBalance = 0.0
-
-	user.DateCreated = time.Now().Unix() * 1000
-
-	dbClient := <-getDbClient
-
-	ctx := context.Background()
-	userKey := datastore.NameKey(kUserKind, authResponse.UserInfo.UID, nil)
-
-	_, err = dbClient.RunInTransaction(ctx, func(tx *datastore.Transaction) error {
-		var scratchUser User
-
-		// This should fail because the user should not exist
-		get_err := tx.Get(userKey, &scratchUser)
-		if get_err == nil {
-			return ErrUserAlreadyExists
-		} else if get_err != datastore.ErrNoSuchEntity {
-			return get_err
-		}
-
-		_, put_err := tx.Put(userKey, &user)
-		if put_err != nil {
-			return put_err
-		}
-
-		return nil
-
-	})
-
-	returnDbClient <- dbClient
+	json.NewEncoder(w).Encode(createdUser)
+}
 
+func sendTransaction(ctx context.Context, tx *b.TransactionBuilder, hc *horizon.Client) error {
+	txeB64, err := stellarutil.SignTransaction(tx, serverSigner)
 	if err != nil {
-		http.Error(w, err.Error(), GetErrorCode(err))
-		return
+		return err
 	}
+	return submitEnvelope(ctx, txeB64, hc)
+}
 
-	if user.EmploymentInfo == nil {
-		user.EmploymentInfo = new(EmploymentInfo)
+// Signs a transaction with the server account, returning its hash and envelope to be saved before it is
+// submitted.
+func signTransaction(tx *b.TransactionBuilder) (string, string, error) {
+	txHash, err := tx.HashHex()
+	if err != nil {
+		return "", "", err
 	}
-
-	if user.ResidenceInfo == nil {
-		user.ResidenceInfo = new(ResidenceInfo)
+	envelope, err := stellarutil.SignTransaction(tx, serverSigner)
+	if err != nil {
+		return "", "", err
 	}
-
-	json.NewEncoder(w).Encode(user)
+	return txHash, envelope, nil
 }
 
-func sendTransaction(tx *b.TransactionBuilder, from *string, hc *horizon.Client) error {
-
-	txe, err := tx.Sign(*from)
-	if err != nil {
+// Makes sure a saved transaction is applied, submitting it again unless it already was. Returns nil once it
+// is. An error for which stellarutil.IsRejected is true means it never will be and a new transaction has to be
+// built; after any other error the outcome is unknown and the same transaction is submitted again later.
+func applyTransaction(ctx context.Context, hc *horizon.Client, txHash string, envelope string) error {
+	applied, err := stellarutil.TransactionApplied(hc, txHash)
+	if err != nil || applied {
 		return err
 	}
 
-	txeB64, err := txe.Base64()
-
-	if err != nil {
-		return err
+	submit_err := submitEnvelope(ctx, envelope, hc)
+	if submit_err == nil {
+		return nil
 	}
 
-	resp, err := hc.SubmitTransaction(fmt.Sprintf("%s", txeB64))
+	// An earlier submission, possibly from another replica, may have been applied since, which makes this one
+	// fail with a bad sequence number.
+	applied, err = stellarutil.TransactionApplied(hc, txHash)
 	if err != nil {
-		fmt.Println(err)
-		herr, isHorizonError := err.(*horizon.Error)
-		if isHorizonError {
-			resultCodes, err := herr.ResultCodes()
-			if err != nil {
-				fmt.Println("failed to extract result codes from horizon response")
-				return err
-			}
-			fmt.Println(resultCodes)
-		}
 		return err
 	}
-
-	fmt.Println("transaction posted in ledger:", resp.Ledger)
-
-	return nil
-
+	if applied {
+		return nil
+	}
+	return submit_err
 }
 
-func GetBloomAddressAndMemo(c *http.Client) (string, string, error) {
-
-	// Dummy federation call.
-	r, err := c.Get("https://staging.bloomremit.net/stellar/federation?type=forward&forward_type=remittance_center&code=BOPIPHMM")
-
-	defer r.Body.Close()
+// Submits a transaction the server account has already signed.
+func submitEnvelope(ctx context.Context, txeB64 string, hc *horizon.Client) error {
+	ctx, span := tracer.Start(ctx, "stellar.SubmitTransaction")
 
+	resp, err := stellarutil.SubmitEnvelope(hc, txeB64)
 	if err != nil {
-		return "", "", err
+		// Includes the result codes when horizon provides them.
+		Logger(ctx).Error("Transaction failed", "err", err)
+		endSpan(span, err)
+		return err
 	}
 
-	var federationResponse FederationResponse
-	json.NewDecoder(r.Body).Decode(&federationResponse)
+	Logger(ctx).Info("Transaction posted", "ledger", resp.Ledger)
+	span.SetAttributes(attribute.Int64("stellar.ledger", int64(resp.Ledger)))
+	endSpan(span, nil)
 
-	// No compliance for the demo - users are not registered with bloom and we do not want to expose our demo users' personal info to a third party.
-
-	return federationResponse.AccountId, federationResponse.Memo, nil
+	return nil
 }
 
-// For demo purposes, all calls will be dummy calls from here on in, the only value that matters is the amount.
-func SendToBloom(amount float64) error {
+func GetFederationAddressAndMemo(ctx context.Context, partner *Partner) (accountId string, memo string, err error) {
+	ctx, span := tracer.Start(ctx, "federation.Lookup", trace.WithAttributes(attribute.String("sbc.partner", partner.PartnerId)))
+	defer func() { endSpan(span, err) }()
 
 	c := &http.Client{
 		Timeout: 10 * time.Second,
 	}
 
-	address, memo, err := GetBloomAddressAndMemo(c)
-
+	req, err := http.NewRequest("GET", partner.FederationUrl+"?type=forward&forward_type=remittance_center&code="+url.QueryEscape(partner.Code), nil)
 	if err != nil {
-		return err
-	}
-
-	hc := &horizon.Client{
-		URL:  "https://horizon-testnet.stellar.org",
-		HTTP: c,
+		return "", "", err
 	}
+	SetOutgoingRequestId(ctx, req)
 
-	tx, err := b.Transaction(
-		b.SourceAccount{AddressOrSeed: *from},
-		b.TestNetwork,
-		b.AutoSequence{SequenceProvider: hc},
-		b.Payment(
-			b.Destination{AddressOrSeed: address},
-			b.CreditAmount{"PHP", "GCBEJ5SNCV4B3E2TEDEUNR7DSC7Y4RLFAGSPNKZGNIOHQFWBHXCMMHZA", strconv.FormatFloat(amount, 'f', -1, 64)},
-			b.PayWith(b.Asset{Native: true}, "1000000"),
-		),
-		b.MemoText{memo},
-	)
+	r, err := c.Do(req)
 
 	if err != nil {
-		return err
+		return "", "", err
 	}
 
-	err = sendTransaction(tx, from, hc)
+	defer r.Body.Close()
 
-	if err != nil {
-		return err
-	}
+	var federationResponse FederationResponse
+	json.NewDecoder(r.Body).Decode(&federationResponse)
 
-	return nil
+	// No compliance for the demo - users are not registered with bloom and we do not want to expose our demo users' personal info to a third party.
+
+	return federationResponse.AccountId, federationResponse.Memo, nil
 }
 
 func IsLoanActive(loanRecord *LoanRecord) (bool, error) {
 	switch loanRecord.State {
 	case "PENDING":
 		return true, nil
+	case "PENDING_REVIEW":
+		return true, nil
 	case "APPROVED":
 		return true, nil
 	case "REJECTED":
@@ -565,41 +508,16 @@ func IsLoanActive(loanRecord *LoanRecord) (bool, error) {
 	}
 }
 
-func ActiveLoanForLoanHistory(loanHistory *LoanHistory) (*LoanRecord, error) {
-	var loanRecord *LoanRecord
-	var hasFoundLoan bool
-	hasFoundLoan = false
-	for i, loan := range loanHistory.LoanRecords {
-		isActive, err := IsLoanActive(&loan)
-		if err != nil {
-			return nil, err
-		}
-
-		if isActive {
-			if hasFoundLoan {
-				return nil, errors.New("Found multiple active loans")
-			}
-			hasFoundLoan = true
-			loanRecord = &loanHistory.LoanRecords[i]
-		}
-	}
-	return loanRecord, nil
-}
-
-func DefaultActiveLoanIfNecessary(loanHistory *LoanHistory) (bool, error) {
-	activeLoan, err := ActiveLoanForLoanHistory(loanHistory)
-	if err != nil {
-		return false, err
-	}
-
-	if activeLoan != nil && activeLoan.State == "SENT" {
-		if activeLoan.DueDate == 0 {
+// Defaults a sent loan that is past its due date. Returns whether it did.
+func DefaultLoanIfNecessary(loanRecord *LoanRecord) (bool, error) {
+	if loanRecord.State == "SENT" {
+		if loanRecord.DueDate == 0 {
 			return false, errors.New("Due date not set for SENT loan")
 		}
 		var currentTime int64
 		currentTime = time.Now().Unix() * 1000
-		if activeLoan.DueDate < currentTime {
-			activeLoan.State = "DEFAULTED"
+		if loanRecord.DueDate < currentTime {
+			loanRecord.State = "DEFAULTED"
 			return true, nil
 		}
 	}
@@ -609,182 +527,24 @@ func DefaultActiveLoanIfNecessary(loanHistory *LoanHistory) (bool, error) {
 }
 
 func LoanRequestFun(w http.ResponseWriter, r *http.Request) {
-	CheckOrigin(w, r)
-
-	w.Header().Add("Strict-Transport-Security", "max-age=63072000; includeSubDomains")
-	authResponse, err := DoAuth(r, true)
+	var loanRequest LoanRequest
+	err := DecodeRequest(r, &loanRequest)
 
-	if err != nil {
-		http.Error(w, err.Error(), GetErrorCode(err))
-		return
+	if err == nil {
+		var v Validator
+		loanRequest.Validate(&v)
+		err = v.Err()
 	}
 
-	var loanRecord *LoanRecord
-	loanRecord = new(LoanRecord)
-	loanRecord.Request = new(LoanRequest)
-	err = json.NewDecoder(r.Body).Decode(loanRecord.Request)
-
-	if err != nil || loanRecord.Request.User != nil || loanRecord.Request.LoanAmount == 0.0 {
-		err = ErrBadJsonPopulation
-		http.Error(w, err.Error(), GetErrorCode(err))
+	if err != nil {
+		WriteError(w, r, err)
 		return
 	}
 
-	loanRecord.Memo = loanRecord.Request.LoanMemo
-	loanRecord.Amount = loanRecord.Request.LoanAmount
-	loanRecord.CurrencyCode = "PHP"
-
-	loanRecord.DateCreated = time.Now().Unix() * 1000
-
-	// Note: this should be reset when the loan is accepted or rejected by the ERA.
-	loanRecord.State = "PENDING"
-
-	dbClient := <-getDbClient
-
-	ctx := context.Background()
-	loanHistoryKey := datastore.NameKey(kLoanHistoryKind, authResponse.UserInfo.UID, nil)
-	userKey := datastore.NameKey(kUserKind, authResponse.UserInfo.UID, nil)
-	var loanHistory *LoanHistory
-
-	_, err = dbClient.RunInTransaction(ctx, func(tx *datastore.Transaction) error {
-		loanHistory = new(LoanHistory)
-		var user User
-
-		get_err := tx.Get(userKey, &user)
-		if get_err == datastore.ErrNoSuchEntity {
-			return ErrUserNotRegistered
-		} else if get_err != nil {
-			return get_err
-		}
-
-		if user.EmploymentInfo == nil || user.ResidenceInfo == nil {
-			return ErrUserDataNotFound
-		}
-
-		loanRecord.Request.User = &user
-
-		get_err = tx.Get(loanHistoryKey, loanHistory)
-		if get_err != nil && get_err != datastore.ErrNoSuchEntity {
-			return get_err
-		}
-
-		// Don't need to know if it modified the active loan since a write will occur at the end of this func anyway.
-		_, default_err := DefaultActiveLoanIfNecessary(loanHistory)
-
-		if default_err != nil {
-			return default_err
-		}
-
-		// Set loan ID
-		numPrevLoans := len(loanHistory.LoanRecords)
-		loanRecord.LoanId = authResponse.UserInfo.UID + "-" + strconv.Itoa(numPrevLoans)
-
-		var borrowerInfo BorrowerInformation
-		borrowerInfo.earned_qin = user.QinBalance
-		borrowerInfo.no_loans = 0
-		borrowerInfo.successful_loans = 0
-
-		for _, loan := range loanHistory.LoanRecords {
-			active, state_err := IsLoanActive(&loan)
-			if state_err != nil {
-				return state_err
-			}
-			if active {
-				return ErrLoanAlreadyExists
-			}
-
-			if loan.State == "REPAID" {
-				borrowerInfo.successful_loans++
-				borrowerInfo.no_loans++
-			}
-
-			if loan.State == "DEFAULTED" {
-				borrowerInfo.no_loans++
-			}
-		}
-
-		var borrowerApp BorrowerApp
-		borrowerApp.principal_amount = loanRecord.Amount
-		borrowerApp.borrower_id = authResponse.UserInfo.UID
-
-		// Handle all them pointers
-		if income := loanRecord.Request.User.EmploymentInfo.EmploymentIncome; income == nil {
-			borrowerApp.stated_monthly_income = 0
-		} else {
-			borrowerApp.stated_monthly_income = *income
-		}
-
-		if startMonth := loanRecord.Request.User.EmploymentInfo.EmploymentStartMonth; startMonth == nil {
-			borrowerApp.employment_start_month = 0
-		} else {
-			borrowerApp.employment_start_month = *startMonth
-		}
-
-		if startYear := loanRecord.Request.User.EmploymentInfo.EmploymentStartYear; startYear == nil {
-			borrowerApp.employment_start_year = 0
-		} else {
-			borrowerApp.employment_start_year = *startYear
-		}
-
-		borrowerApp.employment_status = loanRecord.Request.User.EmploymentInfo.EmploymentStatus
-
-		// fmt.Printf("Borrower App Struct:\n%+v\n", &borrowerApp)
-		// fmt.Printf("Borrower Info Struct:\n%+v\n", &borrowerInfo)
-
-		era_terms, num_not_nil := processBorrowerRequest(eraDriver, borrowerApp, borrowerInfo)
-
-		loanRecord.State = "APPROVED"
-
-		if num_not_nil > 0 {
-			loanRecord.State = "APPROVED"
-
-			loanRecord.Terms = make([]LoanTerms, num_not_nil)
-
-			var currentIndex int
-			currentIndex = 0
-			for _, terms := range era_terms {
-				if terms != nil { // skip rejected eras
-					// fmt.Printf("ERA Terms %i:\n%+v\n", i, terms)
-					loanRecord.Terms[currentIndex].TermId = loanRecord.LoanId + "-" + strconv.Itoa(currentIndex)
-					// Round to 4 decimal places (or round the percentage to 2 decimal places)
-					loanRecord.Terms[currentIndex].InterestRate = Round(terms.interest_rate*10000.0) / 10000.0
-					// Round QIN to nearest 0.01 QIN.
-					loanRecord.Terms[currentIndex].QinReward = Round(terms.qin_reward*100.0) / 100.0
-					loanRecord.Terms[currentIndex].QinRequired = Round(terms.qin_collateral*100.0) / 100.0
-					// Round to the nearest $0.01
-					loanRecord.Terms[currentIndex].AmountOwed = Round((1.0+loanRecord.Terms[currentIndex].InterestRate)*loanRecord.Amount*100.0) / 100.0
-					loanRecord.Terms[currentIndex].OfferedBy = terms.offered_by
-					currentIndex++
-				}
-			}
-
-		} else {
-			loanRecord.Terms = make([]LoanTerms, 1)
-
-			loanRecord.Terms[0].TermId = loanRecord.LoanId + "-0"
-			loanRecord.Terms[0].InterestRate = 0.05
-			// Round QIN to nearest 0.01 QIN.
-			loanRecord.Terms[0].QinReward = 0.1
-			loanRecord.Terms[0].QinRequired = 0.0
-			// Round to the nearest $0.01
-			loanRecord.Terms[0].AmountOwed = Round((1.0+loanRecord.Terms[0].InterestRate)*loanRecord.Amount*100.0) / 100.0
-			loanRecord.Terms[0].OfferedBy = "OneDaijo"
-		}
-
-		loanHistory.LoanRecords = append(loanHistory.LoanRecords, *loanRecord)
-
-		_, put_err := tx.Put(loanHistoryKey, loanHistory)
-		if put_err != nil {
-			return put_err
-		}
-
-		return nil
-	})
-
-	returnDbClient <- dbClient
+	loanRecord, err := RequestLoan(RequestContext(r), RequestUid(r), loanRequest)
 
 	if err != nil {
-		http.Error(w, err.Error(), GetErrorCode(err))
+		WriteError(w, r, err)
 		return
 	}
 
@@ -793,67 +553,49 @@ func LoanRequestFun(w http.ResponseWriter, r *http.Request) {
 }
 
 func GetActiveLoan(w http.ResponseWriter, r *http.Request) {
-	CheckOrigin(w, r)
-
-	w.Header().Add("Strict-Transport-Security", "max-age=63072000; includeSubDomains")
-	authResponse, err := DoAuth(r, true)
+	activeLoan, err := ReadLoan(RequestContext(r), RequestUid(r), "")
 
 	if err != nil {
-		http.Error(w, err.Error(), GetErrorCode(err))
+		WriteError(w, r, err)
 		return
 	}
 
-	dbClient := <-getDbClient
+	// Remove the request before encoding since that's not part of the API spec
+	activeLoan.Request = nil
 
-	ctx := context.Background()
-	loanHistoryKey := datastore.NameKey(kLoanHistoryKind, authResponse.UserInfo.UID, nil)
-	var loanHistory *LoanHistory
+	json.NewEncoder(w).Encode(activeLoan)
+}
 
-	_, err = dbClient.RunInTransaction(ctx, func(tx *datastore.Transaction) error {
-		loanHistory = new(LoanHistory)
-		get_err := tx.Get(loanHistoryKey, loanHistory)
-		if get_err != nil && get_err != datastore.ErrNoSuchEntity {
-			return get_err
-		}
+// Applies an update to a single loan of a user in its own transaction and returns the updated loan.
+func UpdateLoanRecord(uid string, loanId string, update func(loanRecord *LoanRecord) error) (*LoanRecord, error) {
+	dbClient := GetDbClient()
+	updated, err := UpdateLoanRecordWith(context.Background(), dbClient, uid, loanId, update)
+	returnDbClient <- dbClient
+	return updated, err
+}
 
-		didModify, default_err := DefaultActiveLoanIfNecessary(loanHistory)
+// Like UpdateLoanRecord, for callers that already hold a pooled client.
+func UpdateLoanRecordWith(ctx context.Context, dbClient *datastore.Client, uid string, loanId string, update func(loanRecord *LoanRecord) error) (*LoanRecord, error) {
+	var updated *LoanRecord
 
-		if default_err != nil {
-			return default_err
+	err := RunLoanTransaction(ctx, dbClient, uid, func(tx *datastore.Transaction, loanTx *LoanTx) error {
+		var get_err error
+		updated, get_err = loanTx.Get(loanId)
+		if get_err != nil {
+			return get_err
 		}
 
-		if didModify {
-			_, put_err := tx.Put(loanHistoryKey, loanHistory)
-			if put_err != nil {
-				return put_err
-			}
+		if update_err := update(updated); update_err != nil {
+			return update_err
 		}
 
-		return nil
+		return loanTx.Put(updated)
 	})
 
-	returnDbClient <- dbClient
-
-	if err != nil {
-		http.Error(w, err.Error(), GetErrorCode(err))
-		return
-	}
-
-	activeLoan, err := ActiveLoanForLoanHistory(loanHistory)
-
-	if err == nil && activeLoan == nil {
-		err = ErrNoActiveLoan
-	}
-
 	if err != nil {
-		http.Error(w, err.Error(), GetErrorCode(err))
-		return
+		return nil, err
 	}
-
-	// Remove the request before encoding since that's not part of the API spec
-	activeLoan.Request = nil
-
-	json.NewEncoder(w).Encode(activeLoan)
+	return updated, nil
 }
 
 func LoanTermsForId(termId string, loanRecord *LoanRecord) *LoanTerms {
@@ -866,298 +608,118 @@ func LoanTermsForId(termId string, loanRecord *LoanRecord) *LoanTerms {
 }
 
 func SelectLoanOffer(w http.ResponseWriter, r *http.Request) {
-	CheckOrigin(w, r)
+	var loanSelectRequest LoanSelectRequest
 
-	w.Header().Add("Strict-Transport-Security", "max-age=63072000; includeSubDomains")
-	authResponse, err := DoAuth(r, true)
+	err := DecodeRequest(r, &loanSelectRequest)
 
-	if err != nil {
-		http.Error(w, err.Error(), GetErrorCode(err))
-		return
+	if err == nil {
+		var v Validator
+		loanSelectRequest.Validate(&v)
+		err = v.Err()
 	}
 
-	var loanSelectRequest LoanSelectRequest
-
-	err = json.NewDecoder(r.Body).Decode(&loanSelectRequest)
-
-	if err != nil || (loanSelectRequest.Location.LocationName == "" && loanSelectRequest.SelectedTerm == "") {
-		err = ErrBadJsonPopulation
-		http.Error(w, err.Error(), GetErrorCode(err))
+	if err != nil {
+		WriteError(w, r, err)
 		return
 	}
 
-	dbClient := <-getDbClient
-
-	ctx := context.Background()
-	loanHistoryKey := datastore.NameKey(kLoanHistoryKind, authResponse.UserInfo.UID, nil)
-	userKey := datastore.NameKey(kUserKind, authResponse.UserInfo.UID, nil)
-	var loanHistory *LoanHistory
-	var activeLoan *LoanRecord
-
-	_, err = dbClient.RunInTransaction(ctx, func(tx *datastore.Transaction) error {
-		loanHistory = new(LoanHistory)
-		var user User
-
-		get_err := tx.Get(userKey, &user)
-		if get_err == datastore.ErrNoSuchEntity {
-			return ErrUserNotRegistered
-		} else if get_err != nil {
-			return get_err
-		}
-
-		get_err = tx.Get(loanHistoryKey, loanHistory)
-		if get_err != nil && get_err != datastore.ErrNoSuchEntity {
-			return get_err
-		}
-
-		activeLoan, err = ActiveLoanForLoanHistory(loanHistory)
-
-		if err != nil {
-			return err
-		}
-
-		if activeLoan == nil {
-			return ErrNoActiveLoan
-		}
-
-		if activeLoan.State != "APPROVED" {
-			return ErrLoanInWrongState
-		}
-
-		// Loan terms must be selected before or at the same time as pickup location
-		if loanSelectRequest.SelectedTerm != "" {
-			// Loan terms cannot be provided twice.
-			if activeLoan.AcceptedTerms != nil {
-				return ErrBadJsonPopulation
-			}
-
-			terms := LoanTermsForId(loanSelectRequest.SelectedTerm, activeLoan)
-			if terms == nil {
-				return ErrInvalidId
-			}
-
-			if terms.QinRequired > user.QinBalance {
-				return ErrNotEnoughQin
-			}
-
-			activeLoan.AcceptedTerms = terms
-		}
-
-		if loanSelectRequest.Location.LocationName != "" {
-			if activeLoan.AcceptedTerms == nil {
-				return ErrBadJsonPopulation
-			}
-
-			activeLoan.Location = new(PickupLocation)
-			*activeLoan.Location = loanSelectRequest.Location
-
-			// Ignore money sending errors for the demo for demo
-			// if err != nil {
-			// 	return err
-			// }
-
-			// Adds 30 days, gets the unix timestamps rounds down to the nearest day and multiplies by 1000 to get it in milliseconds
-			activeLoan.DueDate = (time.Now().AddDate(0, 0, 30).Unix() / 86400 * 86400) * 1000
-
-			activeLoan.State = "SENT"
-
-			if user.QinBalance < activeLoan.AcceptedTerms.QinRequired {
-				return errors.New("Internal Error: user has less QIN than when loan was selected.")
-			}
-
-			user.QinBalance -= activeLoan.AcceptedTerms.QinRequired
-
-			_, put_err := tx.Put(userKey, &user)
-			if put_err != nil {
-				return put_err
-			}
-
-		}
-
-		_, put_err := tx.Put(loanHistoryKey, loanHistory)
-		if put_err != nil {
-			return put_err
-		}
-
-		return nil
-	})
-
-	returnDbClient <- dbClient
+	activeLoan, err := SelectLoanTerms(RequestContext(r), RequestUid(r), "", loanSelectRequest)
 
 	if err != nil {
-		http.Error(w, err.Error(), GetErrorCode(err))
+		WriteError(w, r, err)
 		return
 	}
 
-	// Send to bloom outside of the transaction and ignore any error.
-	err = SendToBloom(activeLoan.Amount)
-	fmt.Println(err)
-
 	activeLoan.Request = nil
 	json.NewEncoder(w).Encode(activeLoan)
 }
 
 func Repay(w http.ResponseWriter, r *http.Request) {
-	CheckOrigin(w, r)
+	// An empty body repays instantly, which is what the demo does.
+	var repayRequest RepayRequest
+	decode_err := json.NewDecoder(r.Body).Decode(&repayRequest)
 
-	w.Header().Add("Strict-Transport-Security", "max-age=63072000; includeSubDomains")
-	authResponse, err := DoAuth(r, true)
-
-	if err != nil {
-		http.Error(w, err.Error(), GetErrorCode(err))
+	if decode_err != nil && decode_err != io.EOF {
+		WriteError(w, r, ErrBadJsonPopulation)
 		return
 	}
 
-	dbClient := <-getDbClient
-
-	ctx := context.Background()
-	loanHistoryKey := datastore.NameKey(kLoanHistoryKind, authResponse.UserInfo.UID, nil)
-	userKey := datastore.NameKey(kUserKind, authResponse.UserInfo.UID, nil)
-	var loanHistory *LoanHistory
-	var activeLoan *LoanRecord
-
-	var repaid bool
-
-	_, err = dbClient.RunInTransaction(ctx, func(tx *datastore.Transaction) error {
-		loanHistory = new(LoanHistory)
-		var user User
-
-		get_err := tx.Get(userKey, &user)
-		if get_err == datastore.ErrNoSuchEntity {
-			return ErrUserNotRegistered
-		} else if get_err != nil {
-			return get_err
-		}
-
-		get_err = tx.Get(loanHistoryKey, loanHistory)
-		if get_err != nil && get_err != datastore.ErrNoSuchEntity {
-			return get_err
-		}
-
-		activeLoan, err = ActiveLoanForLoanHistory(loanHistory)
-
-		if err != nil {
-			return err
-		}
-
-		if activeLoan == nil {
-			return ErrNoActiveLoan
-		}
-
-		if activeLoan.State != "SENT" {
-			return ErrLoanInWrongState
-		}
-
-		didModify, default_err := DefaultActiveLoanIfNecessary(loanHistory)
-
-		if default_err != nil {
-			return default_err
-		}
-
-		// If it was modified above, this loan is no longer active and should not be repaid
-		if !didModify {
-			var timestamp int64
-			timestamp = time.Now().Unix() * 1000
-			// Instant repayment for demo
-			activeLoan.Repayments = append(activeLoan.Repayments, Repayment{Amount: activeLoan.AcceptedTerms.AmountOwed, Timestamp: timestamp})
-			activeLoan.RepaidDate = timestamp
-			activeLoan.State = "REPAID"
-
-			// Return the collateral and give the reward
-			user.QinBalance += activeLoan.AcceptedTerms.QinRequired + activeLoan.AcceptedTerms.QinReward
-
-			// This is the only place where we modify user, so only write in this if
-			_, put_err := tx.Put(userKey, &user)
-			if put_err != nil {
-				return put_err
-			}
-
-			repaid = true
-
-		} else {
-			repaid = false
-		}
-
-		_, put_err := tx.Put(loanHistoryKey, loanHistory)
-
-		if put_err != nil {
-			return put_err
-		}
-
-		return nil
-
-	})
-
-	returnDbClient <- dbClient
-
-	if err == nil && !repaid {
-		err = ErrLoanInDefault
-	}
+	activeLoan, done, err := RepayLoan(RequestContext(r), RequestUid(r), "", repayRequest)
 
 	if err != nil {
-		http.Error(w, err.Error(), GetErrorCode(err))
+		WriteError(w, r, err)
 		return
 	}
 
 	activeLoan.Request = nil
+	if !done {
+		w.WriteHeader(http.StatusAccepted)
+	}
 	json.NewEncoder(w).Encode(activeLoan)
 }
 
-func DeleteActiveLoan(w http.ResponseWriter, r *http.Request) {
-	CheckOrigin(w, r)
-
-	w.Header().Add("Strict-Transport-Security", "max-age=63072000; includeSubDomains")
-	authResponse, err := DoAuth(r, true)
-
+// Starts a cash-in repayment with a partner, or checks on the one already in progress.
+// Returns the loan and whether the partner has received the money.
+func StartOrCheckCashIn(ctx context.Context, uid string, loanId string, partnerId string) (*LoanRecord, bool, error) {
+	partner, err := PartnerForId(partnerId)
 	if err != nil {
-		http.Error(w, err.Error(), GetErrorCode(err))
-		return
+		return nil, false, err
 	}
 
-	dbClient := <-getDbClient
-
-	ctx := context.Background()
-	loanHistoryKey := datastore.NameKey(kLoanHistoryKind, authResponse.UserInfo.UID, nil)
-	var loanHistory *LoanHistory
-
-	_, err = dbClient.RunInTransaction(ctx, func(tx *datastore.Transaction) error {
-		loanHistory = new(LoanHistory)
+	if !partner.CashIn {
+		return nil, false, ErrPartnerNoCashIn
+	}
 
-		get_err := tx.Get(loanHistoryKey, loanHistory)
-		if get_err != nil && get_err != datastore.ErrNoSuchEntity {
-			return get_err
-		}
+	dbClient := GetDbClient()
 
-		activeLoan, err := ActiveLoanForLoanHistory(loanHistory)
+	var activeLoan *LoanRecord
 
-		if err != nil {
-			return err
-		}
+	// Read in a transaction for a consistent view of the summary and the loan.
+	err = RunLoanTransaction(ctx, dbClient, uid, func(tx *datastore.Transaction, loanTx *LoanTx) error {
+		var get_err error
+		activeLoan, get_err = loanTx.Get(loanId)
+		return get_err
+	})
 
-		if activeLoan == nil {
-			return ErrNoActiveLoan
-		}
+	returnDbClient <- dbClient
 
-		if activeLoan.State != "APPROVED" && activeLoan.State != "PENDING" {
-			return ErrLoanInWrongState
-		}
+	if err != nil {
+		return nil, false, err
+	}
+	if activeLoan.State != "SENT" {
+		return nil, false, ErrLoanInWrongState
+	}
 
-		activeLoan.State = "CANCELED"
+	var transfer *AnchorTransfer
+	done := false
 
-		_, put_err := tx.Put(loanHistoryKey, loanHistory)
+	if activeLoan.CashIn != nil && activeLoan.CashIn.PartnerId == partner.PartnerId {
+		transfer = activeLoan.CashIn
+		done, err = CheckCashIn(ctx, transfer)
+	} else {
+		transfer, err = AnchorClientForPartner(partner).StartDeposit(ctx, activeLoan.AcceptedTerms.AmountOwed, activeLoan.CurrencyCode, activeLoan.LoanId)
+	}
 
-		if put_err != nil {
-			return put_err
-		}
+	if err != nil {
+		return nil, false, err
+	}
 
+	updated, err := UpdateLoanRecord(uid, activeLoan.LoanId, func(loanRecord *LoanRecord) error {
+		loanRecord.CashIn = transfer
 		return nil
-
 	})
+	if err != nil {
+		return nil, false, err
+	}
 
-	returnDbClient <- dbClient
+	return updated, done, nil
+}
+
+func DeleteActiveLoan(w http.ResponseWriter, r *http.Request) {
+	_, err := CancelLoan(RequestContext(r), RequestUid(r), "")
 
 	if err != nil {
-		http.Error(w, err.Error(), GetErrorCode(err))
+		WriteError(w, r, err)
 		return
 	}
 
@@ -1166,161 +728,131 @@ func DeleteActiveLoan(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(loanDeleteResponse)
 }
 
+// v1 lists every loan, oldest first, in one response; paging and filters are only on v2.
 func GetLoans(w http.ResponseWriter, r *http.Request) {
-	CheckOrigin(w, r)
-
-	w.Header().Add("Strict-Transport-Security", "max-age=63072000; includeSubDomains")
-	authResponse, err := DoAuth(r, true)
+	loanHistory, err := ListAllLoans(RequestContext(r), RequestUid(r))
 
 	if err != nil {
-		http.Error(w, err.Error(), GetErrorCode(err))
+		WriteError(w, r, err)
 		return
 	}
 
-	dbClient := <-getDbClient
-
-	ctx := context.Background()
-	loanHistoryKey := datastore.NameKey(kLoanHistoryKind, authResponse.UserInfo.UID, nil)
-	var loanHistory *LoanHistory
-
-	_, err = dbClient.RunInTransaction(ctx, func(tx *datastore.Transaction) error {
-		loanHistory = new(LoanHistory)
-
-		get_err := tx.Get(loanHistoryKey, loanHistory)
-		if get_err != nil && get_err != datastore.ErrNoSuchEntity {
-			return get_err
-		}
-
-		didModify, default_err := DefaultActiveLoanIfNecessary(loanHistory)
-
-		if default_err != nil {
-			return default_err
-		}
-
-		if didModify {
-			_, put_err := tx.Put(loanHistoryKey, loanHistory)
-
-			if put_err != nil {
-				return put_err
-			}
-		}
-
-		return nil
-
-	})
-
-	returnDbClient <- dbClient
-
-	if err != nil {
-		http.Error(w, err.Error(), GetErrorCode(err))
-		return
-	}
-
-	for i := int(0); i < len(loanHistory.LoanRecords); i++ {
-		// Remove the loan request because the client doesn't want that
-		loanHistory.LoanRecords[i].Request = nil
-	}
-
 	json.NewEncoder(w).Encode(loanHistory)
 }
 
-func HealthCheck(w http.ResponseWriter, r *http.Request) {
-	w.Header().Add("Strict-Transport-Security", "max-age=63072000; includeSubDomains")
-	dbClient := <-getDbClient
-	returnDbClient <- dbClient
-	var resp LoanDeleteResponse
-	resp.Success = true
-	json.NewEncoder(w).Encode(resp)
-}
-
-func HandleOptions(w http.ResponseWriter, r *http.Request) {
-	w.Header().Add("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-	w.Header().Add("Access-Control-Allow-Headers", "Content-type, X-firebase-token")
-
-	CheckOrigin(w, r)
-}
-
 func PatchUser(w http.ResponseWriter, r *http.Request) {
-	CheckOrigin(w, r)
+	var user User
+	err := DecodeRequest(r, &user)
 
-	w.Header().Add("Strict-Transport-Security", "max-age=63072000; includeSubDomains")
-	authResponse, err := DoAuth(r, true)
+	if err == nil {
+		var v Validator
+		user.ValidatePatch(&v, time.Now())
+		err = v.Err()
+	}
 
 	if err != nil {
-		http.Error(w, err.Error(), GetErrorCode(err))
+		WriteError(w, r, err)
 		return
 	}
 
-	var user User
-	err = json.NewDecoder(r.Body).Decode(&user)
+	finalizedUser, err := UpdateUser(RequestContext(r), RequestUid(r), user)
 
-	if err != nil || (user.EmploymentInfo == nil && user.ResidenceInfo == nil) {
-		err = ErrBadJsonPopulation
-		http.Error(w, err.Error(), GetErrorCode(err))
+	if err != nil {
+		WriteError(w, r, err)
 		return
 	}
 
-	var finalizedUser *User
-
-	dbClient := <-getDbClient
-
-	ctx := context.Background()
-	userKey := datastore.NameKey(kUserKind, authResponse.UserInfo.UID, nil)
-
-	_, err = dbClient.RunInTransaction(ctx, func(tx *datastore.Transaction) error {
-		var existingUser User
-
-		// The user must exist
-		get_err := tx.Get(userKey, &existingUser)
-		if get_err != nil {
-			return get_err
-		}
-
-		if user.EmploymentInfo != nil {
-			existingUser.EmploymentInfo = user.EmploymentInfo
-		}
-
-		if user.ResidenceInfo != nil {
-			existingUser.ResidenceInfo = user.ResidenceInfo
-		}
-
-		_, put_err := tx.Put(userKey, &existingUser)
-		if put_err != nil {
-			return put_err
-		}
-
-		finalizedUser = &existingUser
+	json.NewEncoder(w).Encode(finalizedUser)
+}
 
-		return nil
+// Routes of the original API, which only knows about the active loan.
+func AddV1Routes(router *mux.Router) {
+	router.HandleFunc("/user", RequireUser(GetUser)).Methods("Get")
+	router.HandleFunc("/user", RequireUser(Idempotent(CreateUser))).Methods("Post")
+	router.HandleFunc("/user", RequireVerifiedUser(Idempotent(PatchUser))).Methods("Patch")
+	router.HandleFunc("/loan-request", RequireVerifiedUser(Idempotent(LoanRequestFun))).Methods("Post")
+	router.HandleFunc("/active-loan", RequireVerifiedUser(GetActiveLoan)).Methods("Get")
+	router.HandleFunc("/active-loan", RequireVerifiedUser(Idempotent(SelectLoanOffer))).Methods("Put")
+	router.HandleFunc("/active-loan", RequireVerifiedUser(Idempotent(DeleteActiveLoan))).Methods("Delete")
+	router.HandleFunc("/repay", RequireVerifiedUser(Idempotent(Repay))).Methods("Post")
+	router.HandleFunc("/loans", RequireVerifiedUser(GetLoans)).Methods("Get")
+	router.HandleFunc("/partners", RequireUser(GetPartners)).Methods("Get")
+	router.HandleFunc("/locations", RequireUser(GetLocations)).Methods("Get")
+	router.HandleFunc("/events", TokenFromQuery(RequireVerifiedUser(GetEvents))).Methods("Get")
+}
+
+// Every REST route behind the middleware, as served by main.
+func NewRouter() *mux.Router {
+	router := mux.NewRouter()
+	router.Use(TraceRequests)
+	router.Use(LogRequests)
+	router.Use(InstrumentRequests)
+	router.Use(RecoverPanics)
+	router.Use(AllowOrigins)
+	router.Use(SecureHeaders)
+	router.Use(LimitRequests)
+	// Preflight requests for every route. Registered first so that no route turns them away as the wrong method.
+	router.Methods("Options").HandlerFunc(HandleOptions)
+	// The unversioned routes are kept as aliases of v1 for existing clients.
+	AddV1Routes(router)
+	AddV1Routes(router.PathPrefix("/v1").Subrouter())
+	AddV2Routes(router.PathPrefix("/v2").Subrouter())
+	AddAdminRoutes(router.PathPrefix("/admin").Subrouter())
+	router.HandleFunc("/openapi.json", GetOpenApi).Methods("Get")
+	router.HandleFunc("/hc", HealthCheck).Methods("Get")
+	router.HandleFunc("/hc/live", Liveness).Methods("Get")
+	router.HandleFunc("/hc/ready", Readiness).Methods("Get")
+	router.HandleFunc("/config", RequireRole(GetConfig)).Methods("Get")
+	return router
+}
 
-	})
+func main() {
+	var err error
+	config, err = LoadConfig(os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	SetupLogging(config.LogFormat, config.logLevel)
+	if err := SetupTracing(); err != nil {
+		logger.Error("Failed to set up tracing", "err", err)
+		os.Exit(1)
+	}
 
-	returnDbClient <- dbClient
+	serverSigner, err = LoadServerSigner(config)
+	if err != nil {
+		panic(err)
+	}
 
+	err = LoadPartners(config.PartnersFile)
 	if err != nil {
-		http.Error(w, err.Error(), GetErrorCode(err))
-		return
+		panic(err)
 	}
 
-	if finalizedUser.EmploymentInfo == nil {
-		finalizedUser.EmploymentInfo = new(EmploymentInfo)
+	err = LoadCurrencies(config.CurrenciesFile)
+	if err != nil {
+		panic(err)
 	}
 
-	if finalizedUser.ResidenceInfo == nil {
-		finalizedUser.ResidenceInfo = new(ResidenceInfo)
+	fxRates, err = LoadStaticFxRates(config.FxRatesFile)
+	if err != nil {
+		panic(err)
 	}
 
-	json.NewEncoder(w).Encode(finalizedUser)
-}
+	err = LoadOpenApi(config.OpenApiFile)
+	if err != nil {
+		panic(err)
+	}
 
-func main() {
+	err = LoadWebhooks(config.WebhooksFile)
+	if err != nil {
+		panic(err)
+	}
 
-	seed_bytes, err := ioutil.ReadFile("stellar_seed.txt")
+	err = LoadRateLimits(config.RateLimitsFile)
 	if err != nil {
 		panic(err)
 	}
-	seed_string := string(seed_bytes)
-	from = &seed_string
+	rateLimiter = NewRateLimiter(config.RateLimitStore)
 
 	// Constructing the ERA driver
 	eraDriver = constructERADriver()
@@ -1329,30 +861,13 @@ func main() {
 	// Firebase Channels
 	authRequests = make(chan FirebaseAuthRequest)
 	authResponses = make(chan FirebaseAuthResponse)
-	authDone = make(chan bool)
 
 	// Database channels
-	getDbClient = make(chan *datastore.Client, kNumDbClients)
-	returnDbClient = make(chan *datastore.Client, kNumDbClients)
-	dbDone = make(chan bool)
+	getDbClient = make(chan *datastore.Client, config.NumDbClients)
+	returnDbClient = make(chan *datastore.Client, config.NumDbClients)
+
+	router := NewRouter()
 
-	router := mux.NewRouter()
-	router.HandleFunc("/user", HandleOptions).Methods("Options")
-	router.HandleFunc("/loan-request", HandleOptions).Methods("Options")
-	router.HandleFunc("/active-loan", HandleOptions).Methods("Options")
-	router.HandleFunc("/repay", HandleOptions).Methods("Options")
-	router.HandleFunc("/loans", HandleOptions).Methods("Options")
-	router.HandleFunc("/hc", HandleOptions).Methods("Options")
-	router.HandleFunc("/user", GetUser).Methods("Get")
-	router.HandleFunc("/user", CreateUser).Methods("Post")
-	router.HandleFunc("/user", PatchUser).Methods("Patch")
-	router.HandleFunc("/loan-request", LoanRequestFun).Methods("Post")
-	router.HandleFunc("/active-loan", GetActiveLoan).Methods("Get")
-	router.HandleFunc("/active-loan", SelectLoanOffer).Methods("Put")
-	router.HandleFunc("/active-loan", DeleteActiveLoan).Methods("Delete")
-	router.HandleFunc("/repay", Repay).Methods("Post")
-	router.HandleFunc("/loans", GetLoans).Methods("Get")
-	router.HandleFunc("/hc", HealthCheck).Methods("Get")
 	cfg := &tls.Config{
 		MinVersion:               tls.VersionTLS12,
 		CurvePreferences:         []tls.CurveID{tls.CurveP521, tls.CurveP384, tls.CurveP256},
@@ -1365,17 +880,74 @@ func main() {
 		},
 	}
 	srv := &http.Server{
-		Addr:         ":443",
+		Addr:         config.Addr,
 		Handler:      router,
 		TLSConfig:    cfg,
 		TLSNextProto: make(map[string]func(*http.Server, *tls.Conn, http.Handler), 0),
 	}
 
-	go Auth()
-	go ManageDbClients()
+	StartService(Auth)
+	StartService(ManageDbClients)
+	StartWorker(ManageLocations)
+
+	err = MigrateLoanHistories()
+	if err != nil {
+		panic(err)
+	}
+
+	StartWorker(ExpireIdempotencyKeys)
+	StartWorker(ExpireRateLimits)
+	StartWorker(ManageEvents)
+	StartWorker(ManageWebhooks)
+	StartWorker(ManageLoanBookMetrics)
+	StartWorker(MonitorHealth)
+	StartWorker(AdvanceDisbursements)
+
+	if config.QinOnChain {
+		StartWorker(ReconcileQinLedger)
+	}
+
+	// Any server failing shuts the others down too.
+	serveErrors := make(chan error, 3)
+	servers := []*http.Server{srv}
+
+	var grpcServer *grpc.Server
+	if config.Grpc.Addr != "" {
+		var listener net.Listener
+		grpcServer, listener, err = ListenGrpc(config.Grpc)
+		if err != nil {
+			logger.Error("Failed to start gRPC server", "err", err)
+			os.Exit(1)
+		}
+		go func() {
+			serveErrors <- grpcServer.Serve(listener)
+		}()
+	}
+
+	if config.MetricsAddr != "" {
+		metricsSrv := NewMetricsServer(config.MetricsAddr)
+		servers = append(servers, metricsSrv)
+		go func() {
+			serveErrors <- metricsSrv.ListenAndServe()
+		}()
+	}
 
-	log.Fatal(srv.ListenAndServeTLS("server.crt", "server.key"))
+	go func() {
+		serveErrors <- srv.ListenAndServeTLS(config.CertFile, config.KeyFile)
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	exitCode := 0
+	select {
+	case sig := <-signals:
+		logger.Info("Shutting down", "signal", sig.String())
+	case err = <-serveErrors:
+		logger.Error("Server failed, shutting down", "err", err)
+		exitCode = 1
+	}
 
-	authDone <- true
-	dbDone <- true
+	Shutdown(grpcServer, servers...)
+	os.Exit(exitCode)
 }
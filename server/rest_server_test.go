@@ -0,0 +1,65 @@
+package main
+
+import (
+	"io/ioutil"
+	"log"
+	"log/slog"
+	"os"
+	"testing"
+
+	"firebase.google.com/go/auth"
+)
+
+// Tokens the fake Auth service accepts. Any other token fails to verify.
+const (
+	kTestVerifiedToken   string = "verified-token"
+	kTestUnverifiedToken string = "unverified-token"
+	kTestUid             string = "test-user"
+)
+
+// Answers auth requests by token instead of asking Firebase.
+func fakeAuth() {
+	for authRequest := range authRequests {
+		var response FirebaseAuthResponse
+		switch authRequest.Token {
+		case kTestVerifiedToken:
+			response.Success = true
+			response.UserInfo = auth.UserInfo{UID: kTestUid}
+		case kTestUnverifiedToken:
+			response.Error = ErrEmailNotValidated
+			response.UserInfo = auth.UserInfo{UID: kTestUid}
+		default:
+			response.Error = ErrAuthFailed
+		}
+		authResponses <- response
+	}
+}
+
+// Loads the local profile with the built-in partners, currencies and rate limits. Nothing reaches Datastore,
+// so tests stay on routes and functions that don't use it.
+func TestMain(m *testing.M) {
+	var err error
+	config, err = LoadConfig(nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+	logger = slog.New(slog.NewTextHandler(ioutil.Discard, nil))
+
+	for _, load := range []func() error{
+		func() error { return LoadPartners(config.PartnersFile) },
+		func() error { return LoadCurrencies(config.CurrenciesFile) },
+		func() error { return LoadOpenApi(config.OpenApiFile) },
+		func() error { return LoadRateLimits(config.RateLimitsFile) },
+	} {
+		if err := load(); err != nil {
+			log.Fatal(err)
+		}
+	}
+	rateLimiter = NewRateLimiter(kRateLimitStoreMemory)
+
+	authRequests = make(chan FirebaseAuthRequest)
+	authResponses = make(chan FirebaseAuthResponse)
+	go fakeAuth()
+
+	os.Exit(m.Run())
+}
@@ -0,0 +1,520 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"cloud.google.com/go/datastore"
+	"github.com/gorilla/mux"
+	"google.golang.org/api/iterator"
+)
+
+// Applications that match a review rule (see ReviewConfig) are held as PENDING_REVIEW instead of being approved
+// outright. The terms the ERAs offered wait on a loanReview entity next to the loan until a loan officer claims
+// it and approves it, possibly with edited terms or a lower amount, or rejects it with a reason. Every review is
+// due -review-sla after the application; reviews decided or still open after that are overdue.
+
+const kLoanReviewKind string = "loanReview"
+
+// Why an application was held for review.
+const (
+	kReviewAmountAboveThreshold string = "AMOUNT_ABOVE_THRESHOLD"
+	kReviewFirstLoan            string = "FIRST_LOAN"
+	kReviewEraDisagreement      string = "ERA_DISAGREEMENT"
+	kReviewMissingEmployment    string = "MISSING_EMPLOYMENT_DATA"
+)
+
+const (
+	kReviewOpen     string = "OPEN"
+	kReviewClaimed  string = "CLAIMED"
+	kReviewApproved string = "APPROVED"
+	kReviewRejected string = "REJECTED"
+	kReviewCanceled string = "CANCELED" // The borrower canceled the loan
+)
+
+var reviewStatuses = []string{kReviewOpen, kReviewClaimed, kReviewApproved, kReviewRejected, kReviewCanceled}
+
+var (
+	ErrReviewNotFound = NewAPIError(http.StatusNotFound, "REVIEW_NOT_FOUND", "Loan review was not found.")
+	ErrReviewClaimed  = NewAPIError(http.StatusConflict, "REVIEW_CLAIMED", "Another officer has claimed this loan review.")
+	ErrReviewClosed   = NewAPIError(http.StatusConflict, "REVIEW_CLOSED", "This loan review has already been decided.")
+)
+
+type LoanReview struct {
+	Uid           string      `json:"uid"`
+	LoanId        string      `json:"loanId"`
+	Amount        float64     `json:"amount" datastore:",noindex"` // As requested
+	CurrencyCode  string      `json:"currencyCode" datastore:",noindex"`
+	Reasons       []string    `json:"reasons"`
+	Status        string      `json:"status"`
+	Entered       int64       `json:"entered"` // Unix milliseconds
+	Due           int64       `json:"due"`     // When the SLA runs out
+	Officer       string      `json:"officer,omitempty"`
+	Claimed       int64       `json:"claimed,omitempty"`
+	Decided       int64       `json:"decided,omitempty"`
+	Note          string      `json:"note,omitempty" datastore:",noindex"` // The officer's note on approval, or why it was rejected
+	ProposedTerms []LoanTerms `json:"proposedTerms" datastore:",noindex"`  // As offered; the approved loan has the terms as edited
+	Overdue       bool        `json:"overdue" datastore:"-"`
+	Loan          *LoanRecord `json:"loan,omitempty" datastore:"-"` // Only when reading a single review
+}
+
+type LoanReviewPage struct {
+	Reviews    []LoanReview `json:"reviews"`
+	NextCursor string       `json:"nextCursor,omitempty"`
+}
+
+// Changes an officer can make to one of the proposed terms when approving. Fields left out stay as proposed.
+type TermsEdit struct {
+	TermId       string   `json:"id"`
+	InterestRate *float64 `json:"interestRate,omitempty"`
+	QinReward    *float64 `json:"qinReward,omitempty"`
+	QinRequired  *float64 `json:"qinRequired,omitempty"`
+}
+
+type ReviewApproval struct {
+	Amount *float64    `json:"amount,omitempty"` // Lend less than was asked for
+	Terms  []TermsEdit `json:"terms,omitempty"`
+	Note   string      `json:"note,omitempty"`
+}
+
+type ReviewRejection struct {
+	Reason string `json:"reason"`
+}
+
+func LoanReviewKey(uid string, loanId string) *datastore.Key {
+	return datastore.NameKey(kLoanReviewKind, loanId, UserKey(uid))
+}
+
+func nowMillis() int64 {
+	return time.Now().UnixNano() / int64(time.Millisecond)
+}
+
+// The rules an application matches, if any. terms are those offered for it.
+func ReviewReasons(basePrincipal float64, user *User, numLoans uint64, terms []LoanTerms) []string {
+	rules := config.Review
+	var reasons []string
+
+	if rules.AmountAbove > 0.0 && basePrincipal > rules.AmountAbove {
+		reasons = append(reasons, kReviewAmountAboveThreshold)
+	}
+	if rules.FirstLoan && numLoans == 0 {
+		reasons = append(reasons, kReviewFirstLoan)
+	}
+
+	if rules.EraSpread > 0.0 && len(terms) > 1 {
+		lowest, highest := terms[0].InterestRate, terms[0].InterestRate
+		for _, offered := range terms[1:] {
+			if offered.InterestRate < lowest {
+				lowest = offered.InterestRate
+			}
+			if offered.InterestRate > highest {
+				highest = offered.InterestRate
+			}
+		}
+		if highest-lowest > rules.EraSpread {
+			reasons = append(reasons, kReviewEraDisagreement)
+		}
+	}
+
+	employment := user.EmploymentInfo
+	if rules.MissingEmployment && (employment == nil || employment.EmploymentStatus == "" || employment.EmploymentIncome == nil || employment.EmploymentStartYear == nil) {
+		reasons = append(reasons, kReviewMissingEmployment)
+	}
+	return reasons
+}
+
+// An open review of a new loan, due -review-sla after the application.
+func newLoanReview(uid string, loanRecord *LoanRecord, reasons []string) *LoanReview {
+	return &LoanReview{
+		Uid:           uid,
+		LoanId:        loanRecord.LoanId,
+		Amount:        loanRecord.Amount,
+		CurrencyCode:  loanRecord.CurrencyCode,
+		Reasons:       reasons,
+		Status:        kReviewOpen,
+		Entered:       loanRecord.DateCreated,
+		Due:           loanRecord.DateCreated + int64(config.Review.Sla/time.Millisecond),
+		ProposedTerms: loanRecord.Terms,
+	}
+}
+
+// Holds a new loan for review, moving its terms onto the review. Written in the loan's transaction.
+func HoldForReview(tx *datastore.Transaction, uid string, loanRecord *LoanRecord, reasons []string) (*LoanReview, error) {
+	review := newLoanReview(uid, loanRecord, reasons)
+
+	loanRecord.State = "PENDING_REVIEW"
+	loanRecord.Terms = nil
+
+	_, err := tx.Put(LoanReviewKey(uid, loanRecord.LoanId), review)
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// Closes the review of a loan the borrower is canceling. Written in the loan's transaction.
+func CancelLoanReview(tx *datastore.Transaction, uid string, loanId string) (*LoanReview, error) {
+	var review LoanReview
+	err := tx.Get(LoanReviewKey(uid, loanId), &review)
+	if err == datastore.ErrNoSuchEntity {
+		return nil, ErrReviewNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	review.Status = kReviewCanceled
+	review.Decided = nowMillis()
+
+	_, err = tx.Put(LoanReviewKey(uid, loanId), &review)
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// Whether the review ran or is running past its SLA.
+func (review *LoanReview) IsOverdue(now int64) bool {
+	if review.Decided != 0 {
+		return review.Decided > review.Due
+	}
+	return now > review.Due
+}
+
+// Assigns an undecided review to officer, unless another officer has it.
+func (review *LoanReview) claim(officer string) error {
+	if review.Decided != 0 {
+		return ErrReviewClosed
+	}
+	if review.Officer != "" && review.Officer != officer {
+		return ErrReviewClaimed
+	}
+	if review.Officer == "" {
+		review.Officer = officer
+		review.Status = kReviewClaimed
+		review.Claimed = nowMillis()
+	}
+	return nil
+}
+
+// Applies approval edits to terms proposed for proposedAmount, for a loan of amount.
+func editTerms(terms []LoanTerms, edits []TermsEdit, proposedAmount float64, amount float64, currency *CurrencyConfig) ([]LoanTerms, error) {
+	var v Validator
+	edited := make([]LoanTerms, len(terms))
+	copy(edited, terms)
+
+	for i, edit := range edits {
+		var offered *LoanTerms
+		for j := range edited {
+			if edited[j].TermId == edit.TermId {
+				offered = &edited[j]
+			}
+		}
+		if offered == nil {
+			v.Fail("terms["+strconv.Itoa(i)+"].id", kFieldInvalidValue, "Must be the id of one of the proposed terms.")
+			continue
+		}
+
+		if edit.InterestRate != nil {
+			offered.InterestRate = Round(*edit.InterestRate*10000.0) / 10000.0
+		}
+		if edit.QinReward != nil {
+			offered.QinReward = Round(*edit.QinReward*100.0) / 100.0
+		}
+		if edit.QinRequired != nil {
+			offered.QinRequired = Round(*edit.QinRequired*100.0) / 100.0
+		}
+	}
+
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range edited {
+		previous := terms[i]
+		offered := &edited[i]
+
+		// The ERA is rewarded a fraction of the interest, so its reward follows the rate and the amount.
+		eraInterestReward := 0.0
+		if previous.Valuation != nil && previous.InterestRate > 0.0 {
+			eraInterestReward = previous.Valuation.EraInterestReward * (offered.InterestRate / previous.InterestRate) * (amount / proposedAmount)
+		}
+
+		offered.AmountOwed = currency.Round((1.0 + offered.InterestRate) * amount)
+		offered.Valuation = ValueTerms(offered, eraInterestReward)
+	}
+	return edited, nil
+}
+
+// Runs update on a review and its loan in a transaction. The loan is only written once update has moved it out
+// of PENDING_REVIEW.
+func UpdateLoanReview(ctx context.Context, uid string, loanId string, update func(review *LoanReview, loanRecord *LoanRecord) error) (*LoanReview, error) {
+	dbClient := GetDbClient()
+
+	var review LoanReview
+	key := LoanReviewKey(uid, loanId)
+
+	err := RunLoanTransaction(ctx, dbClient, uid, func(tx *datastore.Transaction, loanTx *LoanTx) error {
+		review = LoanReview{}
+		get_err := tx.Get(key, &review)
+		if get_err == datastore.ErrNoSuchEntity {
+			return ErrReviewNotFound
+		} else if get_err != nil {
+			return get_err
+		}
+
+		loanRecord, get_err := loanTx.Get(loanId)
+		if get_err != nil {
+			return get_err
+		}
+
+		update_err := update(&review, loanRecord)
+		if update_err != nil {
+			return update_err
+		}
+
+		if loanRecord.State != "PENDING_REVIEW" {
+			put_err := loanTx.Put(loanRecord)
+			if put_err != nil {
+				return put_err
+			}
+		}
+
+		_, put_err := tx.Put(key, &review)
+		review.Loan = loanRecord
+		return put_err
+	})
+
+	returnDbClient <- dbClient
+
+	if err != nil {
+		return nil, err
+	}
+
+	review.Overdue = review.IsOverdue(nowMillis())
+	if review.Decided != 0 {
+		ObserveReviewDecision(&review)
+	}
+	return &review, nil
+}
+
+// GET /admin/reviews lists reviews with a status, OPEN unless given, optionally only those of an officer.
+// The oldest come first, as they are closest to their SLA.
+func GetLoanReviews(w http.ResponseWriter, r *http.Request) {
+	values := r.URL.Query()
+	var v Validator
+
+	status := values.Get("status")
+	if status == "" {
+		status = kReviewOpen
+	}
+	v.OneOf("status", status, reviewStatuses...)
+
+	query := datastore.NewQuery(kLoanReviewKind).Filter("Status =", status)
+	if officer := values.Get("officer"); officer != "" {
+		query = query.Filter("Officer =", officer)
+	}
+	query = query.Order("Entered")
+
+	limit, cursor := parsePage(&v, values)
+	if cursor != nil {
+		query = query.Start(*cursor)
+	}
+
+	err := v.Err()
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	dbClient := GetDbClient()
+
+	var page LoanReviewPage
+	page.Reviews = make([]LoanReview, 0, limit)
+	now := nowMillis()
+
+	it := dbClient.Run(RequestContext(r), query.Limit(limit))
+	for true {
+		var review LoanReview
+		_, next_err := it.Next(&review)
+		if next_err != nil {
+			if next_err != iterator.Done {
+				err = next_err
+			}
+			break
+		}
+		review.Overdue = review.IsOverdue(now)
+		page.Reviews = append(page.Reviews, review)
+	}
+
+	if err == nil && len(page.Reviews) == limit {
+		next, cursor_err := it.Cursor()
+		if cursor_err == nil {
+			page.NextCursor = next.String()
+		}
+	}
+
+	returnDbClient <- dbClient
+
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(page)
+}
+
+// GET /admin/users/{uid}/loans/{id}/review reads a review along with its loan and the loan request.
+func GetLoanReview(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	ctx := RequestContext(r)
+
+	dbClient := GetDbClient()
+	var review LoanReview
+	var loanRecord LoanRecord
+	err := dbClient.Get(ctx, LoanReviewKey(vars["uid"], vars["id"]), &review)
+	if err == nil {
+		err = dbClient.Get(ctx, LoanKey(vars["uid"], vars["id"]), &loanRecord)
+	}
+	returnDbClient <- dbClient
+
+	if err == datastore.ErrNoSuchEntity {
+		err = ErrReviewNotFound
+	}
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	review.Overdue = review.IsOverdue(nowMillis())
+	review.Loan = &loanRecord
+	json.NewEncoder(w).Encode(review)
+}
+
+// POST /admin/users/{uid}/loans/{id}/review/claim assigns the review to the calling officer.
+func ClaimLoanReview(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	officer := RequestUid(r)
+
+	review, err := UpdateLoanReview(RequestContext(r), vars["uid"], vars["id"], func(review *LoanReview, loanRecord *LoanRecord) error {
+		return review.claim(officer)
+	})
+
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(review)
+}
+
+// POST /admin/users/{uid}/loans/{id}/review/approve approves the loan with the proposed terms, after any edits,
+// so the borrower can accept one. An unclaimed review is claimed by the calling officer first.
+func ApproveLoanReview(w http.ResponseWriter, r *http.Request) {
+	var approval ReviewApproval
+	err := decodeOptionalRequest(r, &approval)
+
+	if err == nil {
+		var v Validator
+		approval.Validate(&v)
+		err = v.Err()
+	}
+
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	vars := mux.Vars(r)
+	officer := RequestUid(r)
+
+	review, err := UpdateLoanReview(RequestContext(r), vars["uid"], vars["id"], func(review *LoanReview, loanRecord *LoanRecord) error {
+		claim_err := review.claim(officer)
+		if claim_err != nil {
+			return claim_err
+		}
+
+		currency, currency_err := CurrencyForCode(loanRecord.CurrencyCode)
+		if currency_err != nil {
+			return currency_err
+		}
+
+		amount := loanRecord.Amount
+		if approval.Amount != nil {
+			if *approval.Amount > loanRecord.Amount {
+				return ErrBadJsonPopulation.WithFields(FieldError{Field: "amount", Code: kFieldOutOfRange, Message: "Must not be more than was asked for."})
+			}
+			amount_err := currency.ValidateLoanAmount(*approval.Amount)
+			if amount_err != nil {
+				return amount_err
+			}
+			amount = *approval.Amount
+		}
+
+		terms, edit_err := editTerms(review.ProposedTerms, approval.Terms, review.Amount, amount, currency)
+		if edit_err != nil {
+			return edit_err
+		}
+
+		review.Status = kReviewApproved
+		review.Decided = nowMillis()
+		review.Note = approval.Note
+
+		loanRecord.Amount = amount
+		loanRecord.Terms = terms
+		loanRecord.State = "APPROVED"
+		return nil
+	})
+
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(review)
+}
+
+// POST /admin/users/{uid}/loans/{id}/review/reject rejects the loan with a reason, which is kept on the review.
+// An unclaimed review is claimed by the calling officer first.
+func RejectLoanReview(w http.ResponseWriter, r *http.Request) {
+	var rejection ReviewRejection
+	err := DecodeRequest(r, &rejection)
+
+	if err == nil {
+		var v Validator
+		rejection.Validate(&v)
+		err = v.Err()
+	}
+
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	vars := mux.Vars(r)
+	officer := RequestUid(r)
+
+	review, err := UpdateLoanReview(RequestContext(r), vars["uid"], vars["id"], func(review *LoanReview, loanRecord *LoanRecord) error {
+		claim_err := review.claim(officer)
+		if claim_err != nil {
+			return claim_err
+		}
+
+		review.Status = kReviewRejected
+		review.Decided = nowMillis()
+		review.Note = rejection.Reason
+
+		loanRecord.State = "REJECTED"
+		return nil
+	})
+
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(review)
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoanReviewIsOverdue(t *testing.T) {
+	const entered int64 = 1700000000000
+	due := entered + int64(24*time.Hour/time.Millisecond)
+
+	tests := []struct {
+		name    string
+		decided int64
+		now     int64
+		want    bool
+	}{
+		{"open within the SLA", 0, entered + 1, false},
+		{"open at the deadline", 0, due, false},
+		{"open past the SLA", 0, due + 1, true},
+		{"decided in time", due - 1, due + 1000, false},
+		{"decided at the deadline", due, due + 1000, false},
+		{"decided late", due + 1, due + 1000, true},
+		// Deciding late stays overdue, whenever it is looked at.
+		{"decided late, read early", due + 1, entered, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			review := LoanReview{Entered: entered, Due: due, Decided: test.decided}
+			if got := review.IsOverdue(test.now); got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestNewLoanReviewDue(t *testing.T) {
+	rules := config.Review
+	defer func() { config.Review = rules }()
+
+	const created int64 = 1700000000000
+	tests := []struct {
+		sla  time.Duration
+		want int64
+	}{
+		{24 * time.Hour, created + 86400000},
+		{90 * time.Minute, created + 5400000},
+		{1500 * time.Microsecond, created + 1}, // Rounded down to the millisecond
+	}
+
+	for _, test := range tests {
+		config.Review.Sla = test.sla
+		terms := []LoanTerms{{InterestRate: 0.1}}
+		review := newLoanReview("u", &LoanRecord{LoanId: "l", DateCreated: created, Terms: terms}, []string{kReviewFirstLoan})
+
+		if review.Entered != created || review.Due != test.want {
+			t.Errorf("with an SLA of %v: entered %d, due %d, want %d, %d", test.sla, review.Entered, review.Due, created, test.want)
+		}
+		if review.Status != kReviewOpen || !reflect.DeepEqual(review.ProposedTerms, terms) {
+			t.Errorf("got status %s with terms %v, want %s with the loan's terms", review.Status, review.ProposedTerms, kReviewOpen)
+		}
+		if review.IsOverdue(test.want) || !review.IsOverdue(test.want+1) {
+			t.Errorf("with an SLA of %v: not overdue exactly after it", test.sla)
+		}
+	}
+}
+
+func TestReviewReasons(t *testing.T) {
+	rules := config.Review
+	defer func() { config.Review = rules }()
+	config.Review = ReviewConfig{AmountAbove: 10000, FirstLoan: true, EraSpread: 0.05, MissingEmployment: true, Sla: rules.Sla}
+
+	income, startYear := 20000.0, int64(2020)
+	employed := &User{EmploymentInfo: &EmploymentInfo{EmploymentStatus: "EMPLOYED", EmploymentIncome: &income, EmploymentStartYear: &startYear}}
+	offers := func(rates ...float64) []LoanTerms {
+		terms := make([]LoanTerms, len(rates))
+		for i, rate := range rates {
+			terms[i].InterestRate = rate
+		}
+		return terms
+	}
+
+	tests := []struct {
+		name      string
+		principal float64
+		user      *User
+		numLoans  uint64
+		terms     []LoanTerms
+		want      []string
+	}{
+		{"none", 5000, employed, 2, offers(0.10, 0.12), nil},
+		{"at the threshold", 10000, employed, 2, offers(0.10), nil},
+		{"above the threshold", 10001, employed, 2, offers(0.10), []string{kReviewAmountAboveThreshold}},
+		{"first loan", 5000, employed, 0, offers(0.10), []string{kReviewFirstLoan}},
+		{"ERAs agree", 5000, employed, 2, offers(0.10, 0.15, 0.12), nil},
+		{"ERAs disagree", 5000, employed, 2, offers(0.10, 0.16, 0.12), []string{kReviewEraDisagreement}},
+		{"one offer", 5000, employed, 2, offers(0.50), nil},
+		{"no employment", 5000, &User{}, 2, offers(0.10), []string{kReviewMissingEmployment}},
+		{"no income", 5000, &User{EmploymentInfo: &EmploymentInfo{EmploymentStatus: "EMPLOYED", EmploymentStartYear: &startYear}}, 2, offers(0.10), []string{kReviewMissingEmployment}},
+		{"every rule", 20000, &User{}, 0, offers(0.05, 0.25), []string{kReviewAmountAboveThreshold, kReviewFirstLoan, kReviewEraDisagreement, kReviewMissingEmployment}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ReviewReasons(test.principal, test.user, test.numLoans, test.terms)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestReviewRulesOff(t *testing.T) {
+	rules := config.Review
+	defer func() { config.Review = rules }()
+	config.Review = ReviewConfig{Sla: rules.Sla}
+
+	if got := ReviewReasons(1e9, &User{}, 0, []LoanTerms{{InterestRate: 0}, {InterestRate: 1}}); got != nil {
+		t.Errorf("got %v with every rule off, want none", got)
+	}
+}
@@ -4,6 +4,8 @@
 go get cloud.google.com/go/datastore
 go get firebase.google.com/go
 go get github.com/gorilla/mux
+go get golang.org/x/crypto/nacl/secretbox
+go get golang.org/x/crypto/scrypt
 go get
 
 # Autolint in place
@@ -19,4 +21,4 @@ get_abs_filename() {
 
 export GOOGLE_APPLICATION_CREDENTIALS=$(get_abs_filename "./cloud_credentials.json")
 
-./server
+./server "$@"
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+
+	"github.com/OneDaijo/sbc-demo-backend/stellarutil"
+)
+
+// The server account's key is kept out of this process where possible: either in a signing sidecar
+// reached over a Unix socket (see signer_sidecar/) or in an encrypted keystore. A plain seed file is
+// still accepted for local development.
+
+var ErrSignerNotConfigured = errors.New("No signer is configured for the server account.")
+
+// Builds the server signer from whichever source was given; exactly one must be. A keystore is opened with
+// the keystore-passphrase or keystore-key secret.
+func LoadServerSigner(cfg *Config) (stellarutil.Signer, error) {
+	keystorePath, signerSocket, seedFile := cfg.Keystore, cfg.SignerSocket, cfg.SeedFile
+
+	configured := 0
+	for _, source := range []string{keystorePath, signerSocket, seedFile} {
+		if source != "" {
+			configured++
+		}
+	}
+	if configured != 1 {
+		return nil, ErrSignerNotConfigured
+	}
+
+	switch {
+	case signerSocket != "":
+		return stellarutil.NewSocketSigner(signerSocket)
+	case keystorePath != "":
+		creds, err := stellarutil.Credentials(cfg.KeystorePassphrase, cfg.KeystoreKey)
+		if err != nil {
+			return nil, err
+		}
+		return stellarutil.OpenKeystore(keystorePath, creds)
+	default:
+		logger.Warn("Signing with a plain text seed; use -keystore or -signer-socket outside development.")
+		seedBytes, err := ioutil.ReadFile(seedFile)
+		if err != nil {
+			return nil, err
+		}
+		return stellarutil.SeedSigner(strings.TrimSpace(string(seedBytes)))
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"net/http"
+
+	"golang.org/x/net/context"
+
+	"cloud.google.com/go/datastore"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Every REST request and gRPC call is traced, with spans for Firebase auth, Datastore transactions, each ERA's
+// assessment, federation lookups and Stellar transactions. Spans are exported over OTLP/gRPC to
+// -trace-endpoint, or printed to stdout for local use. A W3C traceparent header from the caller is continued
+// and one is passed on to partner anchors.
+
+const kServiceName string = "sbc-demo-backend"
+
+const (
+	kTraceExporterNone   string = "none"
+	kTraceExporterOtlp   string = "otlp"
+	kTraceExporterStdout string = "stdout"
+)
+
+var tracer = otel.Tracer("github.com/OneDaijo/sbc-demo-backend/server")
+
+// Nil if tracing is off.
+var tracerProvider *sdktrace.TracerProvider
+
+// Installs the configured exporter. Spans are dropped if it is "none".
+func SetupTracing() error {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch config.TraceExporter {
+	case kTraceExporterOtlp:
+		options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(config.TraceEndpoint)}
+		if config.TraceInsecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(context.Background(), options...)
+	case kTraceExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	tracerProvider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.TraceSampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", kServiceName),
+			attribute.String("deployment.environment", config.Profile),
+		)),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	return nil
+}
+
+// Exports the spans still buffered.
+func ShutdownTracing(ctx context.Context) {
+	if tracerProvider == nil {
+		return
+	}
+	if err := tracerProvider.Shutdown(ctx); err != nil {
+		logger.Warn("Failed to flush traces", "err", err)
+	}
+}
+
+// Ends a span, marking it failed if err is set.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Runs f in a Datastore transaction under a span named for what it does, e.g. "RegisterUser".
+func RunInTransaction(ctx context.Context, dbClient *datastore.Client, name string, f func(tx *datastore.Transaction) error) (*datastore.Commit, error) {
+	ctx, span := tracer.Start(ctx, "datastore.RunInTransaction", trace.WithAttributes(attribute.String("sbc.transaction", name)))
+
+	attempts := 0
+	commit, err := dbClient.RunInTransaction(ctx, func(tx *datastore.Transaction) error {
+		attempts++
+		return f(tx)
+	})
+
+	span.SetAttributes(attribute.Int("sbc.attempts", attempts))
+	endSpan(span, err)
+	return commit, err
+}
+
+// Router middleware starting a span for every request, named for its route template.
+func TraceRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := routeTemplate(r)
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracer.Start(ctx, r.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("http.route", route),
+			),
+		)
+		defer span.End()
+
+		writer := &statusWriter{ResponseWriter: w}
+		next.ServeHTTP(writer, r.WithContext(ctx))
+
+		if writer.status == 0 {
+			writer.status = http.StatusOK
+		}
+		span.SetAttributes(attribute.Int("http.response.status_code", writer.status))
+		if writer.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(writer.status))
+		}
+	})
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"time"
+
+	"golang.org/x/net/context"
+
+	"cloud.google.com/go/datastore"
+)
+
+// User profile operations shared by the REST handlers and the gRPC service. Requests are validated by the
+// caller; users come back with empty employment and residence info rather than none.
+
+func completeUser(user *User) *User {
+	if user.EmploymentInfo == nil {
+		user.EmploymentInfo = new(EmploymentInfo)
+	}
+
+	if user.ResidenceInfo == nil {
+		user.ResidenceInfo = new(ResidenceInfo)
+	}
+
+	return user
+}
+
+func ReadUser(ctx context.Context, uid string) (*User, error) {
+	dbClient := GetDbClient()
+
+	var user User
+	err := dbClient.Get(ctx, UserKey(uid), &user)
+
+	returnDbClient <- dbClient
+
+	if err != nil {
+		return nil, err
+	}
+	return completeUser(&user), nil
+}
+
+// Registers a new user, starting them with no QIN.
+func RegisterUser(ctx context.Context, uid string, user User) (*User, error) {
+	if user.StellarAddress != "" {
+		if err := VerifyStellarAccountForQin(ctx, user.StellarAddress); err != nil {
+			return nil, err
+		}
+	}
+
+	user.QinBalance = 0.0
+
+	user.DateCreated = time.Now().Unix() * 1000
+
+	dbClient := GetDbClient()
+
+	userKey := UserKey(uid)
+
+	_, err := RunInTransaction(ctx, dbClient, "RegisterUser", func(tx *datastore.Transaction) error {
+		var scratchUser User
+
+		// This should fail because the user should not exist
+		get_err := tx.Get(userKey, &scratchUser)
+		if get_err == nil {
+			return ErrUserAlreadyExists
+		} else if get_err != datastore.ErrNoSuchEntity {
+			return get_err
+		}
+
+		_, put_err := tx.Put(userKey, &user)
+		return put_err
+	})
+
+	returnDbClient <- dbClient
+
+	if err != nil {
+		return nil, err
+	}
+	return completeUser(&user), nil
+}
+
+// Replaces the employment info, residence info and Stellar address of a user with those set in patch.
+func UpdateUser(ctx context.Context, uid string, patch User) (*User, error) {
+	if patch.StellarAddress != "" {
+		if err := VerifyStellarAccountForQin(ctx, patch.StellarAddress); err != nil {
+			return nil, err
+		}
+	}
+
+	var finalizedUser *User
+
+	dbClient := GetDbClient()
+
+	userKey := UserKey(uid)
+
+	_, err := RunInTransaction(ctx, dbClient, "UpdateUser", func(tx *datastore.Transaction) error {
+		var existingUser User
+
+		// The user must exist
+		get_err := tx.Get(userKey, &existingUser)
+		if get_err != nil {
+			return get_err
+		}
+
+		if patch.EmploymentInfo != nil {
+			existingUser.EmploymentInfo = patch.EmploymentInfo
+		}
+
+		if patch.ResidenceInfo != nil {
+			existingUser.ResidenceInfo = patch.ResidenceInfo
+		}
+
+		if patch.StellarAddress != "" {
+			// QIN earned before the first link is still only off-chain; mint it to the new account so the
+			// two ledgers start out agreeing.
+			if config.QinOnChain && existingUser.StellarAddress == "" && existingUser.QinBalance > 0.0 {
+				existingUser.QinMigration = &QinMigration{Amount: existingUser.QinBalance}
+			}
+			existingUser.StellarAddress = patch.StellarAddress
+		}
+
+		_, put_err := tx.Put(userKey, &existingUser)
+		if put_err != nil {
+			return put_err
+		}
+
+		finalizedUser = &existingUser
+
+		return nil
+	})
+
+	returnDbClient <- dbClient
+
+	if err != nil {
+		return nil, err
+	}
+
+	if finalizedUser.QinMigration != nil {
+		SettleQinCollateralAsync(ctx, uid)
+	}
+	return completeUser(finalizedUser), nil
+}
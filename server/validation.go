@@ -0,0 +1,269 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Request payloads are checked field by field and every violation is reported at once, as the fields of an
+// ErrBadJsonPopulation. Field paths use the JSON names, e.g. "user.employmentInfo.employmentStatus".
+
+const kAgeOfMajority int = 18
+const kMaxAge int = 120
+const kMaxNameLength int = 100
+const kMaxTextLength int = 500
+const kDateOfBirthFormat string = "2006-01-02"
+
+// Field error codes
+const (
+	kFieldRequired      string = "REQUIRED"
+	kFieldInvalidValue  string = "INVALID_VALUE"
+	kFieldInvalidFormat string = "INVALID_FORMAT"
+	kFieldOutOfRange    string = "OUT_OF_RANGE"
+	kFieldTooLong       string = "TOO_LONG"
+	kFieldUnderAge      string = "UNDER_AGE"
+	kFieldNotAllowed    string = "NOT_ALLOWED"
+)
+
+// Mobile (09XX XXX XXXX) and landline ((02) 8XXX XXXX, (0XX) XXX XXXX) numbers, with 0, 63 or +63 in front.
+var phonePattern = regexp.MustCompile(`^(?:\+63|63|0)(?:9\d{9}|[2-8]\d{8})$`)
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "")
+var postalPattern = regexp.MustCompile(`^\d{4}$`)
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+type Validator struct {
+	fields []FieldError
+}
+
+func (v *Validator) Fail(field string, code string, message string) {
+	v.fields = append(v.fields, FieldError{Field: field, Code: code, Message: message})
+}
+
+func (v *Validator) Required(field string, value string) bool {
+	if strings.TrimSpace(value) == "" {
+		v.Fail(field, kFieldRequired, "This field is required.")
+		return false
+	}
+	return true
+}
+
+func (v *Validator) MaxLength(field string, value string, max int) {
+	if len(value) > max {
+		v.Fail(field, kFieldTooLong, fmt.Sprintf("Must be at most %d characters.", max))
+	}
+}
+
+func (v *Validator) OneOf(field string, value string, allowed ...string) {
+	for _, option := range allowed {
+		if value == option {
+			return
+		}
+	}
+	v.Fail(field, kFieldInvalidValue, "Must be one of "+strings.Join(allowed, ", ")+".")
+}
+
+func (v *Validator) Range(field string, value float64, min float64, max float64) {
+	if value < min || value > max {
+		v.Fail(field, kFieldOutOfRange, fmt.Sprintf("Must be between %v and %v.", min, max))
+	}
+}
+
+func (v *Validator) Matches(field string, value string, pattern *regexp.Regexp, message string) {
+	if !pattern.MatchString(value) {
+		v.Fail(field, kFieldInvalidFormat, message)
+	}
+}
+
+// Returns nil if every check passed.
+func (v *Validator) Err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return ErrBadJsonPopulation.WithFields(v.fields...)
+}
+
+// Decodes a request body, reporting the field of a type mismatch when there is one.
+func DecodeRequest(r *http.Request, v interface{}) error {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err == nil {
+		return nil
+	}
+
+	if typeErr, ok := err.(*json.UnmarshalTypeError); ok && typeErr.Field != "" {
+		return ErrBadJsonPopulation.WithFields(FieldError{
+			Field:   typeErr.Field,
+			Code:    kFieldInvalidValue,
+			Message: "Must be a " + typeErr.Type.String() + ".",
+		})
+	}
+	return ErrBadJsonPopulation
+}
+
+// Years between dateOfBirth and now, counting a year once its anniversary has passed.
+func ageOn(dateOfBirth time.Time, now time.Time) int {
+	age := now.Year() - dateOfBirth.Year()
+	if now.Month() < dateOfBirth.Month() || (now.Month() == dateOfBirth.Month() && now.Day() < dateOfBirth.Day()) {
+		age--
+	}
+	return age
+}
+
+// Fields every new user must provide. Employment and residence details can come later.
+func (user *User) Validate(v *Validator, path string, now time.Time) {
+	if v.Required(path+"firstName", user.Firstname) {
+		v.MaxLength(path+"firstName", user.Firstname, kMaxNameLength)
+	}
+	if v.Required(path+"lastName", user.Lastname) {
+		v.MaxLength(path+"lastName", user.Lastname, kMaxNameLength)
+	}
+
+	if v.Required(path+"phoneNumber", user.PhoneNum) {
+		v.Matches(path+"phoneNumber", phoneSeparators.Replace(user.PhoneNum), phonePattern, "Must be a Philippine mobile or landline number.")
+	}
+
+	if v.Required(path+"dateOfBirth", user.DateOfBirth) {
+		dateOfBirth, err := time.Parse(kDateOfBirthFormat, user.DateOfBirth)
+		if err != nil {
+			v.Fail(path+"dateOfBirth", kFieldInvalidFormat, "Must be a date formatted as YYYY-MM-DD.")
+		} else if age := ageOn(dateOfBirth, now); dateOfBirth.After(now) || age > kMaxAge {
+			v.Fail(path+"dateOfBirth", kFieldOutOfRange, "Must be a real date of birth.")
+		} else if age < kAgeOfMajority {
+			v.Fail(path+"dateOfBirth", kFieldUnderAge, fmt.Sprintf("Borrowers must be at least %d years old.", kAgeOfMajority))
+		}
+	}
+
+	if user.EmploymentInfo != nil {
+		user.EmploymentInfo.Validate(v, path+"employmentInfo.", now)
+	}
+	if user.ResidenceInfo != nil {
+		user.ResidenceInfo.Validate(v, path+"residenceInfo.")
+	}
+}
+
+// Checks a PATCH /user body, where only the parts being changed are sent.
+func (user *User) ValidatePatch(v *Validator, now time.Time) {
+	if user.EmploymentInfo == nil && user.ResidenceInfo == nil && user.StellarAddress == "" {
+		v.Fail("employmentInfo", kFieldRequired, "Provide employmentInfo, residenceInfo or stellarAddress.")
+	}
+	if user.EmploymentInfo != nil {
+		user.EmploymentInfo.Validate(v, "employmentInfo.", now)
+	}
+	if user.ResidenceInfo != nil {
+		user.ResidenceInfo.Validate(v, "residenceInfo.")
+	}
+}
+
+func (info *EmploymentInfo) Validate(v *Validator, path string, now time.Time) {
+	if v.Required(path+"employmentStatus", info.EmploymentStatus) {
+		v.OneOf(path+"employmentStatus", info.EmploymentStatus, "EMPLOYED", "UNEMPLOYED", "STUDENT")
+	}
+	v.MaxLength(path+"employmentJobTitle", info.EmploymentJobTitle, kMaxNameLength)
+	v.MaxLength(path+"employmentEducation", info.EmploymentEducation, kMaxNameLength)
+
+	if info.EmploymentStartMonth != nil {
+		v.Range(path+"employmentStartMonth", float64(*info.EmploymentStartMonth), 1, 12)
+	}
+	if info.EmploymentStartYear != nil {
+		v.Range(path+"employmentStartYear", float64(*info.EmploymentStartYear), float64(now.Year()-kMaxAge), float64(now.Year()))
+
+		if info.EmploymentStartMonth != nil && *info.EmploymentStartYear == int64(now.Year()) && *info.EmploymentStartMonth > int64(now.Month()) {
+			v.Fail(path+"employmentStartMonth", kFieldOutOfRange, "Employment cannot start in the future.")
+		}
+	}
+
+	if info.EmploymentIncome != nil && *info.EmploymentIncome < 0.0 {
+		v.Fail(path+"employmentIncome", kFieldOutOfRange, "Must not be negative.")
+	}
+}
+
+func (info *ResidenceInfo) Validate(v *Validator, path string) {
+	if v.Required(path+"residenceAddr1", info.ResidenceAddr1) {
+		v.MaxLength(path+"residenceAddr1", info.ResidenceAddr1, kMaxTextLength)
+	}
+	v.MaxLength(path+"residenceAddr2", info.ResidenceAddr2, kMaxTextLength)
+	v.MaxLength(path+"residenceDistrict", info.ResidenceDistrict, kMaxNameLength)
+	if v.Required(path+"residenceCity", info.ResidenceCity) {
+		v.MaxLength(path+"residenceCity", info.ResidenceCity, kMaxNameLength)
+	}
+	if v.Required(path+"residenceProvince", info.ResidenceProvince) {
+		v.MaxLength(path+"residenceProvince", info.ResidenceProvince, kMaxNameLength)
+	}
+	if info.ResidencePostal != "" {
+		v.Matches(path+"residencePostal", info.ResidencePostal, postalPattern, "Must be a 4 digit postal code.")
+	}
+
+	if v.Required(path+"residenceStatus", info.ResidenceStatus) {
+		v.OneOf(path+"residenceStatus", info.ResidenceStatus, "own", "rent")
+	}
+
+	if info.ResidenceRentAmt != nil && *info.ResidenceRentAmt < 0.0 {
+		v.Fail(path+"residenceRentAmt", kFieldOutOfRange, "Must not be negative.")
+	} else if info.ResidenceRentAmt == nil && info.ResidenceStatus == "rent" {
+		v.Fail(path+"residenceRentAmt", kFieldRequired, "Required when renting.")
+	}
+}
+
+// Amount limits depend on the currency and are checked against its configuration afterwards.
+func (request *LoanRequest) Validate(v *Validator) {
+	if request.LoanAmount <= 0.0 {
+		v.Fail("loanAmount", kFieldOutOfRange, "Must be greater than 0.")
+	}
+	if request.Currency != "" {
+		v.Matches("currencyCode", request.Currency, currencyCodePattern, "Must be a 3 letter ISO 4217 currency code.")
+	}
+	v.MaxLength("loanMemo", request.LoanMemo, kMaxTextLength)
+	v.MaxLength("loanPurpose", request.LoanPurpose, kMaxNameLength)
+
+	if !request.TermsAgreed {
+		v.Fail("termsAgreed", kFieldRequired, "The loan terms must be agreed to.")
+	}
+
+	// Filled in from the stored user.
+	if request.User != nil {
+		v.Fail("user", kFieldNotAllowed, "Must not be provided.")
+	}
+}
+
+func (request *LoanSelectRequest) Validate(v *Validator) {
+	location := request.Location
+	if request.SelectedTerm == "" && location.LocationId == "" && location.LocationName == "" {
+		v.Fail("selectedTerm", kFieldRequired, "Provide selectedTerm, pickupLocation or both.")
+	}
+	v.MaxLength("selectedTerm", request.SelectedTerm, kMaxNameLength)
+	v.MaxLength("pickupLocation.locationId", location.LocationId, kMaxNameLength)
+	v.MaxLength("pickupLocation.locationName", location.LocationName, kMaxNameLength)
+	v.MaxLength("partnerId", request.PartnerId, kMaxNameLength)
+}
+
+func (approval *ReviewApproval) Validate(v *Validator) {
+	if approval.Amount != nil && *approval.Amount <= 0.0 {
+		v.Fail("amount", kFieldOutOfRange, "Must be greater than 0.")
+	}
+	for i, edit := range approval.Terms {
+		path := "terms[" + strconv.Itoa(i) + "]."
+		if v.Required(path+"id", edit.TermId) {
+			v.MaxLength(path+"id", edit.TermId, kMaxNameLength)
+		}
+		if edit.InterestRate != nil {
+			v.Range(path+"interestRate", *edit.InterestRate, 0.0, config.Era.MaxInterestRate)
+		}
+		if edit.QinReward != nil && *edit.QinReward < 0.0 {
+			v.Fail(path+"qinReward", kFieldOutOfRange, "Must not be negative.")
+		}
+		if edit.QinRequired != nil && *edit.QinRequired < 0.0 {
+			v.Fail(path+"qinRequired", kFieldOutOfRange, "Must not be negative.")
+		}
+	}
+	v.MaxLength("note", approval.Note, kMaxTextLength)
+}
+
+func (rejection *ReviewRejection) Validate(v *Validator) {
+	if v.Required("reason", rejection.Reason) {
+		v.MaxLength("reason", rejection.Reason, kMaxTextLength)
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The field and code of every failure, in order.
+func failures(v *Validator) []string {
+	var got []string
+	for _, field := range v.fields {
+		got = append(got, field.Field+" "+field.Code)
+	}
+	return got
+}
+
+func TestValidator(t *testing.T) {
+	digits := regexp.MustCompile(`^\d+$`)
+
+	tests := []struct {
+		name  string
+		check func(v *Validator)
+		want  []string
+	}{
+		{"required", func(v *Validator) { v.Required("a", "x") }, nil},
+		{"required missing", func(v *Validator) { v.Required("a", "") }, []string{"a REQUIRED"}},
+		{"required blank", func(v *Validator) { v.Required("a", " \t") }, []string{"a REQUIRED"}},
+		{"max length", func(v *Validator) { v.MaxLength("a", "abc", 3) }, nil},
+		{"too long", func(v *Validator) { v.MaxLength("a", "abcd", 3) }, []string{"a TOO_LONG"}},
+		{"one of", func(v *Validator) { v.OneOf("a", "own", "own", "rent") }, nil},
+		{"not one of", func(v *Validator) { v.OneOf("a", "Own", "own", "rent") }, []string{"a INVALID_VALUE"}},
+		{"range bounds", func(v *Validator) { v.Range("a", 1, 1, 12); v.Range("b", 12, 1, 12) }, nil},
+		{"below range", func(v *Validator) { v.Range("a", 0, 1, 12) }, []string{"a OUT_OF_RANGE"}},
+		{"above range", func(v *Validator) { v.Range("a", 13, 1, 12) }, []string{"a OUT_OF_RANGE"}},
+		{"matches", func(v *Validator) { v.Matches("a", "123", digits, "") }, nil},
+		{"does not match", func(v *Validator) { v.Matches("a", "12a", digits, "") }, []string{"a INVALID_FORMAT"}},
+		{"every failure", func(v *Validator) {
+			v.Required("a", "")
+			v.MaxLength("b", "abcd", 3)
+			v.Fail("c", kFieldNotAllowed, "")
+		}, []string{"a REQUIRED", "b TOO_LONG", "c NOT_ALLOWED"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var v Validator
+			test.check(&v)
+
+			if got := failures(&v); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+
+			err := v.Err()
+			if test.want == nil {
+				if err != nil {
+					t.Errorf("got error %v, want nil", err)
+				}
+			} else if apiErr, ok := err.(*APIError); !ok || apiErr.Code != ErrBadJsonPopulation.Code || len(apiErr.Fields) != len(test.want) {
+				t.Errorf("got error %#v, want %s with %d fields", err, ErrBadJsonPopulation.Code, len(test.want))
+			}
+		})
+	}
+}
+
+func TestValidateUser(t *testing.T) {
+	now := time.Date(2024, time.June, 15, 12, 0, 0, 0, time.UTC)
+	status := func(s string) *EmploymentInfo { return &EmploymentInfo{EmploymentStatus: s} }
+	number := func(n int64) *int64 { return &n }
+
+	valid := User{Firstname: "Juan", Lastname: "dela Cruz", PhoneNum: "0917 123 4567", DateOfBirth: "1990-01-31"}
+
+	tests := []struct {
+		name   string
+		change func(user *User)
+		want   []string
+	}{
+		{"valid", func(user *User) {}, nil},
+		{"landline", func(user *User) { user.PhoneNum = "(02) 8123 4567" }, nil},
+		{"international", func(user *User) { user.PhoneNum = "+639171234567" }, nil},
+		{"missing names", func(user *User) { user.Firstname, user.Lastname = "", "" }, []string{"user.firstName REQUIRED", "user.lastName REQUIRED"}},
+		{"long name", func(user *User) { user.Firstname = strings.Repeat("a", kMaxNameLength+1) }, []string{"user.firstName TOO_LONG"}},
+		{"bad phone", func(user *User) { user.PhoneNum = "12345" }, []string{"user.phoneNumber INVALID_FORMAT"}},
+		{"bad date", func(user *User) { user.DateOfBirth = "31/01/1990" }, []string{"user.dateOfBirth INVALID_FORMAT"}},
+		{"born in the future", func(user *User) { user.DateOfBirth = "2025-01-01" }, []string{"user.dateOfBirth OUT_OF_RANGE"}},
+		{"too old", func(user *User) { user.DateOfBirth = "1900-01-01" }, []string{"user.dateOfBirth OUT_OF_RANGE"}},
+		{"turns 18 today", func(user *User) { user.DateOfBirth = "2006-06-15" }, nil},
+		{"turns 18 tomorrow", func(user *User) { user.DateOfBirth = "2006-06-16" }, []string{"user.dateOfBirth UNDER_AGE"}},
+		{"bad employment", func(user *User) { user.EmploymentInfo = status("RETIRED") }, []string{"user.employmentInfo.employmentStatus INVALID_VALUE"}},
+		{"employed in the future", func(user *User) {
+			user.EmploymentInfo = status("EMPLOYED")
+			user.EmploymentInfo.EmploymentStartYear = number(2024)
+			user.EmploymentInfo.EmploymentStartMonth = number(7)
+		}, []string{"user.employmentInfo.employmentStartMonth OUT_OF_RANGE"}},
+		{"renting without rent", func(user *User) {
+			user.ResidenceInfo = &ResidenceInfo{ResidenceAddr1: "1 Rizal St", ResidenceCity: "Manila", ResidenceProvince: "Metro Manila", ResidenceStatus: "rent"}
+		}, []string{"user.residenceInfo.residenceRentAmt REQUIRED"}},
+		{"bad postal code", func(user *User) {
+			user.ResidenceInfo = &ResidenceInfo{ResidenceAddr1: "1 Rizal St", ResidenceCity: "Manila", ResidenceProvince: "Metro Manila", ResidenceStatus: "own", ResidencePostal: "10000"}
+		}, []string{"user.residenceInfo.residencePostal INVALID_FORMAT"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			user := valid
+			test.change(&user)
+
+			var v Validator
+			user.Validate(&v, "user.", now)
+			if got := failures(&v); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateLoanRequest(t *testing.T) {
+	valid := LoanRequest{LoanAmount: 1000, Currency: "PHP", TermsAgreed: true}
+
+	tests := []struct {
+		name   string
+		change func(request *LoanRequest)
+		want   []string
+	}{
+		{"valid", func(request *LoanRequest) {}, nil},
+		{"default currency", func(request *LoanRequest) { request.Currency = "" }, nil},
+		{"no amount", func(request *LoanRequest) { request.LoanAmount = 0 }, []string{"loanAmount OUT_OF_RANGE"}},
+		{"bad currency", func(request *LoanRequest) { request.Currency = "php" }, []string{"currencyCode INVALID_FORMAT"}},
+		{"terms not agreed", func(request *LoanRequest) { request.TermsAgreed = false }, []string{"termsAgreed REQUIRED"}},
+		{"user given", func(request *LoanRequest) { request.User = &User{} }, []string{"user NOT_ALLOWED"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := valid
+			test.change(&request)
+
+			var v Validator
+			request.Validate(&v)
+			if got := failures(&v); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
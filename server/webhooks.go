@@ -0,0 +1,526 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"cloud.google.com/go/datastore"
+	"github.com/gorilla/mux"
+	"google.golang.org/api/iterator"
+)
+
+// Partners subscribe to loan outcomes in webhooks.json. Deliveries are written in the same transaction as the
+// loan change, then POSTed by a background worker with an HMAC signature and retried with backoff. Every attempt
+// is recorded on the delivery, and failed deliveries can be replayed through the admin API.
+
+const kWebhookDeliveryKind string = "webhookDelivery"
+
+const (
+	kWebhookLoanApproved  string = "loan.approved"
+	kWebhookLoanRejected  string = "loan.rejected"
+	kWebhookLoanSent      string = "loan.sent"
+	kWebhookLoanRepaid    string = "loan.repaid"
+	kWebhookLoanDefaulted string = "loan.defaulted"
+	kWebhookLoanCanceled  string = "loan.canceled"
+	kWebhookEraSettled    string = "era.settled" // The loan whose terms an ERA offered was repaid or defaulted
+)
+
+var webhookEventTypes = []string{kWebhookLoanApproved, kWebhookLoanRejected, kWebhookLoanSent, kWebhookLoanRepaid, kWebhookLoanDefaulted, kWebhookLoanCanceled, kWebhookEraSettled}
+
+// Delivery states
+const (
+	kDeliveryPending   string = "pending"
+	kDeliveryDelivered string = "delivered"
+	kDeliveryFailed    string = "failed" // Gave up after kMaxWebhookAttempts
+)
+
+const kWebhookSignatureHeader string = "X-SBC-Signature"
+const kWebhookPollInterval time.Duration = 10 * time.Second
+const kWebhookTimeout time.Duration = 10 * time.Second
+const kWebhookBatchSize int = 50
+const kMaxWebhookAttempts int = 10
+
+// Attempt n waits kWebhookBackoff * 2^(n-1) after the previous one, up to kMaxWebhookBackoff.
+const kWebhookBackoff time.Duration = 30 * time.Second
+const kMaxWebhookBackoff time.Duration = 6 * time.Hour
+
+// Kept short; bodies are only for reading failures in the delivery log.
+const kMaxWebhookResponseLog int64 = 1000
+
+var ErrDeliveryNotFound = NewAPIError(http.StatusNotFound, "DELIVERY_NOT_FOUND", "Webhook delivery was not found.")
+
+type WebhookSubscription struct {
+	SubscriptionId string   `json:"id"`
+	Url            string   `json:"url"`
+	Events         []string `json:"events"`
+	SecretEnv      string   `json:"secretEnv"`           // Environment variable holding the signing secret
+	PartnerId      string   `json:"partnerId,omitempty"` // Only loans paid out or repaid through this partner
+	OfferedBy      string   `json:"offeredBy,omitempty"` // Only loans with terms from this ERA
+	secret         []byte
+}
+
+var webhookSubscriptions []*WebhookSubscription
+
+var webhookClient = &http.Client{Timeout: kWebhookTimeout}
+
+// The loan as partners see it, without the borrower's details.
+type WebhookLoan struct {
+	LoanId        string      `json:"id"`
+	State         string      `json:"state"`
+	Amount        float64     `json:"amount"`
+	CurrencyCode  string      `json:"currencyCode,omitempty"`
+	DateCreated   int64       `json:"created"`
+	DueDate       int64       `json:"dueDate,omitempty"`
+	RepaidDate    int64       `json:"repaidDate,omitempty"`
+	Terms         []LoanTerms `json:"loanTerms,omitempty"`
+	AcceptedTerms *LoanTerms  `json:"acceptedTerms,omitempty"`
+	PartnerId     string      `json:"partnerId,omitempty"`
+	LocationId    string      `json:"locationId,omitempty"`
+}
+
+type WebhookEvent struct {
+	EventId string      `json:"id"` // The same for every subscription and attempt, for deduplication
+	Type    string      `json:"type"`
+	Created int64       `json:"created"` // Unix milliseconds
+	Loan    WebhookLoan `json:"loan"`
+}
+
+// Timestamps are Unix milliseconds.
+type WebhookDelivery struct {
+	DeliveryId     string `json:"id" datastore:"-"` // Encoded datastore key
+	SubscriptionId string `json:"subscriptionId"`
+	EventId        string `json:"eventId"`
+	EventType      string `json:"eventType"`
+	Payload        []byte `json:"-" datastore:",noindex"`
+	State          string `json:"state"`
+	Attempts       int    `json:"attempts"`
+	NextAttempt    int64  `json:"nextAttempt,omitempty"` // While pending
+	LastStatus     int    `json:"lastStatus,omitempty" datastore:",noindex"`
+	LastError      string `json:"lastError,omitempty" datastore:",noindex"`
+	Created        int64  `json:"created"`
+	Delivered      int64  `json:"delivered,omitempty" datastore:",noindex"`
+}
+
+type WebhookDeliveryPage struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+	NextCursor string            `json:"nextCursor,omitempty"`
+}
+
+func LoadWebhooks(path string) error {
+	bytes, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var subscriptions []*WebhookSubscription
+	if err := json.Unmarshal(bytes, &subscriptions); err != nil {
+		return err
+	}
+
+	for _, subscription := range subscriptions {
+		target, err := url.Parse(subscription.Url)
+		if err != nil || (target.Scheme != "https" && target.Scheme != "http") || target.Host == "" {
+			return fmt.Errorf("Invalid webhook url for %s", subscription.SubscriptionId)
+		}
+
+		for _, eventType := range subscription.Events {
+			known := false
+			for _, known_type := range webhookEventTypes {
+				known = known || eventType == known_type
+			}
+			if !known {
+				return fmt.Errorf("Unknown webhook event %s for %s", eventType, subscription.SubscriptionId)
+			}
+		}
+
+		secret := os.Getenv(subscription.SecretEnv)
+		if subscription.SecretEnv == "" || secret == "" {
+			return fmt.Errorf("No webhook secret for %s", subscription.SubscriptionId)
+		}
+		subscription.secret = []byte(secret)
+		os.Unsetenv(subscription.SecretEnv)
+	}
+
+	webhookSubscriptions = subscriptions
+	return nil
+}
+
+func (subscription *WebhookSubscription) Matches(eventType string, loanRecord *LoanRecord) bool {
+	subscribed := false
+	for _, subscribedType := range subscription.Events {
+		subscribed = subscribed || subscribedType == eventType
+	}
+	if !subscribed {
+		return false
+	}
+
+	if subscription.PartnerId != "" {
+		paidOut := loanRecord.Disbursement != nil && loanRecord.Disbursement.PartnerId == subscription.PartnerId
+		paidIn := loanRecord.CashIn != nil && loanRecord.CashIn.PartnerId == subscription.PartnerId
+		if !paidOut && !paidIn {
+			return false
+		}
+	}
+
+	if subscription.OfferedBy != "" {
+		if loanRecord.AcceptedTerms != nil {
+			return loanRecord.AcceptedTerms.OfferedBy == subscription.OfferedBy
+		}
+		for _, terms := range loanRecord.Terms {
+			if terms.OfferedBy == subscription.OfferedBy {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// Webhook events for a loan going from previous, nil if it is new, to loanRecord.
+func WebhookEventTypes(previous *LoanRecord, loanRecord *LoanRecord) []string {
+	if previous != nil && previous.State == loanRecord.State {
+		return nil
+	}
+
+	var eventTypes []string
+	switch loanRecord.State {
+	case "APPROVED", "REJECTED", "SENT", "REPAID", "DEFAULTED", "CANCELED":
+		eventTypes = append(eventTypes, "loan."+strings.ToLower(loanRecord.State))
+	}
+	if (loanRecord.State == "REPAID" || loanRecord.State == "DEFAULTED") && loanRecord.AcceptedTerms != nil {
+		eventTypes = append(eventTypes, kWebhookEraSettled)
+	}
+	return eventTypes
+}
+
+func NewWebhookEvent(eventType string, loanRecord *LoanRecord) WebhookEvent {
+	var random [12]byte
+	rand.Read(random[:])
+
+	event := WebhookEvent{
+		EventId: "evt_" + hex.EncodeToString(random[:]),
+		Type:    eventType,
+		Created: time.Now().Unix() * 1000,
+		Loan: WebhookLoan{
+			LoanId:        loanRecord.LoanId,
+			State:         loanRecord.State,
+			Amount:        loanRecord.Amount,
+			CurrencyCode:  loanRecord.CurrencyCode,
+			DateCreated:   loanRecord.DateCreated,
+			DueDate:       loanRecord.DueDate,
+			RepaidDate:    loanRecord.RepaidDate,
+			Terms:         loanRecord.Terms,
+			AcceptedTerms: loanRecord.AcceptedTerms,
+		},
+	}
+	if loanRecord.Disbursement != nil {
+		event.Loan.PartnerId = loanRecord.Disbursement.PartnerId
+	}
+	if loanRecord.Location != nil {
+		event.Loan.LocationId = loanRecord.Location.LocationId
+	}
+	return event
+}
+
+// Writes the deliveries for a loan change within the transaction making it. They share the user's entity group.
+func PutWebhookDeliveries(tx *datastore.Transaction, uid string, previous *LoanRecord, loanRecord *LoanRecord) error {
+	if len(webhookSubscriptions) == 0 {
+		return nil
+	}
+
+	now := time.Now().Unix() * 1000
+	for _, eventType := range WebhookEventTypes(previous, loanRecord) {
+		event := NewWebhookEvent(eventType, loanRecord)
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		for _, subscription := range webhookSubscriptions {
+			if !subscription.Matches(eventType, loanRecord) {
+				continue
+			}
+
+			delivery := WebhookDelivery{
+				SubscriptionId: subscription.SubscriptionId,
+				EventId:        event.EventId,
+				EventType:      eventType,
+				Payload:        payload,
+				State:          kDeliveryPending,
+				NextAttempt:    now,
+				Created:        now,
+			}
+			if _, err := tx.Put(datastore.IncompleteKey(kWebhookDeliveryKind, UserKey(uid)), &delivery); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func webhookSubscriptionForId(subscriptionId string) *WebhookSubscription {
+	for _, subscription := range webhookSubscriptions {
+		if subscription.SubscriptionId == subscriptionId {
+			return subscription
+		}
+	}
+	return nil
+}
+
+// Signs "<timestamp>.<body>" so that receivers can reject replayed requests by their age.
+func WebhookSignature(secret []byte, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(payload)
+	return "t=" + strconv.FormatInt(timestamp, 10) + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func webhookBackoff(attempts int) time.Duration {
+	backoff := kWebhookBackoff
+	for i := 1; i < attempts && backoff < kMaxWebhookBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > kMaxWebhookBackoff {
+		backoff = kMaxWebhookBackoff
+	}
+	return backoff
+}
+
+// Makes one attempt at a delivery and records its outcome on it.
+func attemptWebhookDelivery(delivery *WebhookDelivery, now time.Time) {
+	delivery.Attempts++
+
+	err := postWebhook(delivery, now)
+	if err == nil {
+		delivery.State = kDeliveryDelivered
+		delivery.Delivered = now.Unix() * 1000
+		delivery.NextAttempt = 0
+		delivery.LastError = ""
+		return
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= kMaxWebhookAttempts {
+		delivery.State = kDeliveryFailed
+		delivery.NextAttempt = 0
+	} else {
+		delivery.NextAttempt = now.Add(webhookBackoff(delivery.Attempts)).Unix() * 1000
+	}
+}
+
+func postWebhook(delivery *WebhookDelivery, now time.Time) error {
+	subscription := webhookSubscriptionForId(delivery.SubscriptionId)
+	if subscription == nil {
+		return errors.New("Subscription " + delivery.SubscriptionId + " no longer exists")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, subscription.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-SBC-Event-Id", delivery.EventId)
+	req.Header.Set("X-SBC-Event-Type", delivery.EventType)
+	req.Header.Set(kWebhookSignatureHeader, WebhookSignature(subscription.secret, now.Unix(), delivery.Payload))
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		delivery.LastStatus = 0
+		return err
+	}
+	defer resp.Body.Close()
+
+	delivery.LastStatus = resp.StatusCode
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, kMaxWebhookResponseLog))
+		return fmt.Errorf("%s: %s", resp.Status, body)
+	}
+	return nil
+}
+
+// Sends due deliveries until there are none left.
+func DeliverWebhooks(dbClient *datastore.Client) error {
+	ctx := context.Background()
+
+	for true {
+		now := time.Now()
+		query := datastore.NewQuery(kWebhookDeliveryKind).
+			Filter("State =", kDeliveryPending).
+			Filter("NextAttempt <=", now.Unix()*1000).
+			Order("NextAttempt").
+			Limit(kWebhookBatchSize)
+
+		var deliveries []WebhookDelivery
+		keys, err := dbClient.GetAll(ctx, query, &deliveries)
+		if err != nil {
+			return err
+		}
+
+		for i := range deliveries {
+			attemptWebhookDelivery(&deliveries[i], now)
+			if _, err := dbClient.Put(ctx, keys[i], &deliveries[i]); err != nil {
+				return err
+			}
+		}
+
+		if len(deliveries) < kWebhookBatchSize {
+			return nil
+		}
+	}
+	return nil
+}
+
+// Sends due deliveries every kWebhookPollInterval, and once more on shutdown so that none wait for a restart.
+func ManageWebhooks(ctx context.Context) {
+	for true {
+		stopping := !SleepUntilDone(ctx, kWebhookPollInterval)
+
+		if len(webhookSubscriptions) == 0 {
+			if stopping {
+				return
+			}
+			continue
+		}
+
+		dbClient := GetDbClient()
+		err := DeliverWebhooks(dbClient)
+		returnDbClient <- dbClient
+
+		if err != nil {
+			logger.Error("Failed to deliver webhooks", "err", err)
+		}
+
+		if stopping {
+			return
+		}
+	}
+}
+
+// GET /admin/webhooks/deliveries lists deliveries newest first, optionally only those in one state.
+func GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	values := r.URL.Query()
+	var v Validator
+
+	query := datastore.NewQuery(kWebhookDeliveryKind)
+	if state := values.Get("state"); state != "" {
+		v.OneOf("state", state, kDeliveryPending, kDeliveryDelivered, kDeliveryFailed)
+		query = query.Filter("State =", state)
+	}
+	query = query.Order("-Created")
+
+	limit := kDefaultLoanPageSize
+	if value := values.Get("limit"); value != "" {
+		var limit_err error
+		limit, limit_err = strconv.Atoi(value)
+		if limit_err != nil {
+			v.Fail("limit", kFieldInvalidValue, "Must be a whole number.")
+		} else {
+			v.Range("limit", float64(limit), 1, float64(kMaxLoanPageSize))
+		}
+	}
+
+	if value := values.Get("cursor"); value != "" {
+		cursor, cursor_err := datastore.DecodeCursor(value)
+		if cursor_err != nil {
+			v.Fail("cursor", kFieldInvalidValue, "Must be a nextCursor from a previous page.")
+		}
+		query = query.Start(cursor)
+	}
+
+	err := v.Err()
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	dbClient := GetDbClient()
+
+	ctx := context.Background()
+	var page WebhookDeliveryPage
+	page.Deliveries = make([]WebhookDelivery, 0, limit)
+
+	it := dbClient.Run(ctx, query.Limit(limit))
+	for true {
+		var delivery WebhookDelivery
+		key, next_err := it.Next(&delivery)
+		if next_err != nil {
+			if next_err != iterator.Done {
+				err = next_err
+			}
+			break
+		}
+		delivery.DeliveryId = key.Encode()
+		page.Deliveries = append(page.Deliveries, delivery)
+	}
+
+	if err == nil && len(page.Deliveries) == limit {
+		cursor, cursor_err := it.Cursor()
+		if cursor_err == nil {
+			page.NextCursor = cursor.String()
+		}
+	}
+
+	returnDbClient <- dbClient
+
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(page)
+}
+
+// POST /admin/webhooks/deliveries/{id}/replay attempts a delivery again right away, whatever its state, and
+// leaves it pending with fresh retries if that fails.
+func ReplayWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	key, err := datastore.DecodeKey(mux.Vars(r)["id"])
+	if err != nil || key.Kind != kWebhookDeliveryKind {
+		WriteError(w, r, ErrDeliveryNotFound)
+		return
+	}
+
+	dbClient := GetDbClient()
+
+	ctx := context.Background()
+	var delivery WebhookDelivery
+	err = dbClient.Get(ctx, key, &delivery)
+
+	if err == nil {
+		delivery.State = kDeliveryPending
+		delivery.Attempts = 0
+		attemptWebhookDelivery(&delivery, time.Now())
+		_, err = dbClient.Put(ctx, key, &delivery)
+	}
+
+	returnDbClient <- dbClient
+
+	if err == datastore.ErrNoSuchEntity {
+		err = ErrDeliveryNotFound
+	}
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	delivery.DeliveryId = key.Encode()
+	json.NewEncoder(w).Encode(delivery)
+}
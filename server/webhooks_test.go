@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// Partners subscribe to loan.sent to learn about the payouts they have to make, so the event must name them.
+func TestLoanSentWebhookForPartner(t *testing.T) {
+	approved := LoanRecord{
+		LoanId:        "loan-1",
+		State:         "APPROVED",
+		Amount:        5000,
+		CurrencyCode:  "PHP",
+		AcceptedTerms: &LoanTerms{OfferedBy: "Kiva"},
+	}
+	sent := approved
+	markLoanSent(&sent, "bloom", time.Now())
+
+	eventTypes := WebhookEventTypes(&approved, &sent)
+	if len(eventTypes) != 1 || eventTypes[0] != kWebhookLoanSent {
+		t.Fatalf("got events %v, want %s", eventTypes, kWebhookLoanSent)
+	}
+
+	tests := []struct {
+		name         string
+		subscription WebhookSubscription
+		want         bool
+	}{
+		{"partner", WebhookSubscription{Events: []string{kWebhookLoanSent}, PartnerId: "bloom"}, true},
+		{"another partner", WebhookSubscription{Events: []string{kWebhookLoanSent}, PartnerId: "cebuana"}, false},
+		{"partner and ERA", WebhookSubscription{Events: []string{kWebhookLoanSent}, PartnerId: "bloom", OfferedBy: "Kiva"}, true},
+		{"another event", WebhookSubscription{Events: []string{kWebhookLoanRepaid}, PartnerId: "bloom"}, false},
+		{"every loan", WebhookSubscription{Events: []string{kWebhookLoanApproved, kWebhookLoanSent}}, true},
+	}
+	for _, test := range tests {
+		if got := test.subscription.Matches(kWebhookLoanSent, &sent); got != test.want {
+			t.Errorf("%s: matches %v, want %v", test.name, got, test.want)
+		}
+	}
+
+	event := NewWebhookEvent(kWebhookLoanSent, &sent)
+	if event.Loan.PartnerId != "bloom" || event.Loan.State != "SENT" || event.Loan.DueDate == 0 {
+		t.Errorf("got loan %+v in the event, want it SENT through bloom with a due date", event.Loan)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"log"
+
+	firebase "firebase.google.com/go"
+	"golang.org/x/net/context"
+)
+
+// Sets the admin API roles of a staff member, replacing any they had. No roles takes their access away.
+//   go run staff_roles.go -email someone@onedaijo.com support loan_officer
+
+var roles = map[string]bool{
+	"support":      true,
+	"loan_officer": true,
+	"finance":      true,
+	"admin":        true,
+}
+
+func main() {
+	email := flag.String("email", "", "Email the staff member signs in with")
+	flag.Parse()
+	if *email == "" {
+		log.Fatalf("-email is required")
+	}
+
+	granted := make([]interface{}, 0)
+	for _, role := range flag.Args() {
+		if !roles[role] {
+			log.Fatalf("unknown role %s; use support, loan_officer, finance or admin", role)
+		}
+		granted = append(granted, role)
+	}
+
+	// Pulls credentials from env var
+	app, err := firebase.NewApp(context.Background(), nil)
+	if err != nil {
+		log.Fatalf("firebase app creation error: %v", err)
+	}
+	client, err := app.Auth(context.Background())
+	if err != nil {
+		log.Fatalf("error getting Auth client: %v", err)
+	}
+
+	user, err := client.GetUserByEmail(context.Background(), *email)
+	if err != nil {
+		log.Fatalf("error looking up %s: %v", *email, err)
+	}
+
+	// Keeps any other claims.
+	claims := user.CustomClaims
+	if claims == nil {
+		claims = make(map[string]interface{})
+	}
+	claims["roles"] = granted
+
+	err = client.SetCustomUserClaims(context.Background(), user.UID, claims)
+	if err != nil {
+		log.Fatalf("error setting roles: %v", err)
+	}
+	log.Printf("%s (%s) now has roles %v", *email, user.UID, flag.Args())
+}
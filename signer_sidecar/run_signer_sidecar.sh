@@ -0,0 +1,15 @@
+#!/usr/bin/env bash
+
+# Installing required deps
+go get github.com/stellar/go/build
+go get golang.org/x/crypto/nacl/secretbox
+go get golang.org/x/crypto/scrypt
+go get
+
+# Autolint in place
+go fmt
+
+# Building binary
+go build || exit $
+
+./signer_sidecar "$@"
@@ -0,0 +1,133 @@
+package main
+
+// Holds the server account's signing key in its own process and signs for the server over a Unix socket.
+// The keystore is encrypted with a passphrase or key taken from the environment:
+//   SBC_KEYSTORE_PASSPHRASE=... ./signer_sidecar import -seed-file ../server/stellar_seed.txt
+//   SBC_KEYSTORE_PASSPHRASE=... ./signer_sidecar serve
+//   SBC_KEYSTORE_PASSPHRASE=... ./signer_sidecar rotate
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/OneDaijo/sbc-demo-backend/stellarutil"
+)
+
+const usage = `Usage: signer_sidecar [-keystore PATH] <command> [flags]
+
+Commands:
+  import  Create the keystore from a seed file, which should then be deleted
+  serve   Sign for the server over a Unix socket
+  rotate  Replace the signing key on the account and in the keystore
+`
+
+func importSeed(keystorePath string, creds stellarutil.KeystoreCredentials, args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	seedFile := fs.String("seed-file", "../server/stellar_seed.txt", "File holding the seed to import")
+	fs.Parse(args)
+
+	seedBytes, err := ioutil.ReadFile(*seedFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := stellarutil.CreateKeystore(keystorePath, strings.TrimSpace(string(seedBytes)), creds); err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("Keystore written to %s; delete %s once you have a backup.\n", keystorePath, *seedFile)
+}
+
+func serve(keystorePath string, creds stellarutil.KeystoreCredentials, args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	socketPath := fs.String("socket", "/run/sbc-signer/signer.sock", "Unix socket to listen on")
+	fs.Parse(args)
+
+	signer, err := stellarutil.OpenKeystore(keystorePath, creds)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// A socket left behind by a previous run would make Listen fail.
+	os.Remove(*socketPath)
+
+	// Only the owner (the user both processes run as) may connect.
+	oldMask := syscall.Umask(0177)
+	listener, err := net.Listen("unix", *socketPath)
+	syscall.Umask(oldMask)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-interrupts
+		listener.Close()
+	}()
+
+	log.Printf("Signing for %s with %s on %s\n", signer.Address(), signer.SigningKey(), *socketPath)
+
+	if err := http.Serve(listener, stellarutil.SignerHandler(signer)); err != nil && !strings.Contains(err.Error(), "use of closed network connection") {
+		log.Fatal(err)
+	}
+}
+
+func rotate(keystorePath string, creds stellarutil.KeystoreCredentials, args []string) {
+	fs := flag.NewFlagSet("rotate", flag.ExitOnError)
+	networkName := fs.String("network", stellarutil.TestNetwork.Name, "Network the account is on: testnet, standalone or public")
+	fs.Parse(args)
+
+	n, err := stellarutil.NetworkForName(*networkName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	signingKey, err := stellarutil.RotateKeystore(keystorePath, creds, n)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// A running sidecar picks the new key up from the keystore on its next signature.
+	log.Println("Now signing with", signingKey)
+}
+
+func main() {
+	keystorePath := flag.String("keystore", "/etc/sbc-signer/keystore.json", "Encrypted keystore file")
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	creds, err := stellarutil.CredentialsFromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	args := flag.Args()[1:]
+	switch flag.Arg(0) {
+	case "import":
+		importSeed(*keystorePath, creds, args)
+	case "serve":
+		serve(*keystorePath, creds, args)
+	case "rotate":
+		rotate(*keystorePath, creds, args)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
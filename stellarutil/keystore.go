@@ -0,0 +1,437 @@
+package stellarutil
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+
+	b "github.com/stellar/go/build"
+	"github.com/stellar/go/clients/horizon"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/xdr"
+)
+
+// A keystore file holds the signing keys of one account, each encrypted with a key derived from a
+// passphrase (scrypt) or supplied directly. Only the active key can be decrypted; retired keys are
+// kept for the record with their secrets removed.
+
+// Environment variables the credentials are read from. They are unset once read.
+const EnvKeystorePassphrase string = "SBC_KEYSTORE_PASSPHRASE"
+const EnvKeystoreKey string = "SBC_KEYSTORE_KEY" // 32 bytes, base64
+
+const (
+	kKdfScrypt string = "scrypt"
+	kKdfNone   string = "none"
+)
+
+const (
+	kKeyPending string = "pending"
+	kKeyActive  string = "active"
+	kKeyRetired string = "retired"
+)
+
+var (
+	ErrKeystoreCredentials = errors.New("Keystore passphrase or key is missing or invalid.")
+	ErrKeystoreDecrypt     = errors.New("Could not decrypt the keystore; wrong passphrase or key?")
+	ErrKeystoreExists      = errors.New("Keystore file already exists.")
+	ErrKeystoreNoActiveKey = errors.New("Keystore has no active key.")
+	ErrKeyNotSigner        = errors.New("Active keystore key is not a signer on the account.")
+)
+
+type KeystoreCredentials struct {
+	Passphrase string
+	Key        []byte // Used as is instead of deriving a key from Passphrase
+}
+
+func CredentialsFromEnv() (KeystoreCredentials, error) {
+	creds, err := Credentials(os.Getenv(EnvKeystorePassphrase), os.Getenv(EnvKeystoreKey))
+	if err != nil {
+		return creds, err
+	}
+
+	os.Unsetenv(EnvKeystoreKey)
+	os.Unsetenv(EnvKeystorePassphrase)
+	return creds, nil
+}
+
+// Credentials from a passphrase or a base64 encoded key, which is used instead if both are given.
+func Credentials(passphrase string, encodedKey string) (KeystoreCredentials, error) {
+	var creds KeystoreCredentials
+	if encodedKey != "" {
+		key, err := base64.StdEncoding.DecodeString(encodedKey)
+		if err != nil || len(key) != 32 {
+			return creds, ErrKeystoreCredentials
+		}
+		creds.Key = key
+	} else {
+		creds.Passphrase = passphrase
+		if creds.Passphrase == "" {
+			return creds, ErrKeystoreCredentials
+		}
+	}
+	return creds, nil
+}
+
+func (creds KeystoreCredentials) kdf() string {
+	if creds.Key != nil {
+		return kKdfNone
+	}
+	return kKdfScrypt
+}
+
+func (creds KeystoreCredentials) derive(kdf string, salt []byte) (*[32]byte, error) {
+	if kdf != creds.kdf() {
+		return nil, ErrKeystoreCredentials
+	}
+
+	var key [32]byte
+	if kdf == kKdfNone {
+		copy(key[:], creds.Key)
+		return &key, nil
+	}
+
+	derived, err := scrypt.Key([]byte(creds.Passphrase), salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, err
+	}
+	copy(key[:], derived)
+	return &key, nil
+}
+
+type keystoreKey struct {
+	Address    string `json:"address"`
+	Salt       string `json:"salt,omitempty"`
+	Nonce      string `json:"nonce,omitempty"`
+	Ciphertext string `json:"ciphertext,omitempty"`
+	State      string `json:"state"`
+	Created    int64  `json:"created"`
+	Retired    int64  `json:"retired,omitempty"`
+}
+
+type keystoreFile struct {
+	Account string         `json:"account"`
+	Kdf     string         `json:"kdf"`
+	Keys    []*keystoreKey `json:"keys"`
+}
+
+func (ks *keystoreFile) keyInState(state string) *keystoreKey {
+	for _, key := range ks.Keys {
+		if key.State == state {
+			return key
+		}
+	}
+	return nil
+}
+
+func (ks *keystoreFile) removeKey(remove *keystoreKey) {
+	keys := ks.Keys[:0]
+	for _, key := range ks.Keys {
+		if key != remove {
+			keys = append(keys, key)
+		}
+	}
+	ks.Keys = keys
+}
+
+func readKeystoreFile(path string) (*keystoreFile, error) {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var ks keystoreFile
+	if err := json.Unmarshal(bytes, &ks); err != nil {
+		return nil, err
+	}
+	return &ks, nil
+}
+
+// Replaces the file atomically so a crash never leaves a half written keystore.
+func writeKeystoreFile(path string, ks *keystoreFile) error {
+	bytes, err := json.MarshalIndent(ks, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".keystore")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(bytes); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func encryptKey(kp *keypair.Full, creds KeystoreCredentials, kdf string) (*keystoreKey, error) {
+	var salt [16]byte
+	var nonce [24]byte
+	if _, err := rand.Read(salt[:]); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, err
+	}
+
+	key, err := creds.derive(kdf, salt[:])
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext := secretbox.Seal(nil, []byte(kp.Seed()), &nonce, key)
+	return &keystoreKey{
+		Address:    kp.Address(),
+		Salt:       base64.StdEncoding.EncodeToString(salt[:]),
+		Nonce:      base64.StdEncoding.EncodeToString(nonce[:]),
+		Ciphertext: base64.StdEncoding.EncodeToString(ciphertext),
+		Created:    time.Now().Unix(),
+	}, nil
+}
+
+func decryptKey(entry *keystoreKey, creds KeystoreCredentials, kdf string) (*keypair.Full, error) {
+	salt, salt_err := base64.StdEncoding.DecodeString(entry.Salt)
+	nonceBytes, nonce_err := base64.StdEncoding.DecodeString(entry.Nonce)
+	ciphertext, ct_err := base64.StdEncoding.DecodeString(entry.Ciphertext)
+	if salt_err != nil || nonce_err != nil || ct_err != nil || len(nonceBytes) != 24 {
+		return nil, ErrKeystoreDecrypt
+	}
+
+	key, err := creds.derive(kdf, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	var nonce [24]byte
+	copy(nonce[:], nonceBytes)
+	seed, ok := secretbox.Open(nil, ciphertext, &nonce, key)
+	if !ok {
+		return nil, ErrKeystoreDecrypt
+	}
+
+	kp, err := keypair.Parse(string(seed))
+	if err != nil {
+		return nil, err
+	}
+	full, ok := kp.(*keypair.Full)
+	if !ok || full.Address() != entry.Address {
+		return nil, ErrKeystoreDecrypt
+	}
+	return full, nil
+}
+
+// Creates a keystore for the account of seed, with seed as its active key.
+func CreateKeystore(path string, seed string, creds KeystoreCredentials) error {
+	if _, err := os.Stat(path); err == nil {
+		return ErrKeystoreExists
+	}
+
+	kp, err := keypair.Parse(seed)
+	if err != nil {
+		return err
+	}
+	full, ok := kp.(*keypair.Full)
+	if !ok {
+		return ErrNotASeed
+	}
+
+	entry, err := encryptKey(full, creds, creds.kdf())
+	if err != nil {
+		return err
+	}
+	entry.State = kKeyActive
+
+	return writeKeystoreFile(path, &keystoreFile{
+		Account: full.Address(),
+		Kdf:     creds.kdf(),
+		Keys:    []*keystoreKey{entry},
+	})
+}
+
+// KeystoreSigner signs with the active key of a keystore file, picking up rotations as the file changes.
+type KeystoreSigner struct {
+	path  string
+	creds KeystoreCredentials
+
+	mu      sync.Mutex
+	modTime time.Time
+	account string
+	kp      *keypair.Full
+}
+
+func OpenKeystore(path string, creds KeystoreCredentials) (*KeystoreSigner, error) {
+	signer := &KeystoreSigner{path: path, creds: creds}
+	if err := signer.reload(); err != nil {
+		return nil, err
+	}
+	return signer, nil
+}
+
+// Must be called with mu held, or before the signer is shared.
+func (s *KeystoreSigner) reload() error {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return err
+	}
+	if s.kp != nil && info.ModTime().Equal(s.modTime) {
+		return nil
+	}
+
+	ks, err := readKeystoreFile(s.path)
+	if err != nil {
+		return err
+	}
+
+	active := ks.keyInState(kKeyActive)
+	if active == nil {
+		return ErrKeystoreNoActiveKey
+	}
+
+	kp, err := decryptKey(active, s.creds, ks.Kdf)
+	if err != nil {
+		return err
+	}
+
+	s.account = ks.Account
+	s.kp = kp
+	s.modTime = info.ModTime()
+	return nil
+}
+
+func (s *KeystoreSigner) Address() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.account
+}
+
+// Public key of the key currently used for signing.
+func (s *KeystoreSigner) SigningKey() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.kp.Address()
+}
+
+func (s *KeystoreSigner) SignHash(hash [32]byte) (xdr.DecoratedSignature, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Keep signing with the key we have if the file can't be read mid-rotation.
+	s.reload()
+	return s.kp.SignDecorated(hash[:])
+}
+
+func signerWeight(account horizon.Account, publicKey string) uint32 {
+	for _, signer := range account.Signers {
+		if signer.PublicKey == publicKey && signer.Weight > 0 {
+			return uint32(signer.Weight)
+		}
+	}
+	return 0
+}
+
+// Replaces the active key of a keystore with a new random key, both on the account and in the file.
+// The new key is saved before it is added to the account so an interrupted rotation never loses it;
+// running the rotation again finishes it. Returns the public key of the new signing key.
+func RotateKeystore(path string, creds KeystoreCredentials, n Network) (string, error) {
+	ks, err := readKeystoreFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	active := ks.keyInState(kKeyActive)
+	if active == nil {
+		return "", ErrKeystoreNoActiveKey
+	}
+
+	activeKp, err := decryptKey(active, creds, ks.Kdf)
+	if err != nil {
+		return "", err
+	}
+
+	hc := n.Client()
+	account, err := hc.LoadAccount(ks.Account)
+	if err != nil {
+		return "", DecodeError(err)
+	}
+
+	promote := func(next *keystoreKey) (string, error) {
+		active.State = kKeyRetired
+		active.Retired = time.Now().Unix()
+		active.Salt, active.Nonce, active.Ciphertext = "", "", ""
+		next.State = kKeyActive
+		if err := writeKeystoreFile(path, ks); err != nil {
+			return "", err
+		}
+		return next.Address, nil
+	}
+
+	// Finish or discard a rotation that was interrupted.
+	if pending := ks.keyInState(kKeyPending); pending != nil {
+		if signerWeight(account, pending.Address) > 0 && signerWeight(account, active.Address) == 0 {
+			return promote(pending)
+		}
+		ks.removeKey(pending)
+	}
+
+	weight := signerWeight(account, active.Address)
+	if weight == 0 {
+		return "", ErrKeyNotSigner
+	}
+
+	nextKp, err := keypair.Random()
+	if err != nil {
+		return "", err
+	}
+
+	next, err := encryptKey(nextKp, creds, ks.Kdf)
+	if err != nil {
+		return "", err
+	}
+	next.State = kKeyPending
+	ks.Keys = append(ks.Keys, next)
+
+	if err := writeKeystoreFile(path, ks); err != nil {
+		return "", err
+	}
+
+	// The master key can't be removed, only given no weight.
+	var retire interface{} = b.RemoveSigner(active.Address)
+	if active.Address == ks.Account {
+		retire = b.MasterWeight(0)
+	}
+
+	tx, err := b.Transaction(
+		b.SourceAccount{AddressOrSeed: ks.Account},
+		n.Mutator(),
+		b.AutoSequence{SequenceProvider: hc},
+		b.SetOptions(b.AddSigner(nextKp.Address(), weight)),
+		b.SetOptions(retire),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := SubmitTransaction(hc, tx, &seedSigner{kp: activeKp}); err != nil {
+		return "", err
+	}
+
+	return promote(next)
+}
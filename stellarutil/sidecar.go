@@ -0,0 +1,178 @@
+package stellarutil
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/stellar/go/xdr"
+)
+
+// The signing sidecar serves a Signer over HTTP on a Unix socket, so the API process can get
+// signatures without ever holding the key. Access is controlled by the socket's file permissions.
+
+var ErrSidecarResponse = errors.New("Signing sidecar returned an invalid response.")
+
+type addressResponse struct {
+	Address string `json:"address"`
+}
+
+type signRequest struct {
+	Hash string `json:"hash"` // hex
+}
+
+type signResponse struct {
+	Signature string `json:"signature"` // base64 XDR DecoratedSignature
+}
+
+// SignerHandler exposes a signer at GET /address and POST /sign.
+func SignerHandler(signer Signer) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/address", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		json.NewEncoder(w).Encode(addressResponse{Address: signer.Address()})
+	})
+
+	mux.HandleFunc("/sign", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var req signRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		hashBytes, err := hex.DecodeString(req.Hash)
+		if err != nil || len(hashBytes) != 32 {
+			http.Error(w, "hash must be 32 bytes of hex", http.StatusBadRequest)
+			return
+		}
+
+		var hash [32]byte
+		copy(hash[:], hashBytes)
+
+		sig, err := signer.SignHash(hash)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		encoded, err := xdr.MarshalBase64(sig)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		log.Printf("signed %s\n", req.Hash)
+		json.NewEncoder(w).Encode(signResponse{Signature: encoded})
+	})
+
+	return mux
+}
+
+// SocketSigner asks a signing sidecar for signatures.
+type SocketSigner struct {
+	client  *http.Client
+	address string
+}
+
+func NewSocketSigner(socketPath string) (*SocketSigner, error) {
+	s := &SocketSigner{
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, "unix", socketPath)
+				},
+			},
+		},
+	}
+
+	// The host is ignored; every request goes to the socket.
+	resp, err := s.client.Get("http://signer/address")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var address addressResponse
+	if err := decodeSidecarResponse(resp, &address); err != nil {
+		return nil, err
+	}
+	if address.Address == "" {
+		return nil, ErrSidecarResponse
+	}
+
+	s.address = address.Address
+	return s, nil
+}
+
+func decodeSidecarResponse(resp *http.Response, v interface{}) error {
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("signing sidecar returned %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func (s *SocketSigner) Address() string {
+	return s.address
+}
+
+// Ping checks that the sidecar still answers for the same account.
+func (s *SocketSigner) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://signer/address", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var address addressResponse
+	if err := decodeSidecarResponse(resp, &address); err != nil {
+		return err
+	}
+	if address.Address != s.address {
+		return ErrSidecarResponse
+	}
+	return nil
+}
+
+func (s *SocketSigner) SignHash(hash [32]byte) (xdr.DecoratedSignature, error) {
+	var sig xdr.DecoratedSignature
+
+	body, _ := json.Marshal(signRequest{Hash: hex.EncodeToString(hash[:])})
+	resp, err := s.client.Post("http://signer/sign", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return sig, err
+	}
+	defer resp.Body.Close()
+
+	var signed signResponse
+	if err := decodeSidecarResponse(resp, &signed); err != nil {
+		return sig, err
+	}
+
+	if err := xdr.SafeUnmarshalBase64(signed.Signature, &sig); err != nil {
+		return sig, ErrSidecarResponse
+	}
+	return sig, nil
+}
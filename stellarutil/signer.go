@@ -0,0 +1,69 @@
+package stellarutil
+
+import (
+	"errors"
+
+	b "github.com/stellar/go/build"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/xdr"
+)
+
+var ErrNotASeed = errors.New("Expected a secret seed.")
+
+// Signer signs for a Stellar account without handing out its secret key. The signing key may be
+// the account's master key or, after a rotation, another key listed as a signer on the account.
+type Signer interface {
+	// Address of the account being signed for.
+	Address() string
+	// Signs a transaction hash (or any 32 byte payload such as a SEP-10 challenge hash).
+	SignHash(hash [32]byte) (xdr.DecoratedSignature, error)
+}
+
+type seedSigner struct {
+	kp *keypair.Full
+}
+
+// SeedSigner signs with a seed held in memory. Meant for command line tools and throwaway accounts.
+func SeedSigner(seed string) (Signer, error) {
+	kp, err := keypair.Parse(seed)
+	if err != nil {
+		return nil, err
+	}
+	full, ok := kp.(*keypair.Full)
+	if !ok {
+		return nil, ErrNotASeed
+	}
+	return &seedSigner{kp: full}, nil
+}
+
+func (s *seedSigner) Address() string {
+	return s.kp.Address()
+}
+
+func (s *seedSigner) SignHash(hash [32]byte) (xdr.DecoratedSignature, error) {
+	return s.kp.SignDecorated(hash[:])
+}
+
+// Signs a transaction with every signer and returns the envelope in base64.
+func SignTransaction(tx *b.TransactionBuilder, signers ...Signer) (string, error) {
+	hash, err := tx.Hash()
+	if err != nil {
+		return "", err
+	}
+
+	// Signing with no seeds just builds the envelope.
+	txe, err := tx.Sign()
+	if err != nil {
+		return "", err
+	}
+
+	for _, signer := range signers {
+		sig, err := signer.SignHash(hash)
+		if err != nil {
+			return "", err
+		}
+		txe.E.Signatures = append(txe.E.Signatures, sig)
+	}
+
+	return txe.Base64()
+}
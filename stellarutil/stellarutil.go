@@ -0,0 +1,186 @@
+// Package stellarutil holds the Stellar helpers shared by the server and the command line tools.
+package stellarutil
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	b "github.com/stellar/go/build"
+	"github.com/stellar/go/clients/horizon"
+)
+
+// Network describes a Stellar network we can talk to.
+type Network struct {
+	Name         string
+	HorizonURL   string
+	Passphrase   string
+	FriendbotURL string // Empty if the network has no friendbot
+}
+
+var (
+	TestNetwork = Network{
+		Name:         "testnet",
+		HorizonURL:   "https://horizon-testnet.stellar.org",
+		Passphrase:   "Test SDF Network ; September 2015",
+		FriendbotURL: "https://horizon-testnet.stellar.org/friendbot",
+	}
+	// A local standalone network as run by the stellar/quickstart image.
+	StandaloneNetwork = Network{
+		Name:         "standalone",
+		HorizonURL:   "http://localhost:8000",
+		Passphrase:   "Standalone Network ; February 2017",
+		FriendbotURL: "http://localhost:8000/friendbot",
+	}
+	PublicNetwork = Network{
+		Name:       "public",
+		HorizonURL: "https://horizon.stellar.org",
+		Passphrase: "Public Global Stellar Network ; September 2015",
+	}
+)
+
+var ErrUnknownNetwork = errors.New("Unknown network.")
+var ErrNoFriendbot = errors.New("Network has no friendbot.")
+
+func NetworkForName(name string) (Network, error) {
+	switch name {
+	case TestNetwork.Name:
+		return TestNetwork, nil
+	case StandaloneNetwork.Name:
+		return StandaloneNetwork, nil
+	case PublicNetwork.Name:
+		return PublicNetwork, nil
+	default:
+		return Network{}, ErrUnknownNetwork
+	}
+}
+
+func (n Network) Client() *horizon.Client {
+	return &horizon.Client{
+		URL: n.HorizonURL,
+		HTTP: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+func (n Network) Mutator() b.Network {
+	return b.Network{Passphrase: n.Passphrase}
+}
+
+// Funds an account with the network's friendbot.
+func (n Network) Friendbot(address string) error {
+	if n.FriendbotURL == "" {
+		return ErrNoFriendbot
+	}
+
+	resp, err := http.Get(n.FriendbotURL + "?addr=" + address)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("friendbot returned %s", resp.Status)
+	}
+	return nil
+}
+
+// TransactionError is a failed submission with the result codes Horizon gave for it.
+type TransactionError struct {
+	Err             error
+	TransactionCode string
+	OperationCodes  []string
+}
+
+func (e *TransactionError) Error() string {
+	if e.TransactionCode == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%v (tx: %s, ops: %s)", e.Err, e.TransactionCode, strings.Join(e.OperationCodes, ", "))
+}
+
+// Turns a Horizon submission error into a TransactionError carrying its result codes where possible.
+func DecodeError(err error) error {
+	herr, isHorizonError := err.(*horizon.Error)
+	if !isHorizonError {
+		return err
+	}
+
+	resultCodes, codes_err := herr.ResultCodes()
+	if codes_err != nil || resultCodes == nil {
+		return &TransactionError{Err: err}
+	}
+
+	return &TransactionError{
+		Err:             err,
+		TransactionCode: resultCodes.TransactionCode,
+		OperationCodes:  resultCodes.OperationCodes,
+	}
+}
+
+// Whether Horizon evaluated a submission and turned it away, as opposed to an error such as a timeout that
+// leaves it unknown whether the transaction will be applied.
+func IsRejected(err error) bool {
+	txErr, ok := err.(*TransactionError)
+	return ok && txErr.TransactionCode != ""
+}
+
+// Whether a rejected transaction failed over one of its operations, e.g. a missing trustline or too little
+// balance, which building it again won't change. Other rejections, such as tx_bad_seq, tx_too_late or
+// tx_insufficient_fee, are about the transaction as signed and can succeed once it is rebuilt.
+func IsOperationRejected(err error) bool {
+	txErr, ok := err.(*TransactionError)
+	return ok && txErr.TransactionCode == "tx_failed"
+}
+
+// Signs a transaction with the given signers and submits it, decoding any failure.
+func SubmitTransaction(hc *horizon.Client, tx *b.TransactionBuilder, signers ...Signer) (horizon.TransactionSuccess, error) {
+	txeB64, err := SignTransaction(tx, signers...)
+	if err != nil {
+		return horizon.TransactionSuccess{}, err
+	}
+
+	return SubmitEnvelope(hc, txeB64)
+}
+
+// Submits a signed transaction envelope, decoding any failure. Submitting the same envelope again is safe: its
+// sequence number lets it be applied at most once.
+func SubmitEnvelope(hc *horizon.Client, txeB64 string) (horizon.TransactionSuccess, error) {
+	resp, err := hc.SubmitTransaction(txeB64)
+	if err != nil {
+		return resp, DecodeError(err)
+	}
+
+	return resp, nil
+}
+
+// Whether the transaction with a hash was applied successfully. Failed transactions are in the ledger too,
+// but moved nothing.
+func TransactionApplied(hc *horizon.Client, txHash string) (bool, error) {
+	resp, err := hc.HTTP.Get(strings.TrimSuffix(hc.URL, "/") + "/transactions/" + txHash)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("horizon returned %s looking up transaction %s", resp.Status, txHash)
+	}
+
+	// Older Horizons only record successful transactions and leave this out.
+	var record struct {
+		Successful *bool `json:"successful"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&record); err != nil {
+		return false, err
+	}
+	return record.Successful == nil || *record.Successful, nil
+}